@@ -0,0 +1,5 @@
+# All saved queries against the PostgreSQL database whose label contains "orders"
+data "superset_saved_queries" "orders" {
+  label_contains = "orders"
+  database_name  = "PostgreSQL"
+}
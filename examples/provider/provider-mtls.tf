@@ -0,0 +1,22 @@
+# Example: Superset behind an internal CA with mutual TLS and a proxy
+provider "superset" {
+  host     = "https://superset.internal.example.com"
+  username = "admin"
+  password = "admin_password"
+
+  ca_cert_file = "/etc/ssl/certs/internal-ca.pem"
+  client_cert  = file("${path.module}/certs/terraform.crt")
+  client_key   = file("${path.module}/certs/terraform.key")
+  proxy_url    = "http://proxy.internal.example.com:3128"
+
+  request_timeout = "2m" # Per-request timeout, defaults to 120s
+  max_retries     = 5    # Retries for 429/502/503/504 and reset connections
+  retry_max_wait  = "1m" # Upper bound for a single backoff sleep
+
+  max_concurrent_requests = 4 # Keep small Superset web workers from being overwhelmed
+  requests_per_second     = 10
+
+  extra_headers = {
+    "X-Requested-By" = "terraform"
+  }
+}
@@ -0,0 +1,32 @@
+# Example: OAuth2 client credentials (e.g. Keycloak) instead of a password login
+provider "superset" {
+  host = "https://superset.example.com"
+
+  auth {
+    oauth2 {
+      token_url     = "https://keycloak.example.com/realms/bi/protocol/openid-connect/token"
+      client_id     = "terraform"
+      client_secret = var.superset_client_secret
+      scopes        = ["openid"]
+    }
+  }
+}
+
+# Alternative: pre-issued access token
+# provider "superset" {
+#   host = "https://superset.example.com"
+#   auth {
+#     access_token = var.superset_access_token # or export SUPERSET_ACCESS_TOKEN
+#   }
+# }
+
+# Alternative: trusted AUTH_REMOTE_USER gateway
+# provider "superset" {
+#   host = "https://superset.example.com"
+#   auth {
+#     remote_user {
+#       header   = "X-Forwarded-User"
+#       username = "terraform-ci"
+#     }
+#   }
+# }
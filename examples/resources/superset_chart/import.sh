@@ -0,0 +1,3 @@
+# Chart can be imported by specifying either its numeric identifier or its UUID
+terraform import superset_chart.example 123
+terraform import superset_chart.example 7d9e2a1c-6b1f-4b7e-9a51-0f4c2f7c3e10
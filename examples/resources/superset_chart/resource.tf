@@ -0,0 +1,44 @@
+terraform {
+  required_providers {
+    superset = {
+      source = "svdimchenko/superset"
+    }
+  }
+}
+
+provider "superset" {
+  host     = "http://localhost:8088"
+  username = "admin"
+  password = "admin"
+}
+
+resource "superset_dataset" "orders" {
+  database_name = "PostgreSQL"
+  schema        = "public"
+  table_name    = "orders"
+}
+
+resource "superset_chart" "orders_by_day" {
+  slice_name    = "Orders by day"
+  viz_type      = "echarts_timeseries_line"
+  datasource_id = superset_dataset.orders.id
+  description   = "Daily order count"
+  cache_timeout = 600
+
+  params = jsonencode({
+    viz_type           = "echarts_timeseries_line"
+    x_axis             = "created_at"
+    time_grain_sqla    = "P1D"
+    metrics            = ["count"]
+    row_limit          = 10000
+    show_legend        = true
+    rich_tooltip       = true
+    adhoc_filters      = []
+    groupby            = []
+    truncate_metric    = true
+    comparison_type    = "values"
+    x_axis_sort_asc    = true
+    x_axis_time_format = "smart_date"
+    y_axis_format      = "SMART_NUMBER"
+  })
+}
@@ -0,0 +1,4 @@
+# Dashboard can be imported by specifying its numeric identifier, its UUID or its slug
+terraform import superset_dashboard.example 42
+terraform import superset_dashboard.example 3b0c8f4e-2d6a-4f1b-8c7e-5a9d1e2f3b4c
+terraform import superset_dashboard.example sales-overview
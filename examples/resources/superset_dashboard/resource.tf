@@ -0,0 +1,63 @@
+terraform {
+  required_providers {
+    superset = {
+      source = "svdimchenko/superset"
+    }
+  }
+}
+
+provider "superset" {
+  host     = "http://localhost:8088"
+  username = "admin"
+  password = "admin"
+}
+
+resource "superset_role" "sales" {
+  name = "Sales"
+}
+
+resource "superset_dashboard" "sales" {
+  dashboard_title = "Sales overview"
+  slug            = "sales-overview"
+  published       = true
+  roles           = [superset_role.sales.id]
+  css             = ".header-title { font-weight: bold; }"
+
+  json_metadata = jsonencode({
+    color_scheme      = "supersetColors"
+    refresh_frequency = 300
+  })
+}
+
+resource "superset_dashboard" "orders" {
+  dashboard_title = "Orders"
+
+  layout = {
+    tabs = [
+      {
+        title = "Overview"
+        rows = [
+          {
+            items = [
+              { chart_id = 12, width = 8, height = 60 },
+              { markdown = "## Orders\nDaily orders across all regions.", width = 4 },
+            ]
+          },
+          { divider = true },
+          {
+            items = [
+              { chart_uuid = "7d9e2a1c-6b1f-4b7e-9a51-0f4c2f7c3e10", width = 6 },
+              { chart_id = 13, width = 6 },
+            ]
+          },
+        ]
+      },
+      {
+        title = "Details"
+        rows = [
+          { items = [{ chart_id = 14, width = 12, height = 80 }] },
+        ]
+      },
+    ]
+  }
+}
@@ -0,0 +1,2 @@
+# Report schedule can be imported by specifying its numeric identifier
+terraform import superset_report_schedule.example 123
@@ -0,0 +1,57 @@
+terraform {
+  required_providers {
+    superset = {
+      source = "svdimchenko/superset"
+    }
+  }
+}
+
+provider "superset" {
+  host     = "http://localhost:8088"
+  username = "admin"
+  password = "admin"
+}
+
+# Weekly PDF of the sales dashboard, sent by email and to Slack
+resource "superset_report_schedule" "weekly_sales" {
+  type          = "Report"
+  name          = "Weekly sales"
+  crontab       = "0 9 * * mon"
+  timezone      = "Europe/Berlin"
+  dashboard_id  = 12
+  report_format = "PDF"
+
+  recipients {
+    type   = "Email"
+    target = "sales@example.com,finance@example.com"
+  }
+
+  recipients {
+    type   = "Slack"
+    target = "#sales"
+  }
+}
+
+# Alert checked every 15 minutes, sending the chart when more than 10 orders are late
+resource "superset_report_schedule" "late_orders" {
+  type          = "Alert"
+  name          = "Too many late orders"
+  crontab       = "*/15 * * * *"
+  chart_id      = 34
+  database_name = "PostgreSQL"
+  sql           = "SELECT count(*) FROM orders WHERE shipped_at IS NULL AND created_at < now() - interval '2 days'"
+
+  validator_type        = "operator"
+  validator_config_json = jsonencode({
+    op        = ">"
+    threshold = 10
+  })
+
+  grace_period    = 3600
+  working_timeout = 600
+
+  recipients {
+    type   = "Email"
+    target = "ops@example.com"
+  }
+}
@@ -0,0 +1,2 @@
+# Saved query can be imported by specifying its numeric identifier
+terraform import superset_saved_query.example 123
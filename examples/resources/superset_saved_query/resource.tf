@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    superset = {
+      source = "svdimchenko/superset"
+    }
+  }
+}
+
+provider "superset" {
+  host     = "http://localhost:8088"
+  username = "admin"
+  password = "admin"
+}
+
+resource "superset_saved_query" "orders_by_region" {
+  label         = "Orders by region"
+  description   = "Order count per region for the selected country"
+  database_name = "PostgreSQL"
+  schema        = "public"
+
+  sql = <<-SQL
+    SELECT region, count(*) AS orders
+    FROM orders
+    WHERE country = '{{ country }}'
+    GROUP BY region
+  SQL
+
+  template_parameters = jsonencode({
+    country = "DE"
+  })
+}
@@ -0,0 +1,151 @@
+package client
+
+import "context"
+
+// RoleAPI manages roles and the permissions granted to them.
+type RoleAPI interface {
+	FetchRoles(ctx context.Context) ([]Role, error)
+	GetRole(ctx context.Context, id int64) (*Role, error)
+	GetRoleIDByName(ctx context.Context, roleName string) (int64, error)
+	CreateRole(ctx context.Context, name string) (int64, error)
+	UpdateRole(ctx context.Context, id int64, name string) error
+	DeleteRole(ctx context.Context, id int64) error
+
+	GetRolePermissions(ctx context.Context, roleID int64) ([]Permission, error)
+	GetPermissionIDByNameAndView(ctx context.Context, permissionName, viewMenuName string) (int64, error)
+	GetPermissionViewMenuIDs(ctx context.Context, permissions []map[string]string) ([]int64, error)
+	ResolvePermissions(ctx context.Context, pairs []PermissionPair) (map[PermissionPair]int64, error)
+	UpdateRolePermissions(ctx context.Context, roleID int64, permissionIDs []int64) error
+	ClearRolePermissions(ctx context.Context, roleID int64) error
+}
+
+// UserAPI manages users.
+type UserAPI interface {
+	FetchUsers(ctx context.Context) ([]UserListItem, error)
+	GetUser(ctx context.Context, id int64) (*User, error)
+	CreateUser(ctx context.Context, username, firstName, lastName, email, password string, active bool, roles []int64) (int64, error)
+	UpdateUser(ctx context.Context, id int64, username, firstName, lastName, email, password string, active bool, roles []int64) error
+	DeleteUser(ctx context.Context, id int64) error
+}
+
+// DatabaseAPI manages database connections, including meta databases.
+type DatabaseAPI interface {
+	GetAllDatabases(ctx context.Context) ([]Database, error)
+	GetDatabasesInfos(ctx context.Context) (map[string]interface{}, error)
+	GetDatabaseConnectionByID(ctx context.Context, databaseID int64) (*Database, error)
+	GetDatabaseSchemasByID(ctx context.Context, databaseID int64) ([]string, error)
+	GetDatabaseIDByName(ctx context.Context, databaseName string) (int64, error)
+	GetDatabaseNameByID(ctx context.Context, databaseID int64) (string, error)
+	CreateDatabase(ctx context.Context, payload map[string]interface{}) (*Database, error)
+	UpdateDatabase(ctx context.Context, databaseID int64, payload map[string]interface{}) (*Database, error)
+	DeleteDatabase(ctx context.Context, databaseID int64) error
+
+	GetMetaDatabase(ctx context.Context, id int64) (*MetaDatabase, error)
+	FindMetaDatabaseByName(ctx context.Context, databaseName string) (*MetaDatabase, error)
+	CreateMetaDatabase(ctx context.Context, metaDB *MetaDatabase) (int64, error)
+	UpdateMetaDatabase(ctx context.Context, id int64, metaDB *MetaDatabase) error
+	DeleteMetaDatabase(ctx context.Context, id int64) error
+}
+
+// DatasetAPI manages datasets.
+type DatasetAPI interface {
+	GetAllDatasets(ctx context.Context) ([]Dataset, error)
+	GetDataset(ctx context.Context, id int64) (*Dataset, error)
+	GetDatasetIDByUUID(ctx context.Context, uuid string) (int64, error)
+	GetDatasetIDsByUUIDs(ctx context.Context, uuids []string) (map[string]int64, error)
+	GetDatasetChartCount(ctx context.Context, datasetID int64) (int, error)
+	CreateDataset(ctx context.Context, dataset DatasetRequest) (*Dataset, error)
+	UpdateDataset(ctx context.Context, id int64, dataset DatasetUpdateRequest) error
+	DeleteDataset(ctx context.Context, id int64) error
+	ImportDataset(ctx context.Context, bundle Bundle, overwrite bool, passwords string) error
+}
+
+// ChartAPI manages charts.
+type ChartAPI interface {
+	CreateChart(ctx context.Context, chart ChartRequest) (int64, error)
+	GetChart(ctx context.Context, id int64) (*Chart, error)
+	UpdateChart(ctx context.Context, id int64, chart ChartRequest) error
+	GetChartIDByUUID(ctx context.Context, uuid string) (int64, error)
+	GetChartIDsByUUIDs(ctx context.Context, uuids []string) (map[string]int64, error)
+	GetChartUUIDsByIDs(ctx context.Context, ids []int64) (map[string]int64, error)
+	GetChartDashboardCount(ctx context.Context, chartID int64) (int, error)
+	DeleteChart(ctx context.Context, id int64) error
+	ImportChart(ctx context.Context, bundle Bundle, overwrite bool, passwords string) error
+}
+
+// DashboardAPI manages dashboards and their embedding configuration.
+type DashboardAPI interface {
+	CreateDashboard(ctx context.Context, dashboard DashboardRequest) (int64, error)
+	GetDashboard(ctx context.Context, id int64) (*Dashboard, error)
+	UpdateDashboard(ctx context.Context, id int64, dashboard DashboardRequest) error
+	GetDashboardUUID(ctx context.Context, id int64) (string, error)
+	GetDashboardIDByUUID(ctx context.Context, uuid string) (int64, error)
+	GetDashboardIDBySlug(ctx context.Context, slug string) (int64, error)
+	DashboardExistsByID(ctx context.Context, id int64) (bool, error)
+	GetDashboardChartUUIDs(ctx context.Context, dashboardID int64) (map[string]int64, error)
+	LinkChartsToDashboard(ctx context.Context, chartIDs []int64, dashboardID int64) error
+	UnlinkChartsFromDashboard(ctx context.Context, chartIDs []int64, dashboardID int64) error
+	ClearDashboardLayout(ctx context.Context, dashboardID int64) error
+	SetDashboardRoles(ctx context.Context, dashboardID int64, roleIDs []int64) error
+	DeleteDashboard(ctx context.Context, id int64) error
+	ImportDashboard(ctx context.Context, bundle Bundle, overwrite bool, passwords string) error
+
+	GetDashboardEmbedded(ctx context.Context, dashboardID int64) (*DashboardEmbedded, error)
+	CreateDashboardEmbedded(ctx context.Context, dashboardID int64, allowedDomains []string) (*DashboardEmbedded, error)
+	DeleteDashboardEmbedded(ctx context.Context, dashboardID int64) error
+}
+
+// CSSTemplateAPI manages CSS templates.
+type CSSTemplateAPI interface {
+	GetCSSTemplate(ctx context.Context, id int) (*CSSTemplate, error)
+	FindCSSTemplateByName(ctx context.Context, name string) (*CSSTemplate, error)
+	FindCSSTemplatesByName(ctx context.Context, name string) ([]CSSTemplate, error)
+	CreateCSSTemplate(ctx context.Context, templateName, css string) (*CSSTemplate, error)
+	UpdateCSSTemplate(ctx context.Context, id int, templateName, css string) (*CSSTemplate, error)
+	DeleteCSSTemplate(ctx context.Context, id int) error
+}
+
+// RowLevelSecurityAPI manages row level security rules.
+type RowLevelSecurityAPI interface {
+	GetRowLevelSecurity(ctx context.Context, id int64) (*RowLevelSecurity, error)
+	CreateRowLevelSecurity(ctx context.Context, name string, tables []int64, clause string, roleIDs []int64, groupKey, filterType, description string) (int64, error)
+	UpdateRowLevelSecurity(ctx context.Context, id int64, name string, tables []int64, clause string, roleIDs []int64, groupKey, filterType, description string) error
+	DeleteRowLevelSecurity(ctx context.Context, id int64) error
+}
+
+// SavedQueryAPI manages SQL Lab saved queries.
+type SavedQueryAPI interface {
+	CreateSavedQuery(ctx context.Context, query SavedQueryRequest) (int64, error)
+	GetSavedQuery(ctx context.Context, id int64) (*SavedQuery, error)
+	UpdateSavedQuery(ctx context.Context, id int64, query SavedQueryRequest) error
+	DeleteSavedQuery(ctx context.Context, id int64) error
+	ListSavedQueries(ctx context.Context, filters ...Filter) ([]SavedQuery, error)
+}
+
+// ReportScheduleAPI manages alerts and reports.
+type ReportScheduleAPI interface {
+	CreateReportSchedule(ctx context.Context, report ReportScheduleRequest) (int64, error)
+	GetReportSchedule(ctx context.Context, id int64) (*ReportSchedule, error)
+	UpdateReportSchedule(ctx context.Context, id int64, report ReportScheduleRequest) error
+	DeleteReportSchedule(ctx context.Context, id int64) error
+}
+
+// SupersetAPI is everything the provider needs from Superset. *Client implements it against the
+// REST API; tests can substitute an in-memory fake.
+type SupersetAPI interface {
+	RoleAPI
+	UserAPI
+	DatabaseAPI
+	DatasetAPI
+	ChartAPI
+	DashboardAPI
+	CSSTemplateAPI
+	RowLevelSecurityAPI
+	SavedQueryAPI
+	ReportScheduleAPI
+
+	// Capabilities reports what the connected server supports.
+	Capabilities() Capabilities
+}
+
+var _ SupersetAPI = (*Client)(nil)
@@ -0,0 +1,359 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultRemoteUserHeader is the header a trusted gateway uses to pass the authenticated user to Superset.
+const DefaultRemoteUserHeader = "X-Remote-User"
+
+// Authenticator establishes credentials for a Client and attaches them to outgoing requests.
+type Authenticator interface {
+	// Authenticate obtains fresh credentials for c. NewClient calls it once, and the client
+	// calls it again, serialized, whenever Superset answers 401.
+	Authenticate(ctx context.Context, c *Client) error
+	// Apply adds the current credentials to an outgoing API request.
+	Apply(req *http.Request, c *Client)
+}
+
+// PasswordAuthenticator logs in through /api/v1/security/login with a username and password
+// and renews an expired access token with the refresh token before falling back to a new login.
+type PasswordAuthenticator struct {
+	Username string
+	Password string
+	// Provider is the Flask-AppBuilder login provider, 'db' or 'ldap'.
+	Provider string
+}
+
+// Authenticate implements Authenticator.
+func (a *PasswordAuthenticator) Authenticate(ctx context.Context, c *Client) error {
+	c.tokenMu.RLock()
+	canRefresh := c.Token != "" && c.RefreshToken != ""
+	c.tokenMu.RUnlock()
+
+	if canRefresh {
+		if err := c.refresh(ctx); err == nil {
+			return nil
+		}
+	}
+
+	if a.Username == "" {
+		return fmt.Errorf("access token expired and no credentials are configured to log in again")
+	}
+	return a.login(ctx, c)
+}
+
+// Apply implements Authenticator.
+func (a *PasswordAuthenticator) Apply(req *http.Request, c *Client) {
+	applyBearer(req, c)
+}
+
+// login sends an authentication request to the Superset API using the configured username, password, and provider.
+func (a *PasswordAuthenticator) login(ctx context.Context, c *Client) error {
+	endpoint := fmt.Sprintf("%s/api/v1/security/login", c.Host)
+	payload := map[string]interface{}{
+		"username": a.Username,
+		"password": a.Password,
+		"provider": a.Provider,
+		"refresh":  true,
+	}
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doWithRetry(ctx, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(jsonPayload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp, "authenticate with Superset")
+	}
+
+	var result struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if result.AccessToken == "" {
+		return fmt.Errorf("failed to retrieve access token from response")
+	}
+
+	// The refresh token is optional; without it an expired session falls back to a fresh login.
+	c.setSession(result.AccessToken, result.RefreshToken)
+	return nil
+}
+
+// AccessTokenAuthenticator sends a pre-issued access token as a bearer token.
+// The token cannot be renewed, so a 401 is replayed once and then reported.
+type AccessTokenAuthenticator struct {
+	Token string
+}
+
+// Authenticate implements Authenticator.
+func (a *AccessTokenAuthenticator) Authenticate(_ context.Context, c *Client) error {
+	if a.Token == "" {
+		return fmt.Errorf("access token is empty")
+	}
+	c.setSession(a.Token, "")
+	return nil
+}
+
+// Apply implements Authenticator.
+func (a *AccessTokenAuthenticator) Apply(req *http.Request, c *Client) {
+	applyBearer(req, c)
+}
+
+// OAuth2ClientCredentialsAuthenticator exchanges a client ID and secret for an access token
+// at an OAuth2 token endpoint (RFC 6749 section 4.4) and sends it as a bearer token.
+type OAuth2ClientCredentialsAuthenticator struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+// Authenticate implements Authenticator.
+func (a *OAuth2ClientCredentialsAuthenticator) Authenticate(ctx context.Context, c *Client) error {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(a.Scopes) > 0 {
+		form.Set("scope", strings.Join(a.Scopes, " "))
+	}
+	body := form.Encode()
+
+	// The token endpoint belongs to the identity provider, not Superset.
+	resp, err := c.doExternalWithRetry(ctx, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", a.TokenURL, strings.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+		req.SetBasicAuth(url.QueryEscape(a.ClientID), url.QueryEscape(a.ClientSecret))
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp, fmt.Sprintf("obtain OAuth2 access token from %s", a.TokenURL))
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if result.AccessToken == "" {
+		return fmt.Errorf("failed to retrieve access token from OAuth2 token response")
+	}
+	if result.TokenType != "" && !strings.EqualFold(result.TokenType, "bearer") {
+		return fmt.Errorf("unsupported OAuth2 token type %q", result.TokenType)
+	}
+
+	c.setSession(result.AccessToken, "")
+	return nil
+}
+
+// Apply implements Authenticator.
+func (a *OAuth2ClientCredentialsAuthenticator) Apply(req *http.Request, c *Client) {
+	applyBearer(req, c)
+}
+
+// RemoteUserAuthenticator authenticates through a trusted gateway that maps a header to
+// Superset's AUTH_REMOTE_USER. The header is sent with every request, and the session cookie
+// returned by /login/ stays in the client's cookie jar for endpoints that require a logged-in session.
+type RemoteUserAuthenticator struct {
+	// Header defaults to DefaultRemoteUserHeader.
+	Header   string
+	Username string
+}
+
+// Authenticate implements Authenticator.
+func (a *RemoteUserAuthenticator) Authenticate(ctx context.Context, c *Client) error {
+	if a.Username == "" {
+		return fmt.Errorf("remote user name is empty")
+	}
+
+	endpoint := fmt.Sprintf("%s/login/", c.Host)
+	resp, err := c.doWithRetry(ctx, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(a.header(), a.Username)
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// /login/ redirects to the welcome page once the gateway header was accepted.
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newAPIError(resp, fmt.Sprintf("authenticate remote user %q with Superset", a.Username))
+	}
+
+	// The session cookie set by /login/ is kept in the client's cookie jar.
+	c.setSession("", "")
+	return nil
+}
+
+// Apply implements Authenticator.
+func (a *RemoteUserAuthenticator) Apply(req *http.Request, _ *Client) {
+	req.Header.Set(a.header(), a.Username)
+}
+
+func (a *RemoteUserAuthenticator) header() string {
+	if a.Header != "" {
+		return a.Header
+	}
+	return DefaultRemoteUserHeader
+}
+
+// applyBearer sets the Authorization header from the client's current access token.
+func applyBearer(req *http.Request, c *Client) {
+	if token := c.accessToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// authenticator returns the configured Authenticator, defaulting to a password login with the
+// client's Username, Password and Provider.
+func (c *Client) authenticator() Authenticator {
+	if c.auth != nil {
+		return c.auth
+	}
+	return &PasswordAuthenticator{Username: c.Username, Password: c.Password, Provider: c.Provider}
+}
+
+// authenticate establishes the initial session through the configured Authenticator.
+func (c *Client) authenticate(ctx context.Context) error {
+	return c.authenticator().Authenticate(ctx, c)
+}
+
+// authorize attaches the current credentials to an outgoing API request.
+func (c *Client) authorize(req *http.Request) {
+	c.authenticator().Apply(req, c)
+}
+
+// accessToken returns the current JWT access token.
+func (c *Client) accessToken() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.Token
+}
+
+// setSession stores the credentials produced by an Authenticator. A new login may start a new
+// server-side session, so the cached CSRF token is dropped as well.
+func (c *Client) setSession(accessToken, refreshToken string) {
+	c.tokenMu.Lock()
+	c.Token = accessToken
+	c.RefreshToken = refreshToken
+	c.tokenMu.Unlock()
+
+	c.session.setCSRFToken("")
+}
+
+// do sends an authenticated request through the retry layer. When Superset answers 401 the
+// client renews its credentials through the Authenticator and replays the request exactly once.
+func (c *Client) do(ctx context.Context, build requestBuilder) (*http.Response, error) {
+	session := c.sessions.Load()
+
+	resp, err := c.doWithRetry(ctx, build)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	if err := c.reauthenticate(ctx, session); err != nil {
+		return nil, fmt.Errorf("re-authenticating with Superset after 401: %w", err)
+	}
+
+	return c.doWithRetry(ctx, build)
+}
+
+// reauthenticate obtains new credentials. Concurrent callers that were rejected in the same
+// session wait for a single renewal instead of each logging in again. Sessions are counted
+// rather than compared by token, as a remote user session has no token.
+func (c *Client) reauthenticate(ctx context.Context, staleSession uint64) error {
+	c.reauthMu.Lock()
+	defer c.reauthMu.Unlock()
+
+	if c.sessions.Load() != staleSession {
+		// Another request already renewed the session while we were waiting.
+		return nil
+	}
+
+	if err := c.authenticator().Authenticate(ctx, c); err != nil {
+		return err
+	}
+	c.sessions.Add(1)
+	return nil
+}
+
+// refresh exchanges the refresh token for a new access token via /api/v1/security/refresh.
+func (c *Client) refresh(ctx context.Context) error {
+	c.tokenMu.RLock()
+	refreshToken := c.RefreshToken
+	c.tokenMu.RUnlock()
+
+	if refreshToken == "" {
+		return fmt.Errorf("no refresh token available")
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v1/security/refresh", c.Host)
+	resp, err := c.doWithRetry(ctx, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, "POST", endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+refreshToken)
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp, "refresh access token")
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if result.AccessToken == "" {
+		return fmt.Errorf("failed to retrieve access token from refresh response")
+	}
+
+	c.tokenMu.Lock()
+	c.Token = result.AccessToken
+	c.tokenMu.Unlock()
+	return nil
+}
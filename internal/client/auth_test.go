@@ -0,0 +1,303 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenResponder answers 401 unless the request carries the expected bearer token.
+func tokenResponder(token, body string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("Authorization") != "Bearer "+token {
+			return httpmock.NewStringResponse(401, `{"msg": "Token has expired"}`), nil
+		}
+		return httpmock.NewStringResponse(200, body), nil
+	}
+}
+
+func TestDoRequest_RefreshesExpiredToken(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{
+		Host:         "http://test-host",
+		Token:        "expired-token",
+		RefreshToken: "refresh-token",
+	}
+
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/security/refresh",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "Bearer refresh-token", req.Header.Get("Authorization"))
+			return httpmock.NewStringResponse(200, `{"access_token": "fresh-token"}`), nil
+		})
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/42",
+		tokenResponder("fresh-token", `{"result": {"id": 42, "template_name": "Test", "css": "body {}"}}`))
+
+	tmpl, err := client.GetCSSTemplate(t.Context(), 42)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, tmpl.ID)
+	assert.Equal(t, "fresh-token", client.Token)
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["POST http://test-host/api/v1/security/refresh"])
+}
+
+func TestDoRequest_LogsInAgainWhenRefreshFails(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{
+		Host:         "http://test-host",
+		Username:     "admin",
+		Password:     "secret",
+		Provider:     "db",
+		Token:        "expired-token",
+		RefreshToken: "expired-refresh-token",
+	}
+
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/security/refresh",
+		httpmock.NewStringResponder(401, `{"msg": "Token has expired"}`))
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/security/login",
+		httpmock.NewStringResponder(200, `{"access_token": "login-token", "refresh_token": "new-refresh-token"}`))
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/42",
+		tokenResponder("login-token", `{"result": {"id": 42, "template_name": "Test", "css": "body {}"}}`))
+
+	tmpl, err := client.GetCSSTemplate(t.Context(), 42)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, tmpl.ID)
+	assert.Equal(t, "login-token", client.Token)
+	assert.Equal(t, "new-refresh-token", client.RefreshToken)
+}
+
+func TestDoRequest_ReplaysOnlyOnce(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{
+		Host:         "http://test-host",
+		Token:        "expired-token",
+		RefreshToken: "refresh-token",
+	}
+
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/security/refresh",
+		httpmock.NewStringResponder(200, `{"access_token": "still-rejected"}`))
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/42",
+		httpmock.NewStringResponder(401, `{"msg": "Not authorized"}`))
+
+	_, err := client.GetCSSTemplate(t.Context(), 42)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+	assert.Equal(t, 2, httpmock.GetCallCountInfo()["GET http://test-host/api/v1/css_template/42"])
+}
+
+func TestDoRequest_ConcurrentExpiryRefreshesOnce(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{
+		Host:         "http://test-host",
+		Token:        "expired-token",
+		RefreshToken: "refresh-token",
+	}
+
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/security/refresh",
+		httpmock.NewStringResponder(200, `{"access_token": "fresh-token"}`))
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/42",
+		tokenResponder("fresh-token", `{"result": {"id": 42, "template_name": "Test", "css": "body {}"}}`))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetCSSTemplate(t.Context(), 42)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["POST http://test-host/api/v1/security/refresh"])
+}
+
+func TestDoRequest_ConcurrentRemoteUserExpiryLogsInOnce(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var logins atomic.Int32
+	httpmock.RegisterResponder("GET", "http://test-host/login/",
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(200, `ok`)
+			resp.Header.Add("Set-Cookie", fmt.Sprintf("session=session-%d; Path=/", logins.Add(1)))
+			return resp, nil
+		})
+	// The session of the first login has expired on the server.
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/42",
+		func(req *http.Request) (*http.Response, error) {
+			if cookie, err := req.Cookie("session"); err != nil || cookie.Value == "session-1" {
+				time.Sleep(10 * time.Millisecond)
+				return httpmock.NewStringResponse(401, `{"msg": "Session expired"}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"result": {"id": 42, "template_name": "Test", "css": "body {}"}}`), nil
+		})
+
+	client, err := NewClient(t.Context(), Config{
+		Host: "http://test-host",
+		Auth: &RemoteUserAuthenticator{Username: "ci-bot"},
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetCSSTemplate(t.Context(), 42)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), logins.Load(), "the initial login and a single renewal")
+}
+
+func TestNewClient_AccessTokenAuthenticator(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client, err := NewClient(t.Context(), Config{
+		Host: "http://test-host",
+		Auth: &AccessTokenAuthenticator{Token: "pre-issued"},
+	})
+	assert.NoError(t, err)
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/42",
+		tokenResponder("pre-issued", `{"result": {"id": 42, "template_name": "Test", "css": "body {}"}}`))
+
+	tmpl, err := client.GetCSSTemplate(t.Context(), 42)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, tmpl.ID)
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()["POST http://test-host/api/v1/security/login"])
+}
+
+func TestNewClient_OAuth2ClientCredentialsAuthenticator(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://keycloak.local/realms/bi/protocol/openid-connect/token",
+		func(req *http.Request) (*http.Response, error) {
+			user, pass, ok := req.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "terraform", user)
+			assert.Equal(t, "s3cret", pass)
+			assert.NoError(t, req.ParseForm())
+			assert.Equal(t, "client_credentials", req.PostForm.Get("grant_type"))
+			assert.Equal(t, "openid superset", req.PostForm.Get("scope"))
+			return httpmock.NewStringResponse(200, `{"access_token": "oauth-token", "token_type": "Bearer", "expires_in": 300}`), nil
+		})
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/42",
+		tokenResponder("oauth-token", `{"result": {"id": 42, "template_name": "Test", "css": "body {}"}}`))
+
+	client, err := NewClient(t.Context(), Config{
+		Host: "http://test-host",
+		Auth: &OAuth2ClientCredentialsAuthenticator{
+			TokenURL:     "http://keycloak.local/realms/bi/protocol/openid-connect/token",
+			ClientID:     "terraform",
+			ClientSecret: "s3cret",
+			Scopes:       []string{"openid", "superset"},
+		},
+	})
+	assert.NoError(t, err)
+
+	tmpl, err := client.GetCSSTemplate(t.Context(), 42)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, tmpl.ID)
+}
+
+func TestNewClient_OAuth2TokenRequestStaysOutOfSession(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://test-host/oauth/token",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Empty(t, req.Header.Get("X-Gateway-Key"), "gateway headers are meant for Superset only")
+			resp := httpmock.NewStringResponse(200, `{"access_token": "oauth-token", "token_type": "Bearer"}`)
+			resp.Header.Set("Set-Cookie", "idp_session=abc; Path=/")
+			return resp, nil
+		})
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/42",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "gw-key", req.Header.Get("X-Gateway-Key"))
+			_, err := req.Cookie("idp_session")
+			assert.Error(t, err, "identity provider cookies must not join the Superset session")
+			return tokenResponder("oauth-token", `{"result": {"id": 42, "template_name": "Test", "css": "body {}"}}`)(req)
+		})
+
+	client, err := NewClient(t.Context(), Config{
+		Host:         "http://test-host",
+		Auth:         &OAuth2ClientCredentialsAuthenticator{TokenURL: "http://test-host/oauth/token", ClientID: "x", ClientSecret: "y"},
+		ExtraHeaders: map[string]string{"X-Gateway-Key": "gw-key"},
+	})
+	require.NoError(t, err)
+
+	_, err = client.GetCSSTemplate(t.Context(), 42)
+	assert.NoError(t, err)
+}
+
+func TestNewClient_OAuth2TokenEndpointFailure(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://keycloak.local/token",
+		httpmock.NewStringResponder(401, `{"error": "invalid_client"}`))
+
+	_, err := NewClient(t.Context(), Config{
+		Host: "http://test-host",
+		Auth: &OAuth2ClientCredentialsAuthenticator{TokenURL: "http://keycloak.local/token", ClientID: "x", ClientSecret: "y"},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid_client")
+}
+
+func TestNewClient_RemoteUserAuthenticator(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://test-host/login/",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "ci-bot", req.Header.Get("X-Forwarded-User"))
+			resp := httpmock.NewStringResponse(200, `ok`)
+			resp.Header.Add("Set-Cookie", "session=remote-session; Path=/")
+			return resp, nil
+		})
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/42",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "ci-bot", req.Header.Get("X-Forwarded-User"))
+			assert.Empty(t, req.Header.Get("Authorization"))
+			cookie, err := req.Cookie("session")
+			assert.NoError(t, err)
+			assert.Equal(t, "remote-session", cookie.Value)
+			return httpmock.NewStringResponse(200, `{"result": {"id": 42, "template_name": "Test", "css": "body {}"}}`), nil
+		})
+
+	client, err := NewClient(t.Context(), Config{
+		Host: "http://test-host",
+		Auth: &RemoteUserAuthenticator{Header: "X-Forwarded-User", Username: "ci-bot"},
+	})
+	assert.NoError(t, err)
+
+	_, err = client.GetCSSTemplate(t.Context(), 42)
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"sync"
+)
+
+// DefaultMaxBundleSize caps the ZIP of an import bundle when Config.MaxBundleSize is zero.
+const DefaultMaxBundleSize int64 = 512 << 20
+
+// ErrBundleTooLarge is returned when an import bundle grows past the client's maximum size.
+var ErrBundleTooLarge = errors.New("import bundle exceeds the maximum size")
+
+// Bundle writes a ZIP import bundle to w. The bundle is streamed to Superset as it is written,
+// and a retried upload calls it again, so it must be able to produce the bundle more than once.
+type Bundle func(w io.Writer) error
+
+// BundleBytes returns a Bundle for a ZIP that is already held in memory.
+func BundleBytes(data []byte) Bundle {
+	return func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	}
+}
+
+// bundleUpload streams a bundle as the multipart form Superset's import endpoints expect. Every
+// call to open starts a fresh pipe fed by its own goroutine, so retries and CSRF renewals resend
+// the whole form without the bundle ever being held in memory.
+type bundleUpload struct {
+	bundle   Bundle
+	fields   [][2]string
+	boundary string
+	maxSize  int64
+
+	mu      sync.Mutex
+	reader  *io.PipeReader
+	done    chan struct{}
+	written int64
+	err     error
+}
+
+func newBundleUpload(bundle Bundle, maxSize int64, overwrite bool, passwords string) *bundleUpload {
+	u := &bundleUpload{
+		bundle:   bundle,
+		boundary: multipart.NewWriter(io.Discard).Boundary(),
+		maxSize:  maxSize,
+	}
+	if overwrite {
+		u.fields = append(u.fields, [2]string{"overwrite", "true"})
+	}
+	if passwords != "" {
+		u.fields = append(u.fields, [2]string{"passwords", passwords})
+	}
+	return u
+}
+
+// contentType returns the form's content type. The boundary is fixed up front so that every
+// attempt matches it.
+func (u *bundleUpload) contentType() string {
+	return "multipart/form-data; boundary=" + u.boundary
+}
+
+// open starts writing the form into a new pipe and returns its reading end.
+func (u *bundleUpload) open() (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+
+	u.mu.Lock()
+	u.reader, u.done = pr, done
+	u.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		written, err := u.write(pw)
+		pw.CloseWithError(err)
+
+		u.mu.Lock()
+		if u.done == done {
+			u.written, u.err = written, err
+		}
+		u.mu.Unlock()
+	}()
+	return pr, nil
+}
+
+func (u *bundleUpload) write(w io.Writer) (int64, error) {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(u.boundary); err != nil {
+		return 0, err
+	}
+	part, err := mw.CreateFormFile("formData", "export.zip")
+	if err != nil {
+		return 0, err
+	}
+	zw := &limitedWriter{w: part, limit: u.maxSize}
+	if err := u.bundle(zw); err != nil {
+		return zw.n, err
+	}
+	for _, field := range u.fields {
+		if err := mw.WriteField(field[0], field[1]); err != nil {
+			return zw.n, err
+		}
+	}
+	return zw.n, mw.Close()
+}
+
+// finish stops the last attempt's writer if Superset answered before reading the whole form and
+// returns the size of the ZIP it sent together with the bundle's error, if any.
+func (u *bundleUpload) finish() (int64, error) {
+	u.mu.Lock()
+	reader, done := u.reader, u.done
+	u.mu.Unlock()
+	if reader == nil {
+		return 0, nil
+	}
+
+	reader.Close()
+	<-done
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if errors.Is(u.err, io.ErrClosedPipe) {
+		// The request ended before the bundle was read in full; its outcome is the response.
+		return u.written, nil
+	}
+	if u.err != nil {
+		return u.written, fmt.Errorf("write import bundle: %w", u.err)
+	}
+	return u.written, nil
+}
+
+// limitedWriter counts the bytes written through it and fails once they exceed limit.
+type limitedWriter struct {
+	w     io.Writer
+	limit int64
+	n     int64
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.n+int64(len(p)) > l.limit {
+		return 0, fmt.Errorf("%w of %d bytes", ErrBundleTooLarge, l.limit)
+	}
+	n, err := l.w.Write(p)
+	l.n += int64(n)
+	return n, err
+}
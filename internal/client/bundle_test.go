@@ -0,0 +1,150 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newImportServer serves the CSRF endpoint and hands every import request to handle.
+func newImportServer(t *testing.T, handle http.HandlerFunc) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/security/csrf_token/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"result": "csrf-token"}`)
+	})
+	mux.HandleFunc("POST /api/v1/dashboard/import/", handle)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return &Client{Host: srv.URL, Token: "test-token", httpClient: srv.Client()}
+}
+
+func TestImportDashboard_StreamsBundle(t *testing.T) {
+	zipData := bytes.Repeat([]byte("PK"), 64<<10)
+
+	c := newImportServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, int64(-1), r.ContentLength, "the bundle is streamed with chunked encoding")
+		assert.Equal(t, "csrf-token", r.Header.Get("X-CSRFToken"))
+
+		file, header, err := r.FormFile("formData")
+		require.NoError(t, err)
+		defer file.Close()
+		assert.Equal(t, "export.zip", header.Filename)
+		got, _ := io.ReadAll(file)
+		assert.Equal(t, zipData, got)
+		assert.Equal(t, "true", r.FormValue("overwrite"))
+		assert.Equal(t, `{"databases/examples.yaml": "pw"}`, r.FormValue("passwords"))
+
+		_, _ = io.WriteString(w, `{"message": "OK"}`)
+	})
+
+	require.NoError(t, c.ImportDashboard(t.Context(), BundleBytes(zipData), true, `{"databases/examples.yaml": "pw"}`))
+}
+
+func TestImportDashboard_RejectsOversizedBundle(t *testing.T) {
+	c := newImportServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	c.maxBundleSize = 1 << 10
+
+	err := c.ImportDashboard(t.Context(), BundleBytes(make([]byte, 4<<10)), false, "")
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrBundleTooLarge), "got %v", err)
+	assert.Contains(t, err.Error(), "of 1024 bytes")
+}
+
+func TestImportDashboard_SurfacesBundleErrors(t *testing.T) {
+	c := newImportServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	err := c.ImportDashboard(t.Context(), func(w io.Writer) error {
+		_, _ = w.Write([]byte("PK"))
+		return errors.New("reading dashboards/sales.yaml: no such file")
+	}, false, "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "write import bundle: reading dashboards/sales.yaml: no such file")
+}
+
+func TestImportDashboard_RetryResendsWholeBundle(t *testing.T) {
+	var attempts atomic.Int32
+	c := newImportServer(t, func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("formData")
+		require.NoError(t, err)
+		got, _ := io.ReadAll(file)
+		assert.Equal(t, "PK-bundle", string(got))
+
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = io.WriteString(w, `{"message": "OK"}`)
+	})
+	c.MaxRetries = 1
+	c.RetryMaxWait = time.Millisecond
+
+	var written atomic.Int32
+	err := c.ImportDashboard(t.Context(), func(w io.Writer) error {
+		written.Add(1)
+		_, err := io.WriteString(w, "PK-bundle")
+		return err
+	}, false, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), attempts.Load())
+	assert.Equal(t, int32(2), written.Load(), "every attempt writes the bundle again")
+}
+
+func TestImportDashboard_SlowUploadOutlastsRequestTimeout(t *testing.T) {
+	c := newImportServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = io.WriteString(w, `{"message": "OK"}`)
+	})
+	c.httpClient.Timeout = 100 * time.Millisecond
+
+	// The bundle takes longer to send than the request timeout allows for a whole request.
+	err := c.ImportDashboard(t.Context(), func(w io.Writer) error {
+		for i := 0; i < 5; i++ {
+			if _, err := w.Write(bytes.Repeat([]byte("PK"), 1<<10)); err != nil {
+				return err
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		return nil
+	}, false, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, 100*time.Millisecond, c.httpClient.Timeout, "the shared client keeps its timeout")
+}
+
+func TestImportDashboard_TimesOutWaitingForResponse(t *testing.T) {
+	c := newImportServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	c.httpClient.Timeout = 100 * time.Millisecond
+
+	err := c.ImportDashboard(t.Context(), BundleBytes([]byte("PK-bundle")), false, "")
+
+	var timeoutErr *ResponseTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, "Superset did not respond within 100ms of receiving the upload", err.Error())
+}
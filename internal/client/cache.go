@@ -0,0 +1,178 @@
+package client
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// cacheTTL bounds how long a Client trusts a cached lookup. Writes made through the client
+// invalidate the affected cache immediately; the TTL only covers changes made elsewhere.
+const cacheTTL = 5 * time.Minute
+
+// clientCache holds the lookups a Client memoizes. Every entry records the host it was loaded
+// from, so a client whose Host changes never serves another instance's objects.
+type clientCache struct {
+	databases   cachedList[Database]
+	roleIDs     cachedIDs
+	permissions permissionCatalogue
+}
+
+// cachedList holds the full result of one list endpoint.
+type cachedList[T any] struct {
+	mu      sync.Mutex
+	host    string
+	items   []T
+	fetched time.Time
+}
+
+// load returns the cached items for host, calling fetch when they are missing or expired.
+// Concurrent callers wait for a single fetch.
+func (l *cachedList[T]) load(host string, fetch func() ([]T, error)) ([]T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.items != nil && l.host == host && time.Since(l.fetched) < cacheTTL {
+		return l.items, nil
+	}
+
+	items, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		// Remember empty lists too.
+		items = []T{}
+	}
+	l.host, l.items, l.fetched = host, items, time.Now()
+	return items, nil
+}
+
+func (l *cachedList[T]) invalidate() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.items = nil
+}
+
+// cachedIDs maps names to the IDs seen while walking a list endpoint. Once a walk reaches the
+// end of the list the cache is complete and a missing name is known not to exist.
+type cachedIDs struct {
+	mu       sync.Mutex
+	host     string
+	ids      map[string]int64
+	complete bool
+	loaded   time.Time
+}
+
+// lookup returns the cached ID for key. complete reports whether the whole list is cached,
+// in which case a miss is authoritative.
+func (c *cachedIDs) lookup(host, key string) (id int64, ok, complete bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.fresh(host) {
+		return 0, false, false
+	}
+	id, ok = c.ids[key]
+	return id, ok, c.complete
+}
+
+func (c *cachedIDs) store(host, key string, id int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.fresh(host) {
+		c.host, c.ids, c.complete, c.loaded = host, map[string]int64{}, false, time.Now()
+	}
+	c.ids[key] = id
+}
+
+// markComplete records that every entry of the list has been stored.
+func (c *cachedIDs) markComplete(host string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.fresh(host) {
+		c.host, c.ids, c.loaded = host, map[string]int64{}, time.Now()
+	}
+	c.complete = true
+}
+
+func (c *cachedIDs) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ids, c.complete = nil, false
+}
+
+// fresh reports whether the cache holds unexpired entries for host. c.mu must be held.
+func (c *cachedIDs) fresh(host string) bool {
+	return c.ids != nil && c.host == host && time.Since(c.loaded) < cacheTTL
+}
+
+// permissionCatalogue indexes the permission-view pairs of a Superset instance by permission and
+// view menu name. It has no TTL: pairs only change when Superset creates them for new databases,
+// datasets or schemas, so the catalogue is reloaded in full whenever a lookup misses instead.
+type permissionCatalogue struct {
+	mu     sync.Mutex
+	host   string
+	ids    map[PermissionPair]int64
+	loaded time.Time
+}
+
+// resolve returns the IDs of pairs, calling fetch to reload the catalogue when any of them is
+// unknown. The pairs still unknown after the reload are returned as missing. Callers that
+// waited for a reload started after their own lookup began reuse it instead of fetching again.
+func (p *permissionCatalogue) resolve(host string, pairs []PermissionPair, fetch func() (map[PermissionPair]int64, error)) (ids map[PermissionPair]int64, missing []PermissionPair, err error) {
+	start := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.host != host {
+		p.host, p.ids, p.loaded = host, nil, time.Time{}
+	}
+
+	ids, missing = p.lookup(pairs)
+	if len(missing) == 0 || p.loaded.After(start) {
+		return ids, missing, nil
+	}
+
+	all, err := fetch()
+	if err != nil {
+		return nil, nil, err
+	}
+	p.ids, p.loaded = all, time.Now()
+
+	ids, missing = p.lookup(pairs)
+	return ids, missing, nil
+}
+
+// learn records pairs seen in other responses, such as a role's permissions, so that resolving
+// them later does not need a reload.
+func (p *permissionCatalogue) learn(host string, perms []Permission) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.host != host {
+		p.host, p.ids, p.loaded = host, nil, time.Time{}
+	}
+	if p.ids == nil {
+		p.ids = make(map[PermissionPair]int64, len(perms))
+	}
+	for _, perm := range perms {
+		p.ids[PermissionPair{Permission: perm.PermissionName, ViewMenu: perm.ViewMenuName}] = perm.ID
+	}
+}
+
+// lookup splits pairs into known IDs and unknown pairs. p.mu must be held.
+func (p *permissionCatalogue) lookup(pairs []PermissionPair) (map[PermissionPair]int64, []PermissionPair) {
+	ids := make(map[PermissionPair]int64, len(pairs))
+	var missing []PermissionPair
+	for _, pair := range pairs {
+		if id, ok := p.ids[pair]; ok {
+			ids[pair] = id
+		} else if !slices.Contains(missing, pair) {
+			missing = append(missing, pair)
+		}
+	}
+	return ids, missing
+}
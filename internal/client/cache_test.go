@@ -0,0 +1,193 @@
+package client
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllDatabases_CachedPerClient(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://staging/api/v1/database/?q=(page:0,page_size:100)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 1, "database_name": "staging_db"}], "count": 1}`))
+	httpmock.RegisterResponder("GET", "http://prod/api/v1/database/?q=(page:0,page_size:100)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 2, "database_name": "prod_db"}], "count": 1}`))
+
+	staging := &Client{Host: "http://staging", Token: "test-token"}
+	prod := &Client{Host: "http://prod", Token: "test-token"}
+
+	for i := 0; i < 2; i++ {
+		id, err := staging.GetDatabaseIDByName(t.Context(), "staging_db")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), id)
+
+		_, err = prod.GetDatabaseIDByName(t.Context(), "staging_db")
+		assert.True(t, IsNotFound(err))
+	}
+
+	// One list call per client; repeated lookups are served from each client's own cache.
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+
+	// Repointing a client at another host does not serve the old host's databases.
+	staging.Host = "http://prod"
+	id, err := staging.GetDatabaseIDByName(t.Context(), "prod_db")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), id)
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+func TestCreateDatabase_InvalidatesCache(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/database/?q=(page:0,page_size:100)",
+		httpmock.NewStringResponder(200, `{"result": [], "count": 0}`))
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "csrf-token"}`))
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/database/",
+		httpmock.NewStringResponder(201, `{"id": 7, "result": {"database_name": "new_db"}}`))
+
+	_, err := client.GetDatabaseIDByName(t.Context(), "new_db")
+	assert.True(t, IsNotFound(err))
+
+	_, err = client.CreateDatabase(t.Context(), map[string]interface{}{"database_name": "new_db"})
+	require.NoError(t, err)
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/database/?q=(page:0,page_size:100)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 7, "database_name": "new_db"}], "count": 1}`))
+
+	id, err := client.GetDatabaseIDByName(t.Context(), "new_db")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), id)
+}
+
+func TestPermissionCatalogue_LoadsOnceAndReloadsOnMiss(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	const listURL = "http://test-host/api/v1/security/permissions-resources/?q=(page:0,page_size:100)"
+	httpmock.RegisterResponder("GET", listURL,
+		httpmock.NewStringResponder(200, `{"result": [
+			{"id": 10, "permission": {"name": "can_read"}, "view_menu": {"name": "Dashboard"}},
+			{"id": 11, "permission": {"name": "can_write"}, "view_menu": {"name": "Dashboard"}}
+		], "count": 2}`))
+
+	// The first lookup loads the catalogue; every known pair is then answered from it.
+	id, err := client.GetPermissionIDByNameAndView(t.Context(), "can_write", "Dashboard")
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), id)
+
+	ids, err := client.GetPermissionViewMenuIDs(t.Context(), []map[string]string{
+		{"permission": "can_read", "view_menu": "Dashboard"},
+		{"permission": "can_write", "view_menu": "Dashboard"},
+		{"permission": "can_read", "view_menu": "Dashboard"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10, 11}, ids)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+
+	// A miss reloads the catalogue and picks up pairs created since.
+	httpmock.RegisterResponder("GET", listURL,
+		httpmock.NewStringResponder(200, `{"result": [
+			{"id": 10, "permission": {"name": "can_read"}, "view_menu": {"name": "Dashboard"}},
+			{"id": 11, "permission": {"name": "can_write"}, "view_menu": {"name": "Dashboard"}},
+			{"id": 12, "permission": {"name": "datasource_access"}, "view_menu": {"name": "[examples].[orders](id:3)"}}
+		], "count": 3}`))
+
+	resolved, err := client.ResolvePermissions(t.Context(), []PermissionPair{
+		{Permission: "can_read", ViewMenu: "Dashboard"},
+		{Permission: "datasource_access", ViewMenu: "[examples].[orders](id:3)"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), resolved[PermissionPair{Permission: "datasource_access", ViewMenu: "[examples].[orders](id:3)"}])
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+
+	_, err = client.ResolvePermissions(t.Context(), []PermissionPair{
+		{Permission: "can_delete", ViewMenu: "Dashboard"},
+		{Permission: "can_read", ViewMenu: "Chart"},
+	})
+	assert.True(t, IsNotFound(err))
+	assert.Contains(t, err.Error(), "can_delete on Dashboard, can_read on Chart")
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+func TestPermissionCatalogue_ConcurrentMissesShareOneReload(t *testing.T) {
+	var catalogue permissionCatalogue
+	var fetches atomic.Int32
+	fetch := func() (map[PermissionPair]int64, error) {
+		fetches.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return map[PermissionPair]int64{{Permission: "can_read", ViewMenu: "Dashboard"}: 10}, nil
+	}
+
+	// Terraform applies many role resources in parallel; they must not each walk the list.
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids, missing, err := catalogue.resolve("http://test-host", []PermissionPair{{Permission: "can_read", ViewMenu: "Dashboard"}}, fetch)
+			assert.NoError(t, err)
+			assert.Empty(t, missing)
+			assert.Len(t, ids, 1)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), fetches.Load())
+}
+
+func TestGetRolePermissions_FillsPermissionCatalogue(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/roles/4/permissions/",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 10, "permission_name": "can_read", "view_menu_name": "Dashboard"}]}`))
+
+	_, err := client.GetRolePermissions(t.Context(), 4)
+	require.NoError(t, err)
+
+	// Re-applying the permissions a role already has needs no walk of the permission list.
+	id, err := client.GetPermissionIDByNameAndView(t.Context(), "can_read", "Dashboard")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), id)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestRoleWrites_InvalidateRoleCache(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/roles?q=(page:0,page_size:100)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 1, "name": "Admin"}], "count": 1}`))
+	httpmock.RegisterResponder("DELETE", "http://test-host/api/v1/security/roles/1",
+		httpmock.NewStringResponder(200, `{}`))
+
+	_, err := client.GetRoleIDByName(t.Context(), "Admin")
+	require.NoError(t, err)
+	_, err = client.GetRoleIDByName(t.Context(), "Admin")
+	require.NoError(t, err)
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET http://test-host/api/v1/security/roles?q=(page:0,page_size:100)"])
+
+	require.NoError(t, client.DeleteRole(t.Context(), 1))
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/roles?q=(page:0,page_size:100)",
+		httpmock.NewStringResponder(200, `{"result": [], "count": 0}`))
+
+	_, err = client.GetRoleIDByName(t.Context(), "Admin")
+	assert.True(t, IsNotFound(err))
+}
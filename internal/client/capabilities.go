@@ -0,0 +1,231 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+// Version is a Superset release number. The zero Version means the version is unknown.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	// Raw is the version string as reported by the server, e.g. "4.1.0rc2".
+	Raw string
+}
+
+var versionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// ParseVersion parses a version string such as "4.1.2" or "3.0.0rc1". Development builds,
+// which report 0.0.0, are treated as unknown.
+func ParseVersion(s string) (Version, bool) {
+	m := versionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, false
+	}
+	v := Version{Raw: s}
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	if m[3] != "" {
+		v.Patch, _ = strconv.Atoi(m[3])
+	}
+	if v.Major == 0 && v.Minor == 0 && v.Patch == 0 {
+		return Version{}, false
+	}
+	return v, true
+}
+
+// Known reports whether the version was detected.
+func (v Version) Known() bool {
+	return v.Major > 0 || v.Minor > 0 || v.Patch > 0
+}
+
+// AtLeast reports whether v is at or above other.
+func (v Version) AtLeast(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+func (v Version) String() string {
+	if v.Raw != "" {
+		return v.Raw
+	}
+	if !v.Known() {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Feature is server functionality that depends on the Superset release or a feature flag.
+type Feature struct {
+	// Name completes the sentence "<Name> requires Superset >= X".
+	Name       string
+	MinVersion Version
+	// FeatureFlag, when set, must be enabled in superset_config.py for the feature to work.
+	FeatureFlag string
+}
+
+var (
+	// FeatureEmbeddedDashboards covers /api/v1/dashboard/{id}/embedded.
+	FeatureEmbeddedDashboards = Feature{
+		Name:        "dashboard embedding",
+		MinVersion:  Version{Major: 1, Minor: 5},
+		FeatureFlag: "EMBEDDED_SUPERSET",
+	}
+	// FeatureRowLevelSecurityAPI covers /api/v1/rowlevelsecurity/, added in 2.1.
+	FeatureRowLevelSecurityAPI = Feature{
+		Name:       "the row level security API",
+		MinVersion: Version{Major: 2, Minor: 1},
+	}
+	// FeatureCatalog covers the catalog column of datasets and saved queries. Older servers
+	// reject the field as unknown.
+	FeatureCatalog = Feature{
+		Name:       "catalog support",
+		MinVersion: Version{Major: 4, Minor: 1},
+	}
+)
+
+// Capabilities describes what the connected Superset server supports. Anything that could not
+// be detected is assumed to be available, so an unknown server is never blocked.
+type Capabilities struct {
+	Version Version
+	// FeatureFlags holds the flags reported by the server; nil when the server does not report them.
+	FeatureFlags map[string]bool
+}
+
+// Supports reports whether f is available on the server.
+func (c Capabilities) Supports(f Feature) bool {
+	return c.Require(f) == nil
+}
+
+// Require returns an *UnsupportedError when the detected version or feature flags rule f out.
+func (c Capabilities) Require(f Feature) error {
+	if c.Version.Known() && f.MinVersion.Known() && !c.Version.AtLeast(f.MinVersion) {
+		return &UnsupportedError{Feature: f, Version: c.Version}
+	}
+	if f.FeatureFlag != "" && c.FeatureFlags != nil {
+		if enabled, reported := c.FeatureFlags[f.FeatureFlag]; reported && !enabled {
+			return &UnsupportedError{Feature: f, Version: c.Version, FlagDisabled: true}
+		}
+	}
+	return nil
+}
+
+// UnsupportedError reports that the server is too old for a feature or has its flag disabled.
+type UnsupportedError struct {
+	Feature      Feature
+	Version      Version
+	FlagDisabled bool
+}
+
+// Error implements error.
+func (e *UnsupportedError) Error() string {
+	if e.FlagDisabled {
+		return fmt.Sprintf("%s requires the %s feature flag, which is disabled on this Superset server", e.Feature.Name, e.Feature.FeatureFlag)
+	}
+	return fmt.Sprintf("%s requires Superset >= %d.%d, but the server reports version %s",
+		e.Feature.Name, e.Feature.MinVersion.Major, e.Feature.MinVersion.Minor, e.Version)
+}
+
+// IsUnsupported reports whether err means the server lacks the requested feature.
+func IsUnsupported(err error) bool {
+	var unsupported *UnsupportedError
+	return errors.As(err, &unsupported)
+}
+
+// Capabilities returns what NewClient detected about the server.
+func (c *Client) Capabilities() Capabilities {
+	return c.capabilities
+}
+
+// require checks f against the detected capabilities.
+func (c *Client) require(f Feature) error {
+	return c.capabilities.Require(f)
+}
+
+// detectCapabilities probes the server version and feature flags once. It prefers
+// /api/v1/version and falls back to the version string in the /api/v1/menu/ navbar;
+// when neither is available the capabilities stay unknown.
+func (c *Client) detectCapabilities(ctx context.Context) {
+	caps, ok := c.probeVersionEndpoint(ctx)
+	if !ok {
+		caps, ok = c.probeMenuEndpoint(ctx)
+	}
+	if !ok {
+		logDebug(ctx, "Could not detect Superset version, assuming all features are available")
+		return
+	}
+
+	logDebug(ctx, "Detected Superset version", map[string]interface{}{
+		"version":       caps.Version.String(),
+		"feature_flags": caps.FeatureFlags,
+	})
+	c.capabilities = caps
+}
+
+func (c *Client) probeVersionEndpoint(ctx context.Context) (Capabilities, bool) {
+	var payload struct {
+		Version      string          `json:"version"`
+		FeatureFlags map[string]bool `json:"feature_flags"`
+		Result       *struct {
+			Version      string          `json:"version"`
+			FeatureFlags map[string]bool `json:"feature_flags"`
+		} `json:"result"`
+	}
+	if !c.probe(ctx, "/api/v1/version", &payload) {
+		return Capabilities{}, false
+	}
+	if payload.Result != nil {
+		payload.Version, payload.FeatureFlags = payload.Result.Version, payload.Result.FeatureFlags
+	}
+
+	version, ok := ParseVersion(payload.Version)
+	if !ok && payload.FeatureFlags == nil {
+		return Capabilities{}, false
+	}
+	return Capabilities{Version: version, FeatureFlags: payload.FeatureFlags}, true
+}
+
+func (c *Client) probeMenuEndpoint(ctx context.Context) (Capabilities, bool) {
+	var payload struct {
+		Result struct {
+			NavbarRight struct {
+				VersionString string `json:"version_string"`
+			} `json:"navbar_right"`
+		} `json:"result"`
+	}
+	if !c.probe(ctx, "/api/v1/menu/", &payload) {
+		return Capabilities{}, false
+	}
+
+	version, ok := ParseVersion(payload.Result.NavbarRight.VersionString)
+	if !ok {
+		return Capabilities{}, false
+	}
+	return Capabilities{Version: version}, true
+}
+
+// probe GETs endpoint and decodes a 200 response into out. Any failure just means the
+// endpoint does not help with detection.
+func (c *Client) probe(ctx context.Context, endpoint string, out interface{}) bool {
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	return json.NewDecoder(resp.Body).Decode(out) == nil
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  Version
+		known bool
+	}{
+		{in: "4.1.2", want: Version{Major: 4, Minor: 1, Patch: 2, Raw: "4.1.2"}, known: true},
+		{in: "3.0.0rc1", want: Version{Major: 3, Minor: 0, Patch: 0, Raw: "3.0.0rc1"}, known: true},
+		{in: "v2.1", want: Version{Major: 2, Minor: 1, Raw: "v2.1"}, known: true},
+		{in: "0.0.0dev", known: false},
+		{in: "garbage", known: false},
+		{in: "", known: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := ParseVersion(tt.in)
+			assert.Equal(t, tt.known, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCapabilitiesRequire(t *testing.T) {
+	v20 := Version{Major: 2, Minor: 0, Patch: 1, Raw: "2.0.1"}
+	v21 := Version{Major: 2, Minor: 1, Raw: "2.1.0"}
+	v40 := Version{Major: 4, Minor: 0, Raw: "4.0.0"}
+	v41 := Version{Major: 4, Minor: 1, Patch: 1, Raw: "4.1.1"}
+
+	tests := []struct {
+		name    string
+		caps    Capabilities
+		feature Feature
+		wantErr string
+	}{
+		{name: "unknown version is allowed", caps: Capabilities{}, feature: FeatureRowLevelSecurityAPI},
+		{name: "new enough", caps: Capabilities{Version: v40}, feature: FeatureRowLevelSecurityAPI},
+		{name: "first release with the feature", caps: Capabilities{Version: v21}, feature: FeatureRowLevelSecurityAPI},
+		{
+			name:    "too old",
+			caps:    Capabilities{Version: v20},
+			feature: FeatureRowLevelSecurityAPI,
+			wantErr: "the row level security API requires Superset >= 2.1, but the server reports version 2.0.1",
+		},
+		{name: "catalogs", caps: Capabilities{Version: v41}, feature: FeatureCatalog},
+		{
+			name:    "catalogs too old",
+			caps:    Capabilities{Version: v40},
+			feature: FeatureCatalog,
+			wantErr: "catalog support requires Superset >= 4.1, but the server reports version 4.0.0",
+		},
+		{
+			name:    "flag disabled",
+			caps:    Capabilities{Version: v40, FeatureFlags: map[string]bool{"EMBEDDED_SUPERSET": false}},
+			feature: FeatureEmbeddedDashboards,
+			wantErr: "dashboard embedding requires the EMBEDDED_SUPERSET feature flag, which is disabled on this Superset server",
+		},
+		{
+			name:    "flag not reported",
+			caps:    Capabilities{Version: v40, FeatureFlags: map[string]bool{"ALERT_REPORTS": true}},
+			feature: FeatureEmbeddedDashboards,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.caps.Require(tt.feature)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.True(t, tt.caps.Supports(tt.feature))
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, IsUnsupported(err))
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDetectCapabilities(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses map[string]httpmock.Responder
+		want      Capabilities
+	}{
+		{
+			name: "version endpoint",
+			responses: map[string]httpmock.Responder{
+				"/api/v1/version": httpmock.NewStringResponder(200, `{"version": "4.1.0", "feature_flags": {"EMBEDDED_SUPERSET": true}}`),
+			},
+			want: Capabilities{
+				Version:      Version{Major: 4, Minor: 1, Raw: "4.1.0"},
+				FeatureFlags: map[string]bool{"EMBEDDED_SUPERSET": true},
+			},
+		},
+		{
+			name: "version endpoint with result envelope",
+			responses: map[string]httpmock.Responder{
+				"/api/v1/version": httpmock.NewStringResponder(200, `{"result": {"version": "3.1.2"}}`),
+			},
+			want: Capabilities{Version: Version{Major: 3, Minor: 1, Patch: 2, Raw: "3.1.2"}},
+		},
+		{
+			name: "menu fallback",
+			responses: map[string]httpmock.Responder{
+				"/api/v1/version": httpmock.NewStringResponder(404, `{"message": "Not found"}`),
+				"/api/v1/menu/":   httpmock.NewStringResponder(200, `{"result": {"navbar_right": {"version_string": "2.1.0"}}}`),
+			},
+			want: Capabilities{Version: Version{Major: 2, Minor: 1, Raw: "2.1.0"}},
+		},
+		{
+			name: "undetectable",
+			responses: map[string]httpmock.Responder{
+				"/api/v1/version": httpmock.NewStringResponder(404, `{"message": "Not found"}`),
+				"/api/v1/menu/":   httpmock.NewStringResponder(404, `{"message": "Not found"}`),
+			},
+			want: Capabilities{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			for endpoint, responder := range tt.responses {
+				httpmock.RegisterResponder("GET", "http://test-host"+endpoint, responder)
+			}
+
+			client := &Client{Host: "http://test-host", Token: "test-token"}
+			client.detectCapabilities(t.Context())
+
+			assert.Equal(t, tt.want, client.Capabilities())
+		})
+	}
+}
+
+func TestGetRowLevelSecurity_UnsupportedVersion(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{
+		Host:         "http://test-host",
+		Token:        "test-token",
+		capabilities: Capabilities{Version: Version{Major: 2, Minor: 0, Patch: 1, Raw: "2.0.1"}},
+	}
+
+	_, err := client.GetRowLevelSecurity(t.Context(), 1)
+
+	require.Error(t, err)
+	assert.True(t, IsUnsupported(err))
+	assert.Contains(t, err.Error(), "requires Superset >= 2.1")
+	assert.Equal(t, 0, httpmock.GetTotalCallCount())
+}
+
+func TestCreateDataset_CatalogUnsupportedVersion(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	version, ok := ParseVersion("4.0.2")
+	require.True(t, ok)
+	client := &Client{Host: "http://test-host", Token: "test-token", capabilities: Capabilities{Version: version}}
+
+	_, err := client.CreateDataset(t.Context(), DatasetRequest{TableName: "orders", Database: 1, Catalog: "sales"})
+	require.Error(t, err)
+	assert.EqualError(t, err, "catalog support requires Superset >= 4.1, but the server reports version 4.0.2")
+
+	err = client.UpdateDataset(t.Context(), 1, DatasetUpdateRequest{TableName: "orders", Catalog: "sales"})
+	assert.True(t, IsUnsupported(err), "got %v", err)
+	assert.Equal(t, 0, httpmock.GetTotalCallCount())
+}
+
+func TestSavedQuery_CatalogUnsupportedVersion(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	version, ok := ParseVersion("4.0.2")
+	require.True(t, ok)
+	client := &Client{Host: "http://test-host", Token: "test-token", capabilities: Capabilities{Version: version}}
+
+	catalog := "sales"
+	_, err := client.CreateSavedQuery(t.Context(), SavedQueryRequest{DatabaseID: 1, Label: "Orders", Catalog: &catalog, SQL: "SELECT 1"})
+	assert.EqualError(t, err, "catalog support requires Superset >= 4.1, but the server reports version 4.0.2")
+
+	err = client.UpdateSavedQuery(t.Context(), 4, SavedQueryRequest{DatabaseID: 1, Label: "Orders", Catalog: &catalog, SQL: "SELECT 1"})
+	assert.True(t, IsUnsupported(err), "got %v", err)
+	assert.Equal(t, 0, httpmock.GetTotalCallCount())
+}
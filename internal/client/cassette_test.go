@@ -0,0 +1,229 @@
+package client
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-superset/internal/testing/cassette"
+)
+
+// cassetteDir holds one directory of recorded cassettes per Superset release.
+const cassetteDir = "testdata/cassettes"
+
+// runCassette replays the named cassette of every recorded Superset release through lifecycle,
+// and fails when no release has been recorded, so a missing recording cannot pass as a
+// compatible one. With SUPERSET_CASSETTE_RECORD set it instead runs
+// lifecycle once against SUPERSET_HOST and saves a fresh cassette under the version the server
+// reports.
+func runCassette(t *testing.T, name string, lifecycle func(t *testing.T, c *Client)) {
+	t.Helper()
+
+	if cassette.Recording() {
+		recordCassette(t, name, lifecycle)
+		return
+	}
+
+	versions, err := os.ReadDir(cassetteDir)
+	if !errors.Is(err, fs.ErrNotExist) {
+		require.NoError(t, err)
+	}
+	replayed := false
+	for _, version := range versions {
+		if !version.IsDir() {
+			continue
+		}
+		replayed = true
+		t.Run(version.Name(), func(t *testing.T) {
+			recorded, err := cassette.Load(filepath.Join(cassetteDir, version.Name(), name+".yaml"))
+			require.NoError(t, err)
+			player := cassette.NewPlayer(recorded)
+
+			c, err := NewClient(t.Context(), Config{
+				Host:     "http://superset.test",
+				Username: "admin",
+				Password: "admin",
+				Provider: "db",
+				Transport: TransportConfig{
+					WrapTransport: func(http.RoundTripper) http.RoundTripper { return player },
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, recorded.SupersetVersion, c.Capabilities().Version.String())
+
+			lifecycle(t, c)
+			assert.Empty(t, player.Unplayed(), "recorded requests the client no longer makes")
+		})
+	}
+	if !replayed {
+		t.Fatal("no cassettes recorded under " + cassetteDir + "; record them against a real Superset with make cassettes")
+	}
+}
+
+func recordCassette(t *testing.T, name string, lifecycle func(t *testing.T, c *Client)) {
+	t.Helper()
+
+	var recorder *cassette.Recorder
+	c, err := NewClient(t.Context(), Config{
+		Host:     os.Getenv("SUPERSET_HOST"),
+		Username: os.Getenv("SUPERSET_USERNAME"),
+		Password: os.Getenv("SUPERSET_PASSWORD"),
+		Provider: "db",
+		Transport: TransportConfig{
+			WrapTransport: func(base http.RoundTripper) http.RoundTripper {
+				recorder = cassette.NewRecorder(base)
+				return recorder
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	lifecycle(t, c)
+
+	recorded := recorder.Cassette()
+	recorded.SupersetVersion = c.Capabilities().Version.String()
+	path := filepath.Join(cassetteDir, "superset-"+recorded.SupersetVersion, name+".yaml")
+	require.NoError(t, recorded.Save(path))
+	t.Logf("recorded %d interactions to %s", len(recorded.Interactions), path)
+}
+
+func TestCassette_RoleLifecycle(t *testing.T) {
+	runCassette(t, "role_lifecycle", func(t *testing.T, c *Client) {
+		ctx := t.Context()
+
+		id, err := c.CreateRole(ctx, "tf-cassette-role")
+		require.NoError(t, err)
+
+		role, err := c.GetRole(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "tf-cassette-role", role.Name)
+
+		require.NoError(t, c.UpdateRole(ctx, id, "tf-cassette-role-renamed"))
+		role, err = c.GetRole(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "tf-cassette-role-renamed", role.Name)
+
+		permissionIDs, err := c.GetPermissionViewMenuIDs(ctx, []map[string]string{
+			{"permission": "can_read", "view_menu": "Dashboard"},
+		})
+		require.NoError(t, err)
+		require.Len(t, permissionIDs, 1)
+
+		require.NoError(t, c.UpdateRolePermissions(ctx, id, permissionIDs))
+		permissions, err := c.GetRolePermissions(ctx, id)
+		require.NoError(t, err)
+		require.Len(t, permissions, 1)
+		assert.Equal(t, permissionIDs[0], permissions[0].ID)
+		assert.Equal(t, "can_read", permissions[0].PermissionName)
+		assert.Equal(t, "Dashboard", permissions[0].ViewMenuName)
+
+		require.NoError(t, c.ClearRolePermissions(ctx, id))
+		permissions, err = c.GetRolePermissions(ctx, id)
+		require.NoError(t, err)
+		assert.Empty(t, permissions)
+
+		require.NoError(t, c.DeleteRole(ctx, id))
+		_, err = c.GetRole(ctx, id)
+		assert.True(t, IsNotFound(err), "got %v", err)
+	})
+}
+
+func TestCassette_DatabaseAndDatasetLifecycle(t *testing.T) {
+	uri := os.Getenv("SUPERSET_CASSETTE_DATABASE_URI")
+	if uri == "" {
+		uri = "postgresql://superset:superset@db:5432/superset"
+	}
+
+	runCassette(t, "database_dataset_lifecycle", func(t *testing.T, c *Client) {
+		ctx := t.Context()
+
+		created, err := c.CreateDatabase(ctx, map[string]interface{}{
+			"database_name":    "tf-cassette-db",
+			"sqlalchemy_uri":   uri,
+			"expose_in_sqllab": true,
+		})
+		require.NoError(t, err)
+		databaseID := created.ID
+
+		connection, err := c.GetDatabaseConnectionByID(ctx, databaseID)
+		require.NoError(t, err)
+		assert.Equal(t, "tf-cassette-db", connection.DatabaseName)
+		assert.NotContains(t, connection.SQLAlchemyURI, "superset:superset@", "the password is masked")
+
+		name, err := c.GetDatabaseNameByID(ctx, databaseID)
+		require.NoError(t, err)
+		assert.Equal(t, "tf-cassette-db", name)
+
+		dataset, err := c.CreateDataset(ctx, DatasetRequest{
+			TableName: "tf_cassette_dataset",
+			Database:  databaseID,
+			Schema:    "public",
+			SQL:       "SELECT 1 AS one",
+		})
+		require.NoError(t, err)
+		datasetID := dataset.ID
+
+		require.NoError(t, c.UpdateDataset(ctx, datasetID, DatasetUpdateRequest{TableName: "tf_cassette_dataset", Schema: "public", SQL: "SELECT 2 AS two"}))
+		fetched, err := c.GetDataset(ctx, datasetID)
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT 2 AS two", fetched.SQL)
+		assert.Equal(t, databaseID, fetched.Database.ID)
+
+		require.NoError(t, c.DeleteDataset(ctx, datasetID))
+		require.NoError(t, c.DeleteDatabase(ctx, databaseID))
+	})
+}
+
+func TestCassette_UserLifecycle(t *testing.T) {
+	runCassette(t, "user_lifecycle", func(t *testing.T, c *Client) {
+		ctx := t.Context()
+
+		roleID, err := c.CreateRole(ctx, "tf-cassette-user-role")
+		require.NoError(t, err)
+
+		id, err := c.CreateUser(ctx, "tf-cassette-user", "Cassette", "User", "tf-cassette-user@example.com", "Passw0rd!", true, []int64{roleID})
+		require.NoError(t, err)
+
+		user, err := c.GetUser(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "tf-cassette-user", user.Username)
+		assert.Equal(t, []int64{roleID}, user.Roles)
+		assert.True(t, user.Active)
+
+		require.NoError(t, c.UpdateUser(ctx, id, "tf-cassette-user", "Cassette", "Renamed", "tf-cassette-user@example.com", "", false, []int64{roleID}))
+		user, err = c.GetUser(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed", user.LastName)
+		assert.False(t, user.Active)
+
+		require.NoError(t, c.DeleteUser(ctx, id))
+		require.NoError(t, c.DeleteRole(ctx, roleID))
+	})
+}
+
+func TestCassette_CSSTemplateLifecycle(t *testing.T) {
+	runCassette(t, "css_template_lifecycle", func(t *testing.T, c *Client) {
+		ctx := t.Context()
+
+		created, err := c.CreateCSSTemplate(ctx, "tf-cassette-css", ".dashboard { color: red; }")
+		require.NoError(t, err)
+
+		found, err := c.FindCSSTemplateByName(ctx, "tf-cassette-css")
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+
+		updated, err := c.UpdateCSSTemplate(ctx, created.ID, "tf-cassette-css", ".dashboard { color: blue; }")
+		require.NoError(t, err)
+		assert.Equal(t, ".dashboard { color: blue; }", updated.CSS)
+
+		require.NoError(t, c.DeleteCSSTemplate(ctx, created.ID))
+		_, err = c.GetCSSTemplate(ctx, created.ID)
+		assert.True(t, IsNotFound(err), "got %v", err)
+	})
+}
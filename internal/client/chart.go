@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ChartDashboard is a dashboard a chart is placed on, as embedded in chart responses.
+type ChartDashboard struct {
+	ID             int64  `json:"id"`
+	DashboardTitle string `json:"dashboard_title"`
+}
+
+// Chart is a Superset chart. GET /api/v1/chart/{id} leaves the UUID and the datasource out of
+// its show columns, so GetChart fills them in from the chart list.
+type Chart struct {
+	ID             int64            `json:"id"`
+	UUID           string           `json:"uuid,omitempty"`
+	SliceName      string           `json:"slice_name"`
+	VizType        string           `json:"viz_type"`
+	Description    string           `json:"description"`
+	Params         EmbeddedJSON     `json:"params"`
+	QueryContext   EmbeddedJSON     `json:"query_context"`
+	CacheTimeout   *int64           `json:"cache_timeout"`
+	DatasourceID   int64            `json:"datasource_id"`
+	DatasourceType string           `json:"datasource_type"`
+	Owners         []Owner          `json:"owners"`
+	Dashboards     []ChartDashboard `json:"dashboards"`
+}
+
+// ChartRequest is the body of a chart create or update. Params and QueryContext are JSON
+// documents encoded as strings. Nil fields are left out of the request, so an update keeps
+// Superset's value for them and a create without owners makes the creating user the owner.
+// CacheTimeout is the exception: it is always sent, and nil clears it.
+type ChartRequest struct {
+	SliceName      string   `json:"slice_name"`
+	VizType        string   `json:"viz_type"`
+	DatasourceID   int64    `json:"datasource_id"`
+	DatasourceType string   `json:"datasource_type"`
+	Description    *string  `json:"description,omitempty"`
+	Params         *string  `json:"params,omitempty"`
+	QueryContext   *string  `json:"query_context,omitempty"`
+	CacheTimeout   *int64   `json:"cache_timeout"`
+	Owners         *[]int64 `json:"owners,omitempty"`
+	Dashboards     *[]int64 `json:"dashboards,omitempty"`
+}
+
+// CreateChart creates a chart and returns its ID.
+// POST /api/v1/chart/.
+func (c *Client) CreateChart(ctx context.Context, chart ChartRequest) (int64, error) {
+	resp, err := c.DoRequestWithCSRF(ctx, "POST", "/api/v1/chart/", chart)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return 0, newAPIError(resp, "create chart")
+	}
+
+	var id int64
+	if _, err := decodeCreated(resp.Body, "created chart", func(_ *ChartRequest, createdID int64) { id = createdID }); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// GetChart fetches a chart by ID, together with its UUID and datasource.
+// GET /api/v1/chart/{id}, then GET /api/v1/chart/ filtered on the ID.
+func (c *Client) GetChart(ctx context.Context, id int64) (*Chart, error) {
+	resp, err := c.DoRequest(ctx, "GET", fmt.Sprintf("/api/v1/chart/%d", id), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "fetch chart")
+	}
+
+	chart, err := decodeItem(resp.Body, "chart", id, func(ch *Chart) *int64 { return &ch.ID })
+	if err != nil {
+		return nil, err
+	}
+
+	query := ListQuery{
+		Filters:  []Filter{{Col: "id", Opr: "eq", Value: id}},
+		Columns:  []string{"id", "uuid", "datasource_id", "datasource_type"},
+		PageSize: 1,
+	}
+	items, _, err := fetchPage[Chart](ctx, c, query.Endpoint("/api/v1/chart/"), fmt.Sprintf("chart %d", id))
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		// Deleted between the two requests.
+		return nil, fmt.Errorf("chart %d %w", id, ErrNotFound)
+	}
+	chart.UUID = items[0].UUID
+	chart.DatasourceID = items[0].DatasourceID
+	chart.DatasourceType = items[0].DatasourceType
+
+	return chart, nil
+}
+
+// UpdateChart updates a chart by ID.
+// PUT /api/v1/chart/{id}; only the fields set in the request are changed.
+func (c *Client) UpdateChart(ctx context.Context, id int64, chart ChartRequest) error {
+	resp, err := c.DoRequestWithCSRF(ctx, "PUT", fmt.Sprintf("/api/v1/chart/%d", id), chart)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp, "update chart")
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChart_FillsUUIDAndDatasourceFromList(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/chart/12",
+		httpmock.NewStringResponder(200, `{
+			"id": 12,
+			"result": {
+				"id": 12,
+				"slice_name": "Orders",
+				"viz_type": "table",
+				"description": null,
+				"params": "{\"row_limit\": 100}",
+				"query_context": null,
+				"cache_timeout": 600,
+				"owners": [{"id": 1, "first_name": "Ada", "last_name": "Lovelace"}],
+				"dashboards": [{"id": 3, "dashboard_title": "Sales"}],
+				"thumbnail_url": "/api/v1/chart/12/thumbnail/abc/"
+			}
+		}`))
+	httpmock.RegisterResponder("GET",
+		"http://test-host/api/v1/chart/?q=(columns:!(id,uuid,datasource_id,datasource_type),filters:!((col:id,opr:eq,value:12)),page:0,page_size:1)",
+		httpmock.NewStringResponder(200, `{"count": 1, "result": [{"id": 12, "uuid": "7d9e2a1c-6b1f-4b7e-9a51-0f4c2f7c3e10", "datasource_id": 4, "datasource_type": "table"}]}`))
+
+	chart, err := client.GetChart(t.Context(), 12)
+	require.NoError(t, err)
+
+	assert.Equal(t, "7d9e2a1c-6b1f-4b7e-9a51-0f4c2f7c3e10", chart.UUID)
+	assert.Equal(t, int64(4), chart.DatasourceID)
+	assert.Equal(t, "table", chart.DatasourceType)
+	assert.Equal(t, "", chart.Description)
+	assert.JSONEq(t, `{"row_limit": 100}`, chart.Params.String())
+	assert.Equal(t, EmbeddedJSON(""), chart.QueryContext)
+	require.NotNil(t, chart.CacheTimeout)
+	assert.Equal(t, int64(600), *chart.CacheTimeout)
+	assert.Equal(t, []ChartDashboard{{ID: 3, DashboardTitle: "Sales"}}, chart.Dashboards)
+	assert.Equal(t, []Owner{{ID: 1, FirstName: "Ada", LastName: "Lovelace"}}, chart.Owners)
+}
+
+func TestGetChart_NotFound(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/chart/12",
+		httpmock.NewStringResponder(404, `{"message": "Not found"}`))
+	_, err := client.GetChart(t.Context(), 12)
+	assert.True(t, IsNotFound(err), "got %v", err)
+
+	// Deleted between the show and the list request.
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/chart/12",
+		httpmock.NewStringResponder(200, `{"id": 12, "result": {"id": 12, "slice_name": "Orders"}}`))
+	httpmock.RegisterResponder("GET", `=~^http://test-host/api/v1/chart/\?q=`,
+		httpmock.NewStringResponder(200, `{"count": 0, "result": []}`))
+	_, err = client.GetChart(t.Context(), 12)
+	assert.True(t, IsNotFound(err), "got %v", err)
+}
+
+func TestCreateAndUpdateChart_Payload(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "test-csrf-token"}`))
+
+	var bodies []map[string]any
+	record := func(status int, body string) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			data, _ := io.ReadAll(req.Body)
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal(data, &payload))
+			bodies = append(bodies, payload)
+			return httpmock.NewStringResponse(status, body), nil
+		}
+	}
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/chart/",
+		record(201, `{"id": 12, "result": {"slice_name": "Orders", "viz_type": "table", "datasource_id": 4, "datasource_type": "table", "owners": [1]}}`))
+	httpmock.RegisterResponder("PUT", "http://test-host/api/v1/chart/12",
+		record(200, `{"id": 12, "result": {"slice_name": "Orders"}}`))
+
+	params := `{"row_limit": 100}`
+	owners := []int64{1}
+	id, err := client.CreateChart(t.Context(), ChartRequest{
+		SliceName:      "Orders",
+		VizType:        "table",
+		DatasourceID:   4,
+		DatasourceType: "table",
+		Params:         &params,
+		Owners:         &owners,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), id)
+
+	require.NoError(t, client.UpdateChart(t.Context(), 12, ChartRequest{SliceName: "Orders", VizType: "table", DatasourceID: 4, DatasourceType: "table"}))
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, map[string]any{
+		"slice_name":      "Orders",
+		"viz_type":        "table",
+		"datasource_id":   float64(4),
+		"datasource_type": "table",
+		"params":          params,
+		"cache_timeout":   nil,
+		"owners":          []any{float64(1)},
+	}, bodies[0])
+	assert.NotContains(t, bodies[1], "params", "unset fields are left out of an update")
+	assert.NotContains(t, bodies[1], "owners")
+	assert.Contains(t, bodies[1], "cache_timeout", "a nil cache timeout is sent to clear it")
+}
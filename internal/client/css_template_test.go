@@ -22,7 +22,7 @@ func TestCreateCSSTemplate(t *testing.T) {
 	httpmock.RegisterResponder("POST", "http://test-host/api/v1/css_template/",
 		httpmock.NewStringResponder(201, `{"id": 42, "result": {"id": 42, "template_name": "Test", "css": "body {}"}}`))
 
-	tmpl, err := client.CreateCSSTemplate("Test", "body {}")
+	tmpl, err := client.CreateCSSTemplate(t.Context(), "Test", "body {}")
 
 	assert.NoError(t, err)
 	assert.Equal(t, 42, tmpl.ID)
@@ -42,7 +42,7 @@ func TestGetCSSTemplate(t *testing.T) {
 	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/42",
 		httpmock.NewStringResponder(200, `{"result": {"id": 42, "template_name": "Test", "css": "body {}"}}`))
 
-	tmpl, err := client.GetCSSTemplate(42)
+	tmpl, err := client.GetCSSTemplate(t.Context(), 42)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 42, tmpl.ID)
@@ -62,7 +62,7 @@ func TestGetCSSTemplate_NotFound(t *testing.T) {
 	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/999",
 		httpmock.NewStringResponder(404, `{"message": "Not found"}`))
 
-	tmpl, err := client.GetCSSTemplate(999)
+	tmpl, err := client.GetCSSTemplate(t.Context(), 999)
 
 	assert.Error(t, err)
 	assert.Nil(t, tmpl)
@@ -84,7 +84,7 @@ func TestUpdateCSSTemplate(t *testing.T) {
 	httpmock.RegisterResponder("PUT", "http://test-host/api/v1/css_template/42",
 		httpmock.NewStringResponder(200, `{"result": {"id": 42, "template_name": "Updated", "css": "body { color: red; }"}}`))
 
-	tmpl, err := client.UpdateCSSTemplate(42, "Updated", "body { color: red; }")
+	tmpl, err := client.UpdateCSSTemplate(t.Context(), 42, "Updated", "body { color: red; }")
 
 	assert.NoError(t, err)
 	assert.Equal(t, 42, tmpl.ID)
@@ -107,7 +107,7 @@ func TestUpdateCSSTemplate_NotFound(t *testing.T) {
 	httpmock.RegisterResponder("PUT", "http://test-host/api/v1/css_template/999",
 		httpmock.NewStringResponder(404, `{"message": "Not found"}`))
 
-	tmpl, err := client.UpdateCSSTemplate(999, "Test", "body {}")
+	tmpl, err := client.UpdateCSSTemplate(t.Context(), 999, "Test", "body {}")
 
 	assert.Error(t, err)
 	assert.Nil(t, tmpl)
@@ -129,7 +129,7 @@ func TestDeleteCSSTemplate(t *testing.T) {
 	httpmock.RegisterResponder("DELETE", "http://test-host/api/v1/css_template/42",
 		httpmock.NewStringResponder(200, ``))
 
-	err := client.DeleteCSSTemplate(42)
+	err := client.DeleteCSSTemplate(t.Context(), 42)
 
 	assert.NoError(t, err)
 }
@@ -149,7 +149,7 @@ func TestDeleteCSSTemplate_NotFound_IsSuccess(t *testing.T) {
 	httpmock.RegisterResponder("DELETE", "http://test-host/api/v1/css_template/999",
 		httpmock.NewStringResponder(404, `{"message": "Not found"}`))
 
-	err := client.DeleteCSSTemplate(999)
+	err := client.DeleteCSSTemplate(t.Context(), 999)
 
 	assert.NoError(t, err) // 404 on delete is treated as success
 }
@@ -166,7 +166,7 @@ func TestFindCSSTemplateByName(t *testing.T) {
 	httpmock.RegisterResponder("GET", `=~^http://test-host/api/v1/css_template/\?q=.*`,
 		httpmock.NewStringResponder(200, `{"result": [{"id": 7, "template_name": "Dashboard Styles", "css": ".dash {}"}], "count": 1}`))
 
-	tmpl, err := client.FindCSSTemplateByName("Dashboard Styles")
+	tmpl, err := client.FindCSSTemplateByName(t.Context(), "Dashboard Styles")
 
 	assert.NoError(t, err)
 	assert.Equal(t, 7, tmpl.ID)
@@ -186,7 +186,7 @@ func TestFindCSSTemplateByName_NotFound(t *testing.T) {
 	httpmock.RegisterResponder("GET", `=~^http://test-host/api/v1/css_template/\?q=.*`,
 		httpmock.NewStringResponder(200, `{"result": [], "count": 0}`))
 
-	tmpl, err := client.FindCSSTemplateByName("NonExistent")
+	tmpl, err := client.FindCSSTemplateByName(t.Context(), "NonExistent")
 
 	assert.Error(t, err)
 	assert.Nil(t, tmpl)
@@ -205,7 +205,7 @@ func TestFindCSSTemplateByName_Ambiguous(t *testing.T) {
 	httpmock.RegisterResponder("GET", `=~^http://test-host/api/v1/css_template/\?q=.*`,
 		httpmock.NewStringResponder(200, `{"result": [{"id": 1, "template_name": "Dup", "css": "a"}, {"id": 2, "template_name": "Dup", "css": "b"}], "count": 2}`))
 
-	tmpl, err := client.FindCSSTemplateByName("Dup")
+	tmpl, err := client.FindCSSTemplateByName(t.Context(), "Dup")
 
 	assert.Error(t, err)
 	assert.Nil(t, tmpl)
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// DashboardRequest is the body of a dashboard create or update. JSONMetadata and PositionJSON
+// are JSON documents encoded as strings. Nil fields are left out of the request, so an update
+// keeps Superset's value for them and a create without owners makes the creating user the
+// owner. Slug is the exception: it is always sent, and nil clears it, because Superset rejects
+// an empty slug. Roles are set separately with SetDashboardRoles.
+type DashboardRequest struct {
+	DashboardTitle       string   `json:"dashboard_title"`
+	Slug                 *string  `json:"slug"`
+	Published            *bool    `json:"published,omitempty"`
+	CSS                  *string  `json:"css,omitempty"`
+	CertifiedBy          *string  `json:"certified_by,omitempty"`
+	CertificationDetails *string  `json:"certification_details,omitempty"`
+	JSONMetadata         *string  `json:"json_metadata,omitempty"`
+	PositionJSON         *string  `json:"position_json,omitempty"`
+	Owners               *[]int64 `json:"owners,omitempty"`
+}
+
+// CreateDashboard creates a dashboard and returns its ID.
+// POST /api/v1/dashboard/.
+func (c *Client) CreateDashboard(ctx context.Context, dashboard DashboardRequest) (int64, error) {
+	resp, err := c.DoRequestWithCSRF(ctx, "POST", "/api/v1/dashboard/", dashboard)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return 0, newAPIError(resp, "create dashboard")
+	}
+
+	var id int64
+	if _, err := decodeCreated(resp.Body, "created dashboard", func(_ *DashboardRequest, createdID int64) { id = createdID }); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// UpdateDashboard updates a dashboard by ID.
+// PUT /api/v1/dashboard/{id}; only the fields set in the request are changed.
+func (c *Client) UpdateDashboard(ctx context.Context, id int64, dashboard DashboardRequest) error {
+	resp, err := c.DoRequestWithCSRF(ctx, "PUT", fmt.Sprintf("/api/v1/dashboard/%d", id), dashboard)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp, "update dashboard")
+	}
+
+	return nil
+}
+
+// GetDashboardUUID returns the UUID of a dashboard from the dashboard list. Not every Superset
+// release includes the UUID in GET /api/v1/dashboard/{id}.
+func (c *Client) GetDashboardUUID(ctx context.Context, id int64) (string, error) {
+	uuids, err := lookupIDsAndUUIDs(ctx, c, "/api/v1/dashboard/", fmt.Sprintf("dashboard %d", id), "id", []int64{id})
+	if err != nil {
+		return "", err
+	}
+	for uuid := range uuids {
+		return uuid, nil
+	}
+	return "", fmt.Errorf("dashboard %d %w", id, ErrNotFound)
+}
+
+// GetDashboardIDBySlug returns the ID of the dashboard with the given slug.
+func (c *Client) GetDashboardIDBySlug(ctx context.Context, slug string) (int64, error) {
+	id, err := c.findIDByFilter(ctx, "/api/v1/dashboard/", fmt.Sprintf("dashboard by slug %q", slug), Filter{Col: "slug", Opr: "eq", Value: slug})
+	if err != nil {
+		return 0, err
+	}
+	if id == 0 {
+		return 0, fmt.Errorf("dashboard with slug %q %w", slug, ErrNotFound)
+	}
+	return id, nil
+}
+
+// LinkChartsToDashboard adds the dashboard to the given charts' dashboards list. Charts already
+// on the dashboard are left untouched. It is the counterpart of UnlinkChartsFromDashboard.
+func (c *Client) LinkChartsToDashboard(ctx context.Context, chartIDs []int64, dashboardID int64) error {
+	for _, chartID := range chartIDs {
+		resp, err := c.DoRequest(ctx, "GET", fmt.Sprintf("/api/v1/chart/%d", chartID), nil)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			apiErr := newAPIError(resp, fmt.Sprintf("fetch chart %d", chartID))
+			resp.Body.Close()
+			return apiErr
+		}
+		chart, err := decodeItem(resp.Body, "chart", chartID, func(ch *Chart) *int64 { return &ch.ID })
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		dashboards := make([]int64, 0, len(chart.Dashboards)+1)
+		linked := false
+		for _, d := range chart.Dashboards {
+			dashboards = append(dashboards, d.ID)
+			linked = linked || d.ID == dashboardID
+		}
+		if linked {
+			continue
+		}
+		dashboards = append(dashboards, dashboardID)
+
+		updateResp, err := c.DoRequestWithCSRF(ctx, "PUT", fmt.Sprintf("/api/v1/chart/%d", chartID), map[string]interface{}{
+			"dashboards": dashboards,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update chart %d: %w", chartID, err)
+		}
+		if updateResp.StatusCode != http.StatusOK {
+			apiErr := newAPIError(updateResp, fmt.Sprintf("link chart %d to dashboard", chartID))
+			updateResp.Body.Close()
+			return apiErr
+		}
+		updateResp.Body.Close()
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAndUpdateDashboard_Payload(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "test-csrf-token"}`))
+
+	var bodies []map[string]any
+	record := func(status int, body string) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			data, _ := io.ReadAll(req.Body)
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal(data, &payload))
+			bodies = append(bodies, payload)
+			return httpmock.NewStringResponse(status, body), nil
+		}
+	}
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/dashboard/",
+		record(201, `{"id": 5, "result": {"dashboard_title": "Sales", "slug": "sales", "owners": [1], "published": true}}`))
+	httpmock.RegisterResponder("PUT", "http://test-host/api/v1/dashboard/5",
+		record(200, `{"id": 5, "result": {"dashboard_title": "Sales"}}`))
+
+	slug := "sales"
+	published := true
+	id, err := client.CreateDashboard(t.Context(), DashboardRequest{DashboardTitle: "Sales", Slug: &slug, Published: &published})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), id)
+
+	require.NoError(t, client.UpdateDashboard(t.Context(), 5, DashboardRequest{DashboardTitle: "Sales"}))
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, map[string]any{"dashboard_title": "Sales", "slug": "sales", "published": true}, bodies[0])
+	assert.Equal(t, map[string]any{"dashboard_title": "Sales", "slug": nil}, bodies[1], "a nil slug is sent as null to clear it")
+}
+
+func TestGetDashboardUUIDAndSlugLookups(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET",
+		"http://test-host/api/v1/dashboard/?q=(columns:!(id,uuid),filters:!((col:id,opr:in,value:!(5))),page:0,page_size:100)",
+		httpmock.NewStringResponder(200, `{"count": 1, "result": [{"id": 5, "uuid": "3c1b7e2a-9f4d-4c6b-8a2e-5d7f1e0b9c44"}]}`))
+	httpmock.RegisterResponder("GET",
+		"http://test-host/api/v1/dashboard/?q=(columns:!(id,uuid),filters:!((col:id,opr:in,value:!(6))),page:0,page_size:100)",
+		httpmock.NewStringResponder(200, `{"count": 0, "result": []}`))
+	httpmock.RegisterResponder("GET",
+		"http://test-host/api/v1/dashboard/?q=(columns:!(id),filters:!((col:slug,opr:eq,value:sales)),page:0,page_size:1)",
+		httpmock.NewStringResponder(200, `{"count": 1, "result": [{"id": 5}]}`))
+	httpmock.RegisterResponder("GET",
+		"http://test-host/api/v1/dashboard/?q=(columns:!(id),filters:!((col:slug,opr:eq,value:gone)),page:0,page_size:1)",
+		httpmock.NewStringResponder(200, `{"count": 0, "result": []}`))
+
+	uuid, err := client.GetDashboardUUID(t.Context(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "3c1b7e2a-9f4d-4c6b-8a2e-5d7f1e0b9c44", uuid)
+	_, err = client.GetDashboardUUID(t.Context(), 6)
+	assert.True(t, IsNotFound(err), "got %v", err)
+
+	id, err := client.GetDashboardIDBySlug(t.Context(), "sales")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), id)
+	_, err = client.GetDashboardIDBySlug(t.Context(), "gone")
+	assert.True(t, IsNotFound(err), "got %v", err)
+}
+
+func TestLinkChartsToDashboard(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "test-csrf-token"}`))
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/chart/1",
+		httpmock.NewStringResponder(200, `{"result": {"id": 1, "slice_name": "A", "dashboards": [{"id": 3, "dashboard_title": "Other"}]}}`))
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/chart/2",
+		httpmock.NewStringResponder(200, `{"result": {"id": 2, "slice_name": "B", "dashboards": [{"id": 5, "dashboard_title": "Sales"}]}}`))
+
+	var body map[string]any
+	httpmock.RegisterResponder("PUT", "http://test-host/api/v1/chart/1",
+		func(req *http.Request) (*http.Response, error) {
+			data, _ := io.ReadAll(req.Body)
+			require.NoError(t, json.Unmarshal(data, &body))
+			return httpmock.NewStringResponse(200, `{"id": 1, "result": {}}`), nil
+		})
+
+	require.NoError(t, client.LinkChartsToDashboard(t.Context(), []int64{1, 2}, 5))
+	assert.Equal(t, map[string]any{"dashboards": []any{float64(3), float64(5)}}, body)
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()["PUT http://test-host/api/v1/chart/2"], "a chart already on the dashboard is not updated")
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Sentinel errors matched by errors.Is against an *APIError with the corresponding status.
+// Lookup helpers that search a list wrap ErrNotFound when nothing matches.
+var (
+	ErrNotFound  = errors.New("not found")
+	ErrForbidden = errors.New("forbidden")
+	ErrConflict  = errors.New("conflict")
+)
+
+// maxErrorBodySize caps the response body kept on an APIError.
+const maxErrorBodySize = 1024
+
+// APIError is returned when Superset answers a request with an unexpected status code.
+type APIError struct {
+	// Op describes what the client was doing, e.g. "fetch dataset".
+	Op         string
+	Method     string
+	Endpoint   string
+	StatusCode int
+	// Message is Superset's "message" field. Field validation errors, which Superset sends
+	// as an object, are kept as JSON.
+	Message string
+	// Errors is Superset's structured "errors" payload, if any.
+	Errors []SupersetError
+	// RequestID is taken from the X-Request-Id response header when a proxy or Superset sets it.
+	RequestID string
+	// Body is the raw response body, truncated.
+	Body string
+}
+
+// SupersetError is one entry of the "errors" array in a Superset error response.
+type SupersetError struct {
+	Message   string                 `json:"message"`
+	ErrorType string                 `json:"error_type"`
+	Level     string                 `json:"level"`
+	Extra     map[string]interface{} `json:"extra,omitempty"`
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("failed to %s, status code: %d", e.Op, e.StatusCode)
+	if e.Body != "" {
+		msg += ", response: " + e.Body
+	}
+	if e.RequestID != "" {
+		msg += ", request ID: " + e.RequestID
+	}
+	return msg
+}
+
+// Is reports whether the status code matches one of the sentinel errors.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	}
+	return false
+}
+
+// IsNotFound reports whether err means the requested object does not exist in Superset.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsForbidden reports whether Superset denied access to the requested object.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsConflict reports whether the request clashed with an existing object.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// StatusCode returns the HTTP status of an *APIError in err's chain, or 0 if there is none.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// newAPIError builds an APIError from an unexpected response, consuming its body.
+func newAPIError(resp *http.Response, op string) *APIError {
+	body, _ := io.ReadAll(resp.Body)
+	return newAPIErrorWithBody(resp, op, body)
+}
+
+// newAPIErrorWithBody is newAPIError for callers that have already read the response body.
+func newAPIErrorWithBody(resp *http.Response, op string, body []byte) *APIError {
+	apiErr := &APIError{
+		Op:         op,
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Body:       truncateBody(string(body), maxErrorBodySize),
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Endpoint = resp.Request.URL.Path
+	}
+
+	var payload struct {
+		Message json.RawMessage `json:"message"`
+		Errors  []SupersetError `json:"errors"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		apiErr.Errors = payload.Errors
+		if len(payload.Message) > 0 {
+			var text string
+			if json.Unmarshal(payload.Message, &text) == nil {
+				apiErr.Message = text
+			} else {
+				apiErr.Message = string(payload.Message)
+			}
+		}
+	}
+
+	return apiErr
+}
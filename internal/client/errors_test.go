@@ -0,0 +1,115 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError_FromResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+		wantErrors  int
+		notFound    bool
+		forbidden   bool
+		conflict    bool
+	}{
+		{
+			name:        "not found",
+			status:      404,
+			body:        `{"message": "Not found"}`,
+			wantMessage: "Not found",
+			notFound:    true,
+		},
+		{
+			name:        "forbidden",
+			status:      403,
+			body:        `{"message": "Forbidden"}`,
+			wantMessage: "Forbidden",
+			forbidden:   true,
+		},
+		{
+			name:        "field validation errors",
+			status:      422,
+			body:        `{"message": {"table_name": ["Dataset already exists"]}}`,
+			wantMessage: `{"table_name": ["Dataset already exists"]}`,
+		},
+		{
+			name:       "structured errors",
+			status:     409,
+			body:       `{"errors": [{"message": "already exists", "error_type": "GENERIC_BACKEND_ERROR", "level": "error"}]}`,
+			wantErrors: 1,
+			conflict:   true,
+		},
+		{
+			name:   "non-JSON body",
+			status: 502,
+			body:   `<html>Bad Gateway</html>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			client := &Client{Host: "http://test-host", Token: "test-token"}
+			httpmock.RegisterResponder("DELETE", "http://test-host/api/v1/security/roles/7",
+				func(req *http.Request) (*http.Response, error) {
+					resp := httpmock.NewStringResponse(tt.status, tt.body)
+					resp.Header.Set("X-Request-Id", "req-123")
+					resp.Request = req
+					return resp, nil
+				})
+
+			err := client.DeleteRole(t.Context(), 7)
+
+			var apiErr *APIError
+			require.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tt.status, apiErr.StatusCode)
+			assert.Equal(t, "DELETE", apiErr.Method)
+			assert.Equal(t, "/api/v1/security/roles/7", apiErr.Endpoint)
+			assert.Equal(t, "req-123", apiErr.RequestID)
+			assert.Equal(t, tt.wantMessage, apiErr.Message)
+			assert.Len(t, apiErr.Errors, tt.wantErrors)
+			assert.Equal(t, tt.status, StatusCode(err))
+			assert.Equal(t, tt.notFound, IsNotFound(err))
+			assert.Equal(t, tt.forbidden, IsForbidden(err))
+			assert.Equal(t, tt.conflict, IsConflict(err))
+			assert.Contains(t, err.Error(), "failed to delete role")
+		})
+	}
+}
+
+func TestIsNotFound_WrappedAndLookupErrors(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/dataset/5",
+		httpmock.NewStringResponder(404, `{"message": "Not found"}`))
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/dashboard/",
+		httpmock.NewStringResponder(200, `{"result": [], "count": 0}`))
+
+	_, err := client.GetDataset(t.Context(), 5)
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+	assert.Equal(t, http.StatusNotFound, StatusCode(err))
+
+	_, err = client.GetDashboardIDByUUID(t.Context(), "missing")
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+	assert.Zero(t, StatusCode(err))
+	assert.Equal(t, `dashboard with uuid "missing" not found`, err.Error())
+
+	assert.False(t, IsNotFound(errors.New("boom")))
+	assert.False(t, IsNotFound(nil))
+}
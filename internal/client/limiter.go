@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// importMu serializes bundle imports across every Client in the process. Superset's import
+// endpoints upsert shared objects such as databases and datasets and are not safe to run
+// concurrently, even from separately aliased providers.
+var importMu sync.Mutex
+
+// limiter bounds the load a Client puts on Superset. Both limits apply per HTTP attempt, so a
+// request sleeping in retry backoff does not hold a slot. A slot is held until the response
+// body is closed, so large list and export bodies count while they stream.
+type limiter struct {
+	// slots is a semaphore of MaxConcurrentRequests entries; nil means unlimited.
+	slots chan struct{}
+	// bucket paces requests to RequestsPerSecond; nil means unlimited.
+	bucket *tokenBucket
+}
+
+// newLimiter returns a limiter for the given limits, or nil when neither is set.
+func newLimiter(maxConcurrent int, perSecond float64) *limiter {
+	if maxConcurrent <= 0 && perSecond <= 0 {
+		return nil
+	}
+	l := &limiter{}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+	if perSecond > 0 {
+		l.bucket = newTokenBucket(perSecond)
+	}
+	return l
+}
+
+// acquire waits for a concurrency slot and a rate token. The returned release function must be
+// called once the request has failed or its response body has been closed. A nil limiter never
+// waits.
+func (l *limiter) acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release = func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// tokenBucket is a token bucket refilled at rate tokens per second. Waiters reserve a token up
+// front, letting the balance go negative, so they are served in arrival order.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full bucket whose burst is one second's worth of tokens.
+func newTokenBucket(rate float64) *tokenBucket {
+	burst := math.Max(1, math.Floor(rate))
+	return &tokenBucket{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Hand the reservation back so later callers are not delayed by a request that never ran.
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
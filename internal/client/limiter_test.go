@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inFlight records the highest number of requests its responders were serving at once.
+type inFlight struct {
+	current int32
+	peak    int32
+}
+
+// responder answers 200 with body after a short delay.
+func (f *inFlight) responder(body string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&f.current, 1)
+		defer atomic.AddInt32(&f.current, -1)
+		for {
+			old := atomic.LoadInt32(&f.peak)
+			if n <= old || atomic.CompareAndSwapInt32(&f.peak, old, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return httpmock.NewStringResponse(200, body), nil
+	}
+}
+
+func TestDoRequest_MaxConcurrentRequests(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var tracker inFlight
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/chart/1", tracker.responder(`{"result": {}}`))
+
+	client := &Client{Host: "http://test-host", Token: "test-token", limiter: newLimiter(2, 0)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.DoRequest(t.Context(), "GET", "/api/v1/chart/1", nil)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 8, httpmock.GetTotalCallCount())
+	assert.LessOrEqual(t, atomic.LoadInt32(&tracker.peak), int32(2))
+}
+
+func TestDoRequest_SlotHeldUntilBodyClosed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/chart/export/",
+		httpmock.NewStringResponder(200, "PK..."))
+
+	client := &Client{Host: "http://test-host", Token: "test-token", limiter: newLimiter(1, 0)}
+
+	streaming, err := client.DoRequest(t.Context(), "GET", "/api/v1/chart/export/", nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.DoRequest(ctx, "GET", "/api/v1/chart/export/", nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "the slot is held while the first body is open")
+
+	require.NoError(t, streaming.Body.Close())
+	require.NoError(t, streaming.Body.Close(), "closing twice releases once")
+
+	resp, err := client.DoRequest(t.Context(), "GET", "/api/v1/chart/export/", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, client.limiter.slots)
+}
+
+func TestTokenBucket_PacesAfterBurst(t *testing.T) {
+	bucket := newTokenBucket(20)
+
+	start := time.Now()
+	for i := 0; i < 22; i++ {
+		require.NoError(t, bucket.wait(t.Context()))
+	}
+
+	// The first 20 tokens are the burst; the remaining two arrive 50ms apart.
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+func TestLimiterAcquire_HonoursContext(t *testing.T) {
+	l := newLimiter(1, 0)
+
+	release, err := l.acquire(t.Context())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	release, err = l.acquire(t.Context())
+	require.NoError(t, err)
+	release()
+}
+
+func TestNewLimiter_Unlimited(t *testing.T) {
+	assert.Nil(t, newLimiter(0, 0))
+
+	var l *limiter
+	release, err := l.acquire(t.Context())
+	require.NoError(t, err)
+	release()
+}
+
+func TestImports_Serialized(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var tracker inFlight
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "csrf-token"}`))
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/dashboard/import/", tracker.responder(`{"message": "OK"}`))
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/chart/import/", tracker.responder(`{"message": "OK"}`))
+
+	client := &Client{Host: "http://test-host", Token: "test-token", serializeImports: true}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.ImportDashboard(t.Context(), BundleBytes([]byte("zip")), true, ""))
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.ImportChart(t.Context(), BundleBytes([]byte("zip")), true, ""))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&tracker.peak))
+}
+
+func TestNestedLookups_SingleSlot(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/dashboard/5/charts",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 7}]}`))
+	httpmock.RegisterResponder("GET", "=~^http://test-host/api/v1/chart/\\?q=",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 7, "uuid": "uuid-7"}], "count": 1}`))
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/database/3",
+		httpmock.NewStringResponder(200, `{"result": {"id": 3, "database_name": "meta"}}`))
+	httpmock.RegisterResponder("GET", "=~^http://test-host/api/v1/database/\\?q=",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 3, "database_name": "meta",
+			"extra": "{\"engine_params\": {\"allowed_dbs\": [\"warehouse\"]}}"}], "count": 1}`))
+
+	client := &Client{Host: "http://test-host", Token: "test-token", limiter: newLimiter(1, 0)}
+
+	// The first response is closed before the follow-up lookup, which needs the only slot.
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+	uuids, err := client.GetDashboardChartUUIDs(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"uuid-7": 7}, uuids)
+
+	metaDB, err := client.GetMetaDatabase(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"warehouse"}, metaDB.AllowedDBs)
+	assert.Empty(t, client.limiter.slots)
+}
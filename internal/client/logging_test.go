@@ -0,0 +1,164 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{
+			name:        "login payload",
+			path:        "/api/v1/security/login",
+			contentType: "application/json",
+			body:        `{"password":"hunter2","provider":"db","username":"admin"}`,
+			want:        `{"password":"***","provider":"db","username":"admin"}`,
+		},
+		{
+			name:        "tokens in response",
+			path:        "/api/v1/security/login",
+			contentType: "application/json",
+			body:        `{"access_token":"eyJ.a.b","refresh_token":"eyJ.c.d"}`,
+			want:        `{"access_token":"***","refresh_token":"***"}`,
+		},
+		{
+			name:        "nested database secrets and URI password",
+			path:        "/api/v1/database/",
+			contentType: "application/json",
+			body:        `{"masked_encrypted_extra":"{\"credentials_info\":{}}","parameters":{"Password":"pw"},"sqlalchemy_uri":"postgresql://user:secret@db:5432/app","ssh_tunnel":{"private_key":"-----BEGIN"}}`,
+			want:        `{"masked_encrypted_extra":"***","parameters":{"Password":"***"},"sqlalchemy_uri":"postgresql://user:***@db:5432/app","ssh_tunnel":{"private_key":"***"}}`,
+		},
+		{
+			name:        "empty password stays visible",
+			path:        "/api/v1/security/users/",
+			contentType: "application/json",
+			body:        `{"password":"","username":"bob"}`,
+			want:        `{"password":"","username":"bob"}`,
+		},
+		{
+			name:        "csrf token response",
+			path:        "/api/v1/security/csrf_token/",
+			contentType: "application/json",
+			body:        `{"result":"ImFiYyI.token"}`,
+			want:        "***",
+		},
+		{
+			name:        "form body",
+			path:        "/oauth/token",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "grant_type=client_credentials&client_secret=s3cr3t&scope=read",
+			want:        "grant_type=client_credentials&client_secret=***&scope=read",
+		},
+		{
+			name:        "unparseable JSON",
+			path:        "/api/v1/database/",
+			contentType: "application/json",
+			body:        `{"password": "hunter2", "database_name": `,
+			want:        `{"password": "***", "database_name": `,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, redactBody(tt.path, tt.contentType, []byte(tt.body)))
+		})
+	}
+}
+
+func TestRedactBody_Truncates(t *testing.T) {
+	body := `{"password": "hunter2", "sql": "` + strings.Repeat("x", maxLoggedBody) + `"}`
+
+	got := redactBody("/api/v1/dataset/", "application/json", []byte(body))
+
+	assert.NotContains(t, got, "hunter2")
+	assert.True(t, strings.HasSuffix(got, "... [truncated]"))
+}
+
+func TestDoRequest_TracesRedactedTraffic(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	t.Setenv("TF_LOG_PROVIDER_SUPERSET_HTTP", "TRACE")
+
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(t.Context(), &logs)
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/security/users/",
+		httpmock.NewStringResponder(201, `{"id": 5, "result": {"username": "bob"}}`))
+
+	resp, err := client.DoRequest(ctx, "POST", "/api/v1/security/users/", map[string]interface{}{
+		"username": "bob",
+		"password": "hunter2",
+	})
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+
+	// Peeking at the response for the log must not consume it.
+	assert.JSONEq(t, `{"id": 5, "result": {"username": "bob"}}`, string(body))
+
+	output := logs.String()
+	assert.NotContains(t, output, "hunter2")
+
+	entries, err := tflogtest.MultilineJSONDecode(strings.NewReader(output))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "Sending request to Superset", entries[0]["@message"])
+	assert.Equal(t, "provider.superset_http", entries[0]["@module"])
+	assert.Equal(t, "POST", entries[0]["method"])
+	assert.Equal(t, `{"password":"***","username":"bob"}`, entries[0]["body"])
+
+	assert.Equal(t, "Received response from Superset", entries[1]["@message"])
+	assert.EqualValues(t, 201, entries[1]["status"])
+	assert.Equal(t, `{"id":5,"result":{"username":"bob"}}`, entries[1]["body"])
+	assert.Contains(t, entries[1], "latency_ms")
+}
+
+// countingBody records how much of a response body has been read.
+type countingBody struct {
+	io.Reader
+	read int
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	b.read += n
+	return n, err
+}
+
+func (b *countingBody) Close() error { return nil }
+
+func TestLogResponse_SkipsBodyBelowTrace(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_SUPERSET_HTTP", "DEBUG")
+
+	var logs bytes.Buffer
+	ctx := withLogging(tflogtest.RootLogger(t.Context(), &logs))
+
+	req := httptest.NewRequest("GET", "http://test-host/api/v1/dataset/", nil)
+	body := &countingBody{Reader: strings.NewReader(`{"result": []}`)}
+	resp := &http.Response{StatusCode: 200, Header: http.Header{}, Body: body}
+
+	logResponse(ctx, req, resp, nil, time.Millisecond)
+
+	assert.Zero(t, body.read, "the body must not be peeked while TRACE is off")
+	assert.Empty(t, logs.String())
+	assert.Same(t, body, resp.Body)
+}
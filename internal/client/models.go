@@ -0,0 +1,220 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// EmbeddedJSON is a JSON document Superset stores and returns as a string, such as a dataset's
+// extra or a dashboard's json_metadata. The string is kept as returned and only checked by
+// Decode: Superset does not validate legacy rows, and one corrupted item must not fail a whole
+// list response. Null and empty strings decode to the zero value.
+type EmbeddedJSON string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *EmbeddedJSON) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*e = ""
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("expected a JSON document encoded as a string, got %s", data)
+	}
+	*e = EmbeddedJSON(s)
+	return nil
+}
+
+// String returns the document as Superset stored it.
+func (e EmbeddedJSON) String() string {
+	return string(e)
+}
+
+// Decode unmarshals the document into v. An empty document leaves v untouched; one that is not
+// valid JSON is reported as corrupted.
+func (e EmbeddedJSON) Decode(v any) error {
+	if e == "" {
+		return nil
+	}
+	if !json.Valid([]byte(e)) {
+		return fmt.Errorf("embedded JSON is not valid: %q", truncateBody(string(e), 64))
+	}
+	return json.Unmarshal([]byte(e), v)
+}
+
+// Owner is a user listed as an owner of a dataset or dashboard.
+type Owner struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Username  string `json:"username,omitempty"`
+}
+
+// DatabaseRef is the database a dataset belongs to, as embedded in dataset responses.
+type DatabaseRef struct {
+	ID           int64  `json:"id"`
+	DatabaseName string `json:"database_name"`
+	Backend      string `json:"backend,omitempty"`
+}
+
+// DatasetColumn is a physical or calculated column of a dataset.
+type DatasetColumn struct {
+	ID          int64  `json:"id"`
+	ColumnName  string `json:"column_name"`
+	Type        string `json:"type"`
+	Expression  string `json:"expression"`
+	VerboseName string `json:"verbose_name"`
+	Description string `json:"description"`
+	IsDttm      bool   `json:"is_dttm"`
+	Filterable  bool   `json:"filterable"`
+	Groupby     bool   `json:"groupby"`
+	IsActive    bool   `json:"is_active"`
+}
+
+// DatasetMetric is a saved metric of a dataset.
+type DatasetMetric struct {
+	ID          int64  `json:"id"`
+	MetricName  string `json:"metric_name"`
+	MetricType  string `json:"metric_type"`
+	Expression  string `json:"expression"`
+	VerboseName string `json:"verbose_name"`
+	Description string `json:"description"`
+	D3Format    string `json:"d3format"`
+	WarningText string `json:"warning_text"`
+}
+
+// Dataset is a Superset dataset as returned by the dataset endpoints. List responses only carry
+// a subset of the fields; the rest stay at their zero values.
+type Dataset struct {
+	ID        int64           `json:"id"`
+	UUID      string          `json:"uuid,omitempty"`
+	TableName string          `json:"table_name"`
+	Catalog   string          `json:"catalog"`
+	Schema    string          `json:"schema"`
+	SQL       string          `json:"sql"`
+	Kind      string          `json:"kind"`
+	Database  DatabaseRef     `json:"database"`
+	Owners    []Owner         `json:"owners"`
+	Columns   []DatasetColumn `json:"columns"`
+	Metrics   []DatasetMetric `json:"metrics"`
+	Extra     EmbeddedJSON    `json:"extra"`
+}
+
+// DatabaseParameters are the connection parameters Superset derives from a SQLAlchemy URI for
+// engines that support them. The password is always masked.
+type DatabaseParameters struct {
+	Host       string         `json:"host"`
+	Port       int64          `json:"port"`
+	Username   string         `json:"username"`
+	Password   string         `json:"password"`
+	Database   string         `json:"database"`
+	Query      map[string]any `json:"query,omitempty"`
+	Encryption bool           `json:"encryption"`
+}
+
+// SSHTunnel is the SSH tunnel of a database connection. Secrets are masked.
+type SSHTunnel struct {
+	ID                 int64  `json:"id,omitempty"`
+	ServerAddress      string `json:"server_address"`
+	ServerPort         int64  `json:"server_port"`
+	Username           string `json:"username"`
+	Password           string `json:"password,omitempty"`
+	PrivateKey         string `json:"private_key,omitempty"`
+	PrivateKeyPassword string `json:"private_key_password,omitempty"`
+}
+
+// Database is a Superset database connection. The sqlalchemy_uri password is masked in every
+// response; Parameters and SSHTunnel are only returned by the connection endpoint.
+type Database struct {
+	ID                  int64               `json:"id"`
+	UUID                string              `json:"uuid,omitempty"`
+	DatabaseName        string              `json:"database_name"`
+	Backend             string              `json:"backend"`
+	SQLAlchemyURI       string              `json:"sqlalchemy_uri"`
+	ConfigurationMethod string              `json:"configuration_method"`
+	ExposeInSQLLab      bool                `json:"expose_in_sqllab"`
+	AllowCTAS           bool                `json:"allow_ctas"`
+	AllowCVAS           bool                `json:"allow_cvas"`
+	AllowDML            bool                `json:"allow_dml"`
+	AllowFileUpload     bool                `json:"allow_file_upload"`
+	AllowRunAsync       bool                `json:"allow_run_async"`
+	ImpersonateUser     bool                `json:"impersonate_user"`
+	CacheTimeout        *int64              `json:"cache_timeout"`
+	ForceCTASSchema     string              `json:"force_ctas_schema"`
+	ServerCert          string              `json:"server_cert"`
+	Extra               EmbeddedJSON        `json:"extra"`
+	Parameters          *DatabaseParameters `json:"parameters,omitempty"`
+	SSHTunnel           *SSHTunnel          `json:"ssh_tunnel,omitempty"`
+}
+
+// Dashboard is a Superset dashboard as returned by GET /api/v1/dashboard/{id}.
+type Dashboard struct {
+	ID                   int64        `json:"id"`
+	UUID                 string       `json:"uuid,omitempty"`
+	DashboardTitle       string       `json:"dashboard_title"`
+	Slug                 string       `json:"slug"`
+	URL                  string       `json:"url"`
+	Published            bool         `json:"published"`
+	CSS                  string       `json:"css"`
+	CertifiedBy          string       `json:"certified_by"`
+	CertificationDetails string       `json:"certification_details"`
+	JSONMetadata         EmbeddedJSON `json:"json_metadata"`
+	PositionJSON         EmbeddedJSON `json:"position_json"`
+	Owners               []Owner      `json:"owners"`
+	Roles                []Role       `json:"roles"`
+}
+
+// decodeStrict decodes a response body into v. Decoding into typed models makes a field of the
+// wrong type an error rather than a silently dropped value. Unknown fields are ignored because
+// every Superset release adds some; trailing data after the document is rejected.
+func decodeStrict(r io.Reader, noun string, v any) error {
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("decode %s: %w", noun, err)
+	}
+	if dec.More() {
+		return fmt.Errorf("decode %s: unexpected data after the JSON document", noun)
+	}
+	return nil
+}
+
+// decodeItem decodes a {"result": {...}} response for the object with the given ID into a T.
+// Some endpoints, such as the database connection, leave the ID out of the result, so a missing
+// ID is filled in; a result for a different object is an error.
+func decodeItem[T any](r io.Reader, noun string, want int64, id func(*T) *int64) (*T, error) {
+	var payload struct {
+		Result *T `json:"result"`
+	}
+	if err := decodeStrict(r, noun, &payload); err != nil {
+		return nil, err
+	}
+	if payload.Result == nil {
+		return nil, fmt.Errorf("decode %s: response has no result", noun)
+	}
+	resultID := id(payload.Result)
+	if *resultID == 0 {
+		*resultID = want
+	}
+	if *resultID != want {
+		return nil, fmt.Errorf("decode %s: response is for id %d, expected %d", noun, *resultID, want)
+	}
+	return payload.Result, nil
+}
+
+// decodeCreated decodes a {"id": N, "result": {...}} create or update response into a T whose
+// ID is taken from the top-level id.
+func decodeCreated[T any](r io.Reader, noun string, setID func(*T, int64)) (*T, error) {
+	var payload struct {
+		ID     int64 `json:"id"`
+		Result T     `json:"result"`
+	}
+	if err := decodeStrict(r, noun, &payload); err != nil {
+		return nil, err
+	}
+	if payload.ID == 0 {
+		return nil, fmt.Errorf("decode %s: response has no id", noun)
+	}
+	setID(&payload.Result, payload.ID)
+	return &payload.Result, nil
+}
@@ -0,0 +1,263 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedJSON(t *testing.T) {
+	var doc struct {
+		Extra EmbeddedJSON `json:"extra"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"extra": "{\"certification\": {\"certified_by\": \"Data\"}}"}`), &doc))
+	var extra struct {
+		Certification struct {
+			CertifiedBy string `json:"certified_by"`
+		} `json:"certification"`
+	}
+	require.NoError(t, doc.Extra.Decode(&extra))
+	assert.Equal(t, "Data", extra.Certification.CertifiedBy)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"extra": null}`), &doc))
+	assert.Equal(t, EmbeddedJSON(""), doc.Extra)
+	require.NoError(t, doc.Extra.Decode(&extra), "an empty document decodes to nothing")
+
+	require.NoError(t, json.Unmarshal([]byte(`{"extra": "{not json"}`), &doc), "corrupted documents are only reported when decoded")
+	assert.Equal(t, EmbeddedJSON("{not json"), doc.Extra)
+	err := doc.Extra.Decode(&extra)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "embedded JSON is not valid")
+
+	err = json.Unmarshal([]byte(`{"extra": {"already": "an object"}}`), &doc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "encoded as a string")
+}
+
+func TestGetDataset_Typed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/dataset/7",
+		httpmock.NewStringResponder(200, `{
+			"id": 7,
+			"result": {
+				"id": 7,
+				"uuid": "0b7f0f7e-3c1a-4f0e-9d3e-2f5c1c9f1a11",
+				"table_name": "orders",
+				"schema": "public",
+				"sql": null,
+				"kind": "physical",
+				"database": {"id": 2, "database_name": "warehouse", "backend": "postgresql"},
+				"owners": [{"id": 1, "first_name": "Ada", "last_name": "Lovelace"}],
+				"columns": [
+					{"id": 10, "column_name": "created_at", "type": "TIMESTAMP", "is_dttm": true, "expression": null, "filterable": true, "groupby": true},
+					{"id": 11, "column_name": "total", "type": "NUMERIC", "is_dttm": false}
+				],
+				"metrics": [{"id": 20, "metric_name": "count", "expression": "COUNT(*)", "metric_type": "count", "d3format": null}],
+				"extra": "{\"warning_markdown\": \"Stale\"}",
+				"changed_on_humanized": "2 days ago"
+			}
+		}`))
+
+	ds, err := client.GetDataset(t.Context(), 7)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(7), ds.ID)
+	assert.Equal(t, "orders", ds.TableName)
+	assert.Equal(t, "", ds.SQL)
+	assert.Equal(t, DatabaseRef{ID: 2, DatabaseName: "warehouse", Backend: "postgresql"}, ds.Database)
+	assert.Equal(t, []Owner{{ID: 1, FirstName: "Ada", LastName: "Lovelace"}}, ds.Owners)
+	require.Len(t, ds.Columns, 2)
+	assert.True(t, ds.Columns[0].IsDttm)
+	assert.Equal(t, "NUMERIC", ds.Columns[1].Type)
+	require.Len(t, ds.Metrics, 1)
+	assert.Equal(t, "COUNT(*)", ds.Metrics[0].Expression)
+	assert.JSONEq(t, `{"warning_markdown": "Stale"}`, ds.Extra.String())
+}
+
+func TestGetDataset_RejectsUnexpectedShapes(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "id as string", body: `{"result": {"id": "7", "table_name": "orders"}}`, wantErr: "cannot unmarshal string"},
+		{name: "database as id", body: `{"result": {"id": 7, "database": 2}}`, wantErr: "cannot unmarshal number"},
+		{name: "missing result", body: `{"message": "ok"}`, wantErr: "response has no result"},
+		{name: "other dataset", body: `{"result": {"id": 8, "table_name": "orders"}}`, wantErr: "response is for id 8, expected 7"},
+		{name: "extra as object", body: `{"result": {"id": 7, "extra": {}}}`, wantErr: "encoded as a string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			client := &Client{Host: "http://test-host", Token: "test-token"}
+			httpmock.RegisterResponder("GET", "http://test-host/api/v1/dataset/7", httpmock.NewStringResponder(200, tt.body))
+
+			_, err := client.GetDataset(t.Context(), 7)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "decode dataset")
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGetDatabaseIDByName_ToleratesCorruptedExtra(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/database/?q=(page:0,page_size:100)",
+		httpmock.NewStringResponder(200, `{"result": [
+			{"id": 1, "database_name": "legacy", "extra": "{\"metadata_params\": {"},
+			{"id": 2, "database_name": "warehouse", "extra": "{}"}
+		], "count": 2}`))
+
+	id, err := client.GetDatabaseIDByName(t.Context(), "warehouse")
+	require.NoError(t, err, "a corrupted extra on another database must not break the lookup")
+	assert.Equal(t, int64(2), id)
+
+	databases, err := client.GetAllDatabases(t.Context())
+	require.NoError(t, err)
+	var extra map[string]any
+	assert.ErrorContains(t, databases[0].Extra.Decode(&extra), "embedded JSON is not valid")
+}
+
+func TestCreateDataset_Typed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "test-csrf-token"}`))
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/dataset/",
+		httpmock.NewStringResponder(201, `{"id": 9, "result": {"database": 2, "schema": "public", "table_name": "orders", "sql": ""}}`))
+
+	ds, err := client.CreateDataset(t.Context(), DatasetRequest{TableName: "orders", Database: 2, Schema: "public"})
+	require.NoError(t, err)
+	assert.Equal(t, &Dataset{ID: 9, TableName: "orders", Schema: "public", Database: DatabaseRef{ID: 2}}, ds)
+}
+
+func TestGetDatabaseConnectionByID_Typed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/database/3/connection",
+		httpmock.NewStringResponder(200, `{
+			"id": 3,
+			"result": {
+				"database_name": "warehouse",
+				"backend": "postgresql",
+				"sqlalchemy_uri": "postgresql://app:XXXXXXXXXX@db:5432/analytics",
+				"allow_ctas": true,
+				"allow_dml": false,
+				"expose_in_sqllab": true,
+				"cache_timeout": null,
+				"extra": "{\"metadata_params\": {}}",
+				"parameters": {"host": "db", "port": 5432, "username": "app", "password": "XXXXXXXXXX", "database": "analytics", "query": {}},
+				"ssh_tunnel": {"id": 1, "server_address": "bastion", "server_port": 22, "username": "tunnel", "password": "XXXXXXXXXX"}
+			}
+		}`))
+
+	db, err := client.GetDatabaseConnectionByID(t.Context(), 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), db.ID, "filled in from the requested id")
+	assert.Equal(t, "warehouse", db.DatabaseName)
+	assert.True(t, db.AllowCTAS)
+	assert.False(t, db.AllowDML)
+	assert.Nil(t, db.CacheTimeout)
+	require.NotNil(t, db.Parameters)
+	assert.Equal(t, int64(5432), db.Parameters.Port)
+	assert.Equal(t, "analytics", db.Parameters.Database)
+	require.NotNil(t, db.SSHTunnel)
+	assert.Equal(t, "bastion", db.SSHTunnel.ServerAddress)
+	assert.Equal(t, int64(22), db.SSHTunnel.ServerPort)
+}
+
+func TestCreateDatabase_Typed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "test-csrf-token"}`))
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/database/",
+		httpmock.NewStringResponder(201, `{"id": 4, "result": {"database_name": "warehouse", "cache_timeout": 300, "allow_run_async": true}}`))
+
+	db, err := client.CreateDatabase(t.Context(), map[string]interface{}{"database_name": "warehouse"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), db.ID)
+	assert.Equal(t, "warehouse", db.DatabaseName)
+	require.NotNil(t, db.CacheTimeout)
+	assert.Equal(t, int64(300), *db.CacheTimeout)
+	assert.True(t, db.AllowRunAsync)
+
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/database/",
+		httpmock.NewStringResponder(201, `{"result": {"database_name": "warehouse"}}`))
+	_, err = client.CreateDatabase(t.Context(), map[string]interface{}{"database_name": "warehouse"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "response has no id")
+}
+
+func TestGetDashboard_Typed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/dashboard/5",
+		httpmock.NewStringResponder(200, `{
+			"id": 5,
+			"result": {
+				"id": 5,
+				"dashboard_title": "Sales",
+				"slug": null,
+				"published": true,
+				"css": "",
+				"json_metadata": "{\"color_scheme\": \"supersetColors\"}",
+				"position_json": "{\"DASHBOARD_VERSION_KEY\": \"v2\"}",
+				"owners": [{"id": 1, "first_name": "Ada", "last_name": "Lovelace"}],
+				"roles": [{"id": 3, "name": "Sales"}],
+				"url": "/superset/dashboard/5/"
+			}
+		}`))
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/dashboard/6",
+		httpmock.NewStringResponder(404, `{"message": "Not found"}`))
+
+	dashboard, err := client.GetDashboard(t.Context(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "Sales", dashboard.DashboardTitle)
+	assert.Equal(t, "", dashboard.Slug)
+	assert.True(t, dashboard.Published)
+	assert.Equal(t, []Role{{ID: 3, Name: "Sales"}}, dashboard.Roles)
+	var metadata struct {
+		ColorScheme string `json:"color_scheme"`
+	}
+	require.NoError(t, dashboard.JSONMetadata.Decode(&metadata))
+	assert.Equal(t, "supersetColors", metadata.ColorScheme)
+	assert.JSONEq(t, `{"DASHBOARD_VERSION_KEY": "v2"}`, dashboard.PositionJSON.String())
+
+	_, err = client.GetDashboard(t.Context(), 6)
+	assert.True(t, IsNotFound(err))
+
+	exists, err := client.DashboardExistsByID(t.Context(), 5)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = client.DashboardExistsByID(t.Context(), 6)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
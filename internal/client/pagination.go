@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+)
+
+// DefaultPageSize matches Superset's default FAB_API_MAX_PAGE_SIZE.
+const DefaultPageSize = 100
+
+// paginate walks a Superset list endpoint with page/page_size until the reported count is
+// reached and yields every item of the result arrays. The endpoint is the path without a query
+// string, query carries filters, ordering and columns (its Page and PageSize are managed here),
+// and noun names the listed objects in error messages. Iteration stops at the first error,
+// which is yielded together with the zero value of T.
+func paginate[T any](ctx context.Context, c *Client, endpoint, noun string, query ListQuery) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		pageSize := DefaultPageSize
+		if query.PageSize > 0 {
+			pageSize = query.PageSize
+		}
+		seen := 0
+
+		for page := 0; ; page++ {
+			query.Page, query.PageSize = page, pageSize
+			items, count, err := fetchPage[T](ctx, c, query.Endpoint(endpoint), noun)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			seen += len(items)
+
+			if len(items) == 0 || (count > 0 && seen >= count) {
+				return
+			}
+			if len(items) < pageSize {
+				// A server with a lower FAB_API_MAX_PAGE_SIZE caps the first page; continue
+				// with its page size so the offsets of later pages line up.
+				if page == 0 && count > seen {
+					pageSize = len(items)
+					continue
+				}
+				return
+			}
+		}
+	}
+}
+
+// fetchPage fetches a single page of a list endpoint. The endpoint already carries the q= parameter.
+func fetchPage[T any](ctx context.Context, c *Client, endpoint, noun string) ([]T, int, error) {
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, newAPIError(resp, fmt.Sprintf("fetch %s from Superset", noun))
+	}
+
+	var result struct {
+		Result []T `json:"result"`
+		Count  int `json:"count"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, 0, err
+	}
+
+	return result.Result, result.Count, nil
+}
+
+// collect drains a paginated sequence into a slice.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
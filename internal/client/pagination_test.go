@@ -0,0 +1,176 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerUserPages serves total users in pages of the given size from the users list endpoint.
+func registerUserPages(total, size int) {
+	for page := 0; page*size < total; page++ {
+		users := usersRange(page*size, min((page+1)*size, total))
+		body, _ := json.Marshal(map[string]interface{}{"result": users, "count": total})
+		httpmock.RegisterResponder("GET",
+			fmt.Sprintf("http://test-host/api/v1/security/users/?q=(page:%d,page_size:%d)", page, size),
+			httpmock.NewBytesResponder(200, body))
+	}
+}
+
+// usersRange builds list entries for users from+1 through to.
+func usersRange(from, to int) []map[string]interface{} {
+	var users []map[string]interface{}
+	for i := from; i < to; i++ {
+		users = append(users, map[string]interface{}{"id": i + 1, "username": fmt.Sprintf("user%d", i+1)})
+	}
+	return users
+}
+
+func TestFetchUsers_WalksAllPages(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+	registerUserPages(250, DefaultPageSize)
+
+	users, err := client.FetchUsers(t.Context())
+
+	require.NoError(t, err)
+	assert.Len(t, users, 250)
+	assert.Equal(t, "user250", users[249].Username)
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+func TestFetchUsers_AdaptsToServerPageSizeCap(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	// The server ignores page_size:100 and caps pages at 40 items.
+	registerUserPages(90, 40)
+	first, _ := json.Marshal(map[string]interface{}{"result": usersRange(0, 40), "count": 90})
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/users/?q=(page:0,page_size:100)",
+		httpmock.NewBytesResponder(200, first))
+
+	users, err := client.FetchUsers(t.Context())
+
+	require.NoError(t, err)
+	assert.Len(t, users, 90)
+	assert.Equal(t, int64(41), users[40].ID)
+}
+
+func TestGetRoleIDByName_StopsAtMatch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	var roles []map[string]interface{}
+	for i := 0; i < DefaultPageSize; i++ {
+		roles = append(roles, map[string]interface{}{"id": i + 1, "name": fmt.Sprintf("role%d", i+1)})
+	}
+	body, _ := json.Marshal(map[string]interface{}{"result": roles, "count": 500})
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/roles?q=(page:0,page_size:100)",
+		httpmock.NewBytesResponder(200, body))
+
+	id, err := client.GetRoleIDByName(t.Context(), "role42")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestGetAllDatasets_PropagatesPageError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	var datasets []map[string]interface{}
+	for i := 0; i < DefaultPageSize; i++ {
+		datasets = append(datasets, map[string]interface{}{"id": i + 1})
+	}
+	body, _ := json.Marshal(map[string]interface{}{"result": datasets, "count": 150})
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/dataset/?q=(page:0,page_size:100)",
+		httpmock.NewBytesResponder(200, body))
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/dataset/?q=(page:1,page_size:100)",
+		httpmock.NewStringResponder(500, `{"message": "boom"}`))
+
+	datasetsOut, err := client.GetAllDatasets(t.Context())
+
+	assert.Nil(t, datasetsOut)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to fetch datasets")
+}
+
+func TestGetDashboardChartUUIDs_BatchesChartLookups(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	const total = 120
+	charts := make([]map[string]interface{}, 0, total)
+	for i := 1; i <= total; i++ {
+		charts = append(charts, map[string]interface{}{"id": i})
+	}
+	body, _ := json.Marshal(map[string]interface{}{"result": charts})
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/dashboard/5/charts",
+		httpmock.NewBytesResponder(200, body))
+
+	// The chart list answers an "id in" filter with the id and uuid of every listed chart.
+	inFilter := regexp.MustCompile(`filters:!\(\(col:id,opr:in,value:!\(([0-9,]+)\)\)\)`)
+	var chunkSizes []int
+	httpmock.RegisterResponder("GET", "=~^http://test-host/api/v1/chart/\\?q=",
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query().Get("q")
+			assert.Contains(t, q, "columns:!(id,uuid)")
+			m := inFilter.FindStringSubmatch(q)
+			require.NotNil(t, m, "unexpected query %s", q)
+			var result []map[string]interface{}
+			for _, raw := range strings.Split(m[1], ",") {
+				id, _ := strconv.Atoi(raw)
+				result = append(result, map[string]interface{}{"id": id, "uuid": fmt.Sprintf("uuid-%d", id)})
+			}
+			chunkSizes = append(chunkSizes, len(result))
+			return httpmock.NewJsonResponse(200, map[string]interface{}{"result": result, "count": len(result)})
+		})
+
+	uuids, err := client.GetDashboardChartUUIDs(t.Context(), 5)
+
+	require.NoError(t, err)
+	assert.Len(t, uuids, total)
+	assert.Equal(t, int64(77), uuids["uuid-77"])
+	assert.Equal(t, []int{lookupChunkSize, lookupChunkSize, total - 2*lookupChunkSize}, chunkSizes)
+	assert.Equal(t, 4, httpmock.GetTotalCallCount(), "one dashboard call and one list call per chunk")
+}
+
+func TestGetChartIDsByUUIDs_OmitsMissing(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET",
+		"http://test-host/api/v1/chart/?q=(columns:!(id,uuid),filters:!((col:uuid,opr:in,value:!(aaa,bbb))),page:0,page_size:100)",
+		httpmock.NewStringResponder(200, `{"result": [{"id": 3, "uuid": "aaa"}], "count": 1}`))
+
+	ids, err := client.GetChartIDsByUUIDs(t.Context(), []string{"aaa", "bbb"})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"aaa": 3}, ids)
+
+	ids, err = client.GetDatasetIDsByUUIDs(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount(), "no UUIDs, no request")
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ReportRecipient is where a report schedule sends its notifications. RecipientConfigJSON holds
+// the target, for example {"target": "team@example.com"}; Superset returns it as a string.
+type ReportRecipient struct {
+	Type                string       `json:"type"`
+	RecipientConfigJSON EmbeddedJSON `json:"recipient_config_json"`
+}
+
+// ReportScheduleChart is the chart a report schedule captures, as embedded in report responses.
+type ReportScheduleChart struct {
+	ID        int64  `json:"id"`
+	SliceName string `json:"slice_name"`
+}
+
+// ReportSchedule is an alert or report as returned by GET /api/v1/report/{id}. Dashboard, Chart
+// and Database are nil when unset. ValidatorConfigJSON is a JSON document encoded as a string.
+type ReportSchedule struct {
+	ID                  int64                `json:"id"`
+	Type                string               `json:"type"`
+	Name                string               `json:"name"`
+	Description         string               `json:"description"`
+	Active              bool                 `json:"active"`
+	Crontab             string               `json:"crontab"`
+	Timezone            string               `json:"timezone"`
+	Dashboard           *ChartDashboard      `json:"dashboard"`
+	Chart               *ReportScheduleChart `json:"chart"`
+	Database            *DatabaseRef         `json:"database"`
+	SQL                 string               `json:"sql"`
+	ValidatorType       string               `json:"validator_type"`
+	ValidatorConfigJSON EmbeddedJSON         `json:"validator_config_json"`
+	Recipients          []ReportRecipient    `json:"recipients"`
+	WorkingTimeout      int64                `json:"working_timeout"`
+	LogRetention        int64                `json:"log_retention"`
+	GracePeriod         int64                `json:"grace_period"`
+	ReportFormat        string               `json:"report_format"`
+}
+
+// ReportRecipientRequest is a recipient in a report schedule create or update. Unlike the
+// response, Superset expects the recipient configuration as an object.
+type ReportRecipientRequest struct {
+	Type                string                `json:"type"`
+	RecipientConfigJSON ReportRecipientConfig `json:"recipient_config_json"`
+}
+
+// ReportRecipientConfig is the configuration of a recipient: an email address list or a Slack
+// channel.
+type ReportRecipientConfig struct {
+	Target string `json:"target"`
+}
+
+// ReportScheduleRequest is the body of a report schedule create or update. Every field is sent
+// on update, and nil pointers clear them, so a schedule can move between targets or from alert
+// to report. A create leaves nil pointers out, as Superset's POST schema rejects null for them.
+// ValidatorConfigJSON is the exception: Superset expects an object, not a string, and rejects
+// null, so it is always left out when nil and an update keeps the previous condition.
+type ReportScheduleRequest struct {
+	Type                string                   `json:"type"`
+	Name                string                   `json:"name"`
+	Description         string                   `json:"description"`
+	Active              bool                     `json:"active"`
+	Crontab             string                   `json:"crontab"`
+	Timezone            string                   `json:"timezone"`
+	Dashboard           *int64                   `json:"dashboard"`
+	Chart               *int64                   `json:"chart"`
+	Database            *int64                   `json:"database"`
+	SQL                 *string                  `json:"sql"`
+	ValidatorType       *string                  `json:"validator_type"`
+	ValidatorConfigJSON json.RawMessage          `json:"validator_config_json,omitempty"`
+	Recipients          []ReportRecipientRequest `json:"recipients"`
+	WorkingTimeout      int64                    `json:"working_timeout"`
+	LogRetention        int64                    `json:"log_retention"`
+	GracePeriod         int64                    `json:"grace_period"`
+	ReportFormat        string                   `json:"report_format"`
+}
+
+// reportScheduleCreateRequest is the POST shape of ReportScheduleRequest, leaving unset targets
+// and alert fields out instead of sending null.
+type reportScheduleCreateRequest struct {
+	Type                string                   `json:"type"`
+	Name                string                   `json:"name"`
+	Description         string                   `json:"description"`
+	Active              bool                     `json:"active"`
+	Crontab             string                   `json:"crontab"`
+	Timezone            string                   `json:"timezone"`
+	Dashboard           *int64                   `json:"dashboard,omitempty"`
+	Chart               *int64                   `json:"chart,omitempty"`
+	Database            *int64                   `json:"database,omitempty"`
+	SQL                 *string                  `json:"sql,omitempty"`
+	ValidatorType       *string                  `json:"validator_type,omitempty"`
+	ValidatorConfigJSON json.RawMessage          `json:"validator_config_json,omitempty"`
+	Recipients          []ReportRecipientRequest `json:"recipients"`
+	WorkingTimeout      int64                    `json:"working_timeout"`
+	LogRetention        int64                    `json:"log_retention"`
+	GracePeriod         int64                    `json:"grace_period"`
+	ReportFormat        string                   `json:"report_format"`
+}
+
+// CreateReportSchedule creates an alert or report and returns its ID.
+// POST /api/v1/report/.
+func (c *Client) CreateReportSchedule(ctx context.Context, report ReportScheduleRequest) (int64, error) {
+	resp, err := c.DoRequestWithCSRF(ctx, "POST", "/api/v1/report/", reportScheduleCreateRequest(report))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return 0, newAPIError(resp, "create report schedule")
+	}
+
+	var id int64
+	if _, err := decodeCreated(resp.Body, "created report schedule", func(_ *ReportScheduleRequest, createdID int64) { id = createdID }); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// GetReportSchedule fetches an alert or report by ID.
+// GET /api/v1/report/{id}.
+func (c *Client) GetReportSchedule(ctx context.Context, id int64) (*ReportSchedule, error) {
+	resp, err := c.DoRequest(ctx, "GET", fmt.Sprintf("/api/v1/report/%d", id), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "fetch report schedule")
+	}
+
+	return decodeItem(resp.Body, "report schedule", id, func(r *ReportSchedule) *int64 { return &r.ID })
+}
+
+// UpdateReportSchedule replaces an alert or report by ID.
+// PUT /api/v1/report/{id}.
+func (c *Client) UpdateReportSchedule(ctx context.Context, id int64, report ReportScheduleRequest) error {
+	resp, err := c.DoRequestWithCSRF(ctx, "PUT", fmt.Sprintf("/api/v1/report/%d", id), report)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp, "update report schedule")
+	}
+
+	return nil
+}
+
+// DeleteReportSchedule deletes an alert or report by ID.
+// Returns nil if the report schedule is already deleted (404).
+func (c *Client) DeleteReportSchedule(ctx context.Context, id int64) error {
+	resp, err := c.DoRequestWithCSRF(ctx, "DELETE", fmt.Sprintf("/api/v1/report/%d", id), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil // already deleted
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return newAPIError(resp, "delete report schedule")
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateReportSchedule_Payload(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "test-csrf-token"}`))
+
+	var body string
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/report/",
+		func(req *http.Request) (*http.Response, error) {
+			data, _ := io.ReadAll(req.Body)
+			body = string(data)
+			return httpmock.NewStringResponse(201, `{"id": 9, "result": {"type": "Alert", "name": "Late orders",
+				"recipients": [{"type": "Email", "recipient_config_json": {"target": "ops@example.com"}}],
+				"validator_config_json": {"op": ">", "threshold": 10}}}`), nil
+		})
+
+	chartID, databaseID := int64(3), int64(1)
+	sql := "SELECT count(*) FROM orders WHERE late"
+	validator := "operator"
+	id, err := client.CreateReportSchedule(t.Context(), ReportScheduleRequest{
+		Type:                "Alert",
+		Name:                "Late orders",
+		Active:              true,
+		Crontab:             "*/15 * * * *",
+		Timezone:            "UTC",
+		Chart:               &chartID,
+		Database:            &databaseID,
+		SQL:                 &sql,
+		ValidatorType:       &validator,
+		ValidatorConfigJSON: json.RawMessage(`{"op": ">", "threshold": 10}`),
+		Recipients: []ReportRecipientRequest{
+			{Type: "Email", RecipientConfigJSON: ReportRecipientConfig{Target: "ops@example.com"}},
+		},
+		WorkingTimeout: 3600,
+		LogRetention:   90,
+		GracePeriod:    14400,
+		ReportFormat:   "PNG",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), id)
+
+	assert.JSONEq(t, `{
+		"type": "Alert", "name": "Late orders", "description": "", "active": true,
+		"crontab": "*/15 * * * *", "timezone": "UTC",
+		"chart": 3, "database": 1,
+		"sql": "SELECT count(*) FROM orders WHERE late",
+		"validator_type": "operator", "validator_config_json": {"op": ">", "threshold": 10},
+		"recipients": [{"type": "Email", "recipient_config_json": {"target": "ops@example.com"}}],
+		"working_timeout": 3600, "log_retention": 90, "grace_period": 14400, "report_format": "PNG"
+	}`, body)
+}
+
+func TestUpdateReportSchedule_ReportClearsAlertFields(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "test-csrf-token"}`))
+
+	var payload map[string]any
+	httpmock.RegisterResponder("PUT", "http://test-host/api/v1/report/9",
+		func(req *http.Request) (*http.Response, error) {
+			data, _ := io.ReadAll(req.Body)
+			require.NoError(t, json.Unmarshal(data, &payload))
+			return httpmock.NewStringResponse(200, `{"id": 9, "result": {}}`), nil
+		})
+
+	dashboardID := int64(2)
+	require.NoError(t, client.UpdateReportSchedule(t.Context(), 9, ReportScheduleRequest{
+		Type:      "Report",
+		Name:      "Weekly sales",
+		Crontab:   "0 9 * * 1",
+		Timezone:  "Europe/Berlin",
+		Dashboard: &dashboardID,
+	}))
+
+	assert.Contains(t, payload, "sql")
+	assert.Nil(t, payload["sql"], "a report clears the alert query")
+	assert.Nil(t, payload["validator_type"])
+	assert.NotContains(t, payload, "validator_config_json", "Superset rejects a null condition")
+	assert.Nil(t, payload["chart"], "the chart is cleared when the target moves to a dashboard")
+	assert.Equal(t, float64(2), payload["dashboard"])
+}
+
+func TestGetReportSchedule(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/report/9",
+		httpmock.NewStringResponder(200, `{"id": 9, "result": {
+			"id": 9, "type": "Alert", "name": "Late orders", "description": null, "active": true,
+			"crontab": "*/15 * * * *", "timezone": "UTC", "creation_method": "alerts_reports",
+			"dashboard": null, "chart": {"id": 3, "slice_name": "Orders"},
+			"database": {"id": 1, "database_name": "warehouse"},
+			"sql": "SELECT 1", "validator_type": "operator",
+			"validator_config_json": "{\"op\": \">\", \"threshold\": 10}",
+			"recipients": [{"id": 4, "type": "Slack", "recipient_config_json": "{\"target\": \"#ops\"}"}],
+			"owners": [{"id": 1, "first_name": "Admin", "last_name": "User"}],
+			"working_timeout": 3600, "log_retention": 90, "grace_period": 14400, "report_format": "PNG",
+			"last_state": "Not triggered", "last_eval_dttm": null
+		}}`))
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/report/10",
+		httpmock.NewStringResponder(404, `{"message": "Not found"}`))
+
+	report, err := client.GetReportSchedule(t.Context(), 9)
+	require.NoError(t, err)
+	assert.Nil(t, report.Dashboard)
+	require.NotNil(t, report.Chart)
+	assert.Equal(t, int64(3), report.Chart.ID)
+	require.NotNil(t, report.Database)
+	assert.Equal(t, "warehouse", report.Database.DatabaseName)
+	assert.JSONEq(t, `{"op": ">", "threshold": 10}`, report.ValidatorConfigJSON.String())
+	require.Len(t, report.Recipients, 1)
+
+	var target ReportRecipientConfig
+	require.NoError(t, report.Recipients[0].RecipientConfigJSON.Decode(&target))
+	assert.Equal(t, "#ops", target.Target)
+
+	_, err = client.GetReportSchedule(t.Context(), 10)
+	assert.True(t, IsNotFound(err), "got %v", err)
+}
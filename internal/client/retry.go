@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// DefaultMaxRetries is the number of retries used when the provider does not configure max_retries.
+	DefaultMaxRetries = 3
+	// DefaultRetryMaxWait caps a single backoff sleep when the provider does not configure retry_max_wait.
+	DefaultRetryMaxWait = 30 * time.Second
+
+	retryBaseWait = 500 * time.Millisecond
+)
+
+// requestBuilder builds a fresh *http.Request for every attempt so that request bodies can be replayed.
+type requestBuilder func(ctx context.Context) (*http.Request, error)
+
+// doWithRetry sends the request produced by build, retrying transient failures with jittered
+// exponential backoff. Gateway errors (502/503/504) and connection resets are only retried for
+// idempotent methods; 429 responses and refused connections never reached Superset, so they are
+// retried for every method. A Retry-After header on the response takes precedence over the backoff.
+func (c *Client) doWithRetry(ctx context.Context, build requestBuilder) (*http.Response, error) {
+	return c.retry(ctx, build, true)
+}
+
+// doExternalWithRetry is doWithRetry for endpoints outside Superset, such as an OAuth2 token
+// URL. The extra headers, session cookies and concurrency limit are Superset's, so none of them
+// apply: gateway keys must not leak to a third party and its cookies must not join the session.
+func (c *Client) doExternalWithRetry(ctx context.Context, build requestBuilder) (*http.Response, error) {
+	return c.retry(ctx, build, false)
+}
+
+// retry implements doWithRetry; superset selects whether the request goes to the Superset host.
+func (c *Client) retry(ctx context.Context, build requestBuilder, superset bool) (*http.Response, error) {
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	httpClient = withoutUploadTimeout(ctx, httpClient)
+
+	ctx = withLogging(ctx)
+
+	for attempt := 0; ; attempt++ {
+		req, err := build(ctx)
+		if err != nil {
+			return nil, err
+		}
+		release := func() {}
+		if superset {
+			for key, value := range c.extraHeaders {
+				if req.Header.Get(key) == "" {
+					req.Header.Set(key, value)
+				}
+			}
+			c.session.addCookies(req)
+
+			release, err = c.limiter.acquire(ctx)
+			if err != nil {
+				if req.Body != nil {
+					// Unblock a streamed body's writer.
+					req.Body.Close()
+				}
+				return nil, err
+			}
+		}
+
+		logRequest(ctx, req, attempt)
+		start := time.Now()
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			release()
+		} else {
+			// The slot stays taken while the body streams, until the caller closes it.
+			resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+		}
+		logResponse(ctx, req, resp, err, time.Since(start))
+		if err == nil && superset {
+			c.session.storeCookies(req.URL, resp)
+		}
+
+		if attempt >= c.MaxRetries || !shouldRetry(req.Method, resp, err) {
+			return resp, err
+		}
+
+		wait := c.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = min(retryAfter, c.retryMaxWait())
+			}
+			// Drain the body so the underlying connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		tflog.SubsystemDebug(ctx, LogSubsystem, "Retrying Superset request", map[string]interface{}{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait_ms": wait.Milliseconds(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// releaseOnClose frees a limiter slot when the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Close implements io.Closer.
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// shouldRetry reports whether a request with the given method and outcome is worth another attempt.
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return true
+		}
+		if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return isIdempotent(method)
+		}
+		return false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+// isIdempotent reports whether repeating a request with this method is safe.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff returns the sleep before retry number attempt+1 using full jitter.
+func (c *Client) backoff(attempt int) time.Duration {
+	maxWait := c.retryMaxWait()
+	wait := retryBaseWait << attempt
+	if wait <= 0 || wait > maxWait {
+		wait = maxWait
+	}
+	return time.Duration(rand.Int64N(int64(wait) + 1))
+}
+
+func (c *Client) retryMaxWait() time.Duration {
+	if c.RetryMaxWait > 0 {
+		return c.RetryMaxWait
+	}
+	return DefaultRetryMaxWait
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
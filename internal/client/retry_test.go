@@ -0,0 +1,142 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoRequest_RetriesTransientGET(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{
+		Host:         "http://test-host",
+		Token:        "test-token",
+		MaxRetries:   3,
+		RetryMaxWait: time.Millisecond,
+	}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/42",
+		httpmock.ResponderFromMultipleResponses([]*http.Response{
+			httpmock.NewStringResponse(503, `upstream unavailable`),
+			httpmock.NewStringResponse(502, `bad gateway`),
+			httpmock.NewStringResponse(200, `{"result": {"id": 42, "template_name": "Test", "css": "body {}"}}`),
+		}))
+
+	tmpl, err := client.GetCSSTemplate(t.Context(), 42)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, tmpl.ID)
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+func TestDoRequest_GivesUpAfterMaxRetries(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{
+		Host:         "http://test-host",
+		Token:        "test-token",
+		MaxRetries:   2,
+		RetryMaxWait: time.Millisecond,
+	}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/42",
+		httpmock.NewStringResponder(504, `gateway timeout`))
+
+	_, err := client.GetCSSTemplate(t.Context(), 42)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "504")
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+func TestDoRequest_DoesNotRetryGatewayErrorOnPOST(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{
+		Host:         "http://test-host",
+		Token:        "test-token",
+		MaxRetries:   3,
+		RetryMaxWait: time.Millisecond,
+	}
+
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/security/roles/",
+		httpmock.NewStringResponder(502, `bad gateway`))
+
+	resp, err := client.DoRequest(t.Context(), "POST", "/api/v1/security/roles/", map[string]string{"name": "x"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 502, resp.StatusCode)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestDoRequest_RetriesTooManyRequestsOnPOSTWithBody(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{
+		Host:         "http://test-host",
+		Token:        "test-token",
+		MaxRetries:   1,
+		RetryMaxWait: time.Millisecond,
+	}
+
+	var bodies []string
+	throttled := httpmock.NewStringResponse(429, `slow down`)
+	throttled.Header.Set("Retry-After", "0")
+	responses := []*http.Response{throttled, httpmock.NewStringResponse(201, `{"id": 1}`)}
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/security/roles/",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+			resp := responses[0]
+			responses = responses[1:]
+			return resp, nil
+		})
+
+	resp, err := client.DoRequest(t.Context(), "POST", "/api/v1/security/roles/", map[string]string{"name": "x"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, []string{`{"name":"x"}`, `{"name":"x"}`}, bodies)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "empty", value: "", wantOK: false},
+		{name: "seconds", value: "7", want: 7 * time.Second, wantOK: true},
+		{name: "negative", value: "-1", wantOK: false},
+		{name: "past date", value: "Mon, 02 Jan 2006 15:04:05 GMT", want: 0, wantOK: true},
+		{name: "garbage", value: "soon", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBackoffIsCappedByRetryMaxWait(t *testing.T) {
+	client := &Client{RetryMaxWait: 50 * time.Millisecond}
+
+	for attempt := 0; attempt < 20; attempt++ {
+		wait := client.backoff(attempt)
+		assert.GreaterOrEqual(t, wait, time.Duration(0))
+		assert.LessOrEqual(t, wait, 50*time.Millisecond)
+	}
+}
@@ -0,0 +1,202 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Rison is the compact, URI-friendly JSON dialect Superset expects in the q= parameter
+// of its list endpoints. See https://github.com/Nanonid/rison for the grammar.
+
+const (
+	risonNotIDChar  = " '!:(),*@$"
+	risonNotIDStart = "-0123456789"
+)
+
+// risonEncode encodes v as Rison. Nil, bools, strings, numbers, slices, arrays and maps are
+// encoded structurally, with map keys in sorted order; any other value is encoded as the
+// string produced by fmt.Sprint.
+func risonEncode(v interface{}) string {
+	var b strings.Builder
+	risonWrite(&b, reflect.ValueOf(v))
+	return b.String()
+}
+
+func risonWrite(b *strings.Builder, v reflect.Value) {
+	if !v.IsValid() {
+		b.WriteString("!n")
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			b.WriteString("!n")
+			return
+		}
+		risonWrite(b, v.Elem())
+	case reflect.Bool:
+		if v.Bool() {
+			b.WriteString("!t")
+		} else {
+			b.WriteString("!f")
+		}
+	case reflect.String:
+		b.WriteString(risonString(v.String()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		b.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		// Rison numbers follow JSON, but the exponent marker has no sign when positive.
+		b.WriteString(strings.Replace(strconv.FormatFloat(v.Float(), 'g', -1, 64), "e+", "e", 1))
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			b.WriteString("!()")
+			return
+		}
+		b.WriteString("!(")
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			risonWrite(b, v.Index(i))
+		}
+		b.WriteByte(')')
+	case reflect.Map:
+		keys := v.MapKeys()
+		names := make(map[string]reflect.Value, len(keys))
+		sorted := make([]string, 0, len(keys))
+		for _, k := range keys {
+			name := fmt.Sprint(k.Interface())
+			names[name] = k
+			sorted = append(sorted, name)
+		}
+		sort.Strings(sorted)
+		b.WriteByte('(')
+		for i, name := range sorted {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(risonString(name))
+			b.WriteByte(':')
+			risonWrite(b, v.MapIndex(names[name]))
+		}
+		b.WriteByte(')')
+	default:
+		b.WriteString(risonString(fmt.Sprint(v.Interface())))
+	}
+}
+
+// risonString encodes s as a bare identifier when the grammar allows it and as a quoted
+// string otherwise, escaping ' and ! with a leading !.
+func risonString(s string) string {
+	if isRisonID(s) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('\'')
+	for _, r := range s {
+		if r == '\'' || r == '!' {
+			b.WriteByte('!')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+func isRisonID(s string) bool {
+	if s == "" || strings.ContainsAny(s[:1], risonNotIDStart) {
+		return false
+	}
+	for _, r := range s {
+		if r < 0x20 || r > 0x7e || strings.ContainsRune(risonNotIDChar, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// risonQueryEscape escapes an encoded Rison value for the query string the way rison's
+// encode_uri does: reserved URI characters are percent-encoded, spaces become '+', and the
+// Rison punctuation ( ) ! ' * , : stays readable.
+func risonQueryEscape(s string) string {
+	escaped := url.QueryEscape(s)
+	return risonUnescaper.Replace(escaped)
+}
+
+var risonUnescaper = strings.NewReplacer(
+	"%28", "(",
+	"%29", ")",
+	"%21", "!",
+	"%27", "'",
+	"%2A", "*",
+	"%2C", ",",
+	"%3A", ":",
+	"%40", "@",
+	"%24", "$",
+	"%2F", "/",
+)
+
+// Filter is a single entry of a Superset list query's filters, e.g. {Col: "uuid", Opr: "eq", Value: "..."}.
+type Filter struct {
+	Col   string
+	Opr   string
+	Value interface{}
+}
+
+// ListQuery is the typed form of the q= parameter accepted by Superset list endpoints.
+type ListQuery struct {
+	Filters []Filter
+	// Columns limits the fields returned for each item.
+	Columns []string
+	// OrderColumn and OrderDirection ("asc" or "desc") sort the result.
+	OrderColumn    string
+	OrderDirection string
+	// Page and PageSize select one page; both are omitted when PageSize is zero.
+	Page     int
+	PageSize int
+}
+
+// Encode returns the query as unescaped Rison, e.g. (filters:!((col:uuid,opr:eq,value:'...'))).
+func (q ListQuery) Encode() string {
+	fields := map[string]interface{}{}
+	if len(q.Filters) > 0 {
+		filters := make([]interface{}, 0, len(q.Filters))
+		for _, f := range q.Filters {
+			filters = append(filters, map[string]interface{}{"col": f.Col, "opr": f.Opr, "value": f.Value})
+		}
+		fields["filters"] = filters
+	}
+	if len(q.Columns) > 0 {
+		fields["columns"] = q.Columns
+	}
+	if q.OrderColumn != "" {
+		fields["order_column"] = q.OrderColumn
+	}
+	if q.OrderDirection != "" {
+		fields["order_direction"] = q.OrderDirection
+	}
+	if q.PageSize > 0 {
+		fields["page"] = q.Page
+		fields["page_size"] = q.PageSize
+	}
+
+	return risonEncode(fields)
+}
+
+// Endpoint appends the query to a list endpoint path as an escaped q= parameter.
+// An empty query leaves the path unchanged.
+func (q ListQuery) Endpoint(path string) string {
+	encoded := q.Encode()
+	if encoded == "()" {
+		return path
+	}
+	return path + "?q=" + risonQueryEscape(encoded)
+}
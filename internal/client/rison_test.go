@@ -0,0 +1,153 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRisonEncode(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{name: "nil", value: nil, want: "!n"},
+		{name: "true", value: true, want: "!t"},
+		{name: "false", value: false, want: "!f"},
+		{name: "int", value: 42, want: "42"},
+		{name: "negative int", value: int64(-7), want: "-7"},
+		{name: "float", value: 1.5, want: "1.5"},
+		{name: "float exponent", value: 1e21, want: "1e21"},
+		{name: "bare identifier", value: "uuid", want: "uuid"},
+		{name: "identifier with dash and dot", value: "a-b.c_d", want: "a-b.c_d"},
+		{name: "empty string", value: "", want: "''"},
+		{name: "leading digit", value: "123abc", want: "'123abc'"},
+		{name: "leading dash", value: "-x", want: "'-x'"},
+		{name: "space", value: "Sales Dashboard", want: "'Sales Dashboard'"},
+		{name: "single quote", value: "O'Brien", want: "'O!'Brien'"},
+		{name: "bang", value: "wow!", want: "'wow!!'"},
+		{name: "parentheses and colon", value: "[db].(id:1)", want: "'[db].(id:1)'"},
+		{name: "non-ascii", value: "Übersicht", want: "'Übersicht'"},
+		{name: "nil slice", value: []string(nil), want: "!()"},
+		{name: "list", value: []interface{}{1, "a", true}, want: "!(1,a,!t)"},
+		{name: "object sorted keys", value: map[string]interface{}{"b": 1, "a": "x y"}, want: "(a:'x y',b:1)"},
+		{name: "nested", value: map[string]interface{}{"f": []interface{}{map[string]interface{}{"k": nil}}}, want: "(f:!((k:!n)))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, risonEncode(tt.value))
+		})
+	}
+}
+
+func TestListQueryEncode(t *testing.T) {
+	tests := []struct {
+		name  string
+		query ListQuery
+		want  string
+	}{
+		{
+			name:  "empty",
+			query: ListQuery{},
+			want:  "()",
+		},
+		{
+			name:  "page only",
+			query: ListQuery{Page: 0, PageSize: 100},
+			want:  "(page:0,page_size:100)",
+		},
+		{
+			name:  "uuid filter",
+			query: ListQuery{Filters: []Filter{{Col: "uuid", Opr: "eq", Value: "5f2b8a1e-0c1d-4c5e-9e0a-1b2c3d4e5f60"}}},
+			want:  "(filters:!((col:uuid,opr:eq,value:'5f2b8a1e-0c1d-4c5e-9e0a-1b2c3d4e5f60')))",
+		},
+		{
+			name:  "numeric filter",
+			query: ListQuery{Filters: []Filter{{Col: "datasource_id", Opr: "eq", Value: int64(12)}}},
+			want:  "(filters:!((col:datasource_id,opr:eq,value:12)))",
+		},
+		{
+			name: "in filter with columns and order",
+			query: ListQuery{
+				Filters:        []Filter{{Col: "id", Opr: "in", Value: []int64{1, 2, 3}}},
+				Columns:        []string{"id", "uuid"},
+				OrderColumn:    "changed_on_delta_humanized",
+				OrderDirection: "desc",
+				Page:           2,
+				PageSize:       50,
+			},
+			want: "(columns:!(id,uuid),filters:!((col:id,opr:in,value:!(1,2,3))),order_column:changed_on_delta_humanized,order_direction:desc,page:2,page_size:50)",
+		},
+		{
+			name:  "name with quote and bang",
+			query: ListQuery{Filters: []Filter{{Col: "template_name", Opr: "eq", Value: "Don't panic!"}}},
+			want:  "(filters:!((col:template_name,opr:eq,value:'Don!'t panic!!')))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.query.Encode())
+		})
+	}
+}
+
+func TestListQueryEndpoint(t *testing.T) {
+	tests := []struct {
+		name  string
+		query ListQuery
+		want  string
+	}{
+		{
+			name:  "empty query keeps path",
+			query: ListQuery{},
+			want:  "/api/v1/chart/",
+		},
+		{
+			name:  "rison punctuation stays readable",
+			query: ListQuery{PageSize: 100},
+			want:  "/api/v1/chart/?q=(page:0,page_size:100)",
+		},
+		{
+			name:  "spaces, ampersands and brackets are escaped",
+			query: ListQuery{Filters: []Filter{{Col: "slice_name", Opr: "eq", Value: "A&B [v2] #1"}}},
+			want:  "/api/v1/chart/?q=(filters:!((col:slice_name,opr:eq,value:'A%26B+%5Bv2%5D+%231')))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.query.Endpoint("/api/v1/chart/")
+			assert.Equal(t, tt.want, got)
+
+			// The q parameter must decode back to the unescaped Rison.
+			if parsed, err := url.Parse(got); assert.NoError(t, err) && tt.query.Encode() != "()" {
+				assert.Equal(t, tt.query.Encode(), parsed.Query().Get("q"))
+			}
+		})
+	}
+}
+
+func TestGetDashboardIDByUUID_EscapesFilterValue(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/dashboard/",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "(columns:!(id),filters:!((col:uuid,opr:eq,value:'it!'s')),page:0,page_size:1)", req.URL.Query().Get("q"))
+			return httpmock.NewStringResponse(200, `{"result": [{"id": 9}], "count": 1}`), nil
+		})
+
+	id, err := client.GetDashboardIDByUUID(t.Context(), "it's")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), id)
+}
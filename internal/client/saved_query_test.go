@@ -0,0 +1,97 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAndUpdateSavedQuery_Payload(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/csrf_token/",
+		httpmock.NewStringResponder(200, `{"result": "test-csrf-token"}`))
+
+	var bodies []map[string]any
+	record := func(status int, body string) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			data, _ := io.ReadAll(req.Body)
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal(data, &payload))
+			bodies = append(bodies, payload)
+			return httpmock.NewStringResponse(status, body), nil
+		}
+	}
+	httpmock.RegisterResponder("POST", "http://test-host/api/v1/saved_query/",
+		record(201, `{"id": 4, "result": {"label": "Orders", "db_id": 1, "sql": "SELECT 1"}}`))
+	httpmock.RegisterResponder("PUT", "http://test-host/api/v1/saved_query/4",
+		record(200, `{"id": 4, "result": {"label": "Orders", "db_id": 1, "sql": "SELECT 2"}}`))
+
+	schema := "sales"
+	id, err := client.CreateSavedQuery(t.Context(), SavedQueryRequest{DatabaseID: 1, Label: "Orders", Schema: &schema, SQL: "SELECT 1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), id)
+
+	require.NoError(t, client.UpdateSavedQuery(t.Context(), 4, SavedQueryRequest{DatabaseID: 1, Label: "Orders", SQL: "SELECT 2"}))
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, map[string]any{
+		"db_id": float64(1), "label": "Orders", "description": "", "schema": "sales", "sql": "SELECT 1", "template_parameters": nil,
+	}, bodies[0], "a nil catalog is left out for servers without catalogs")
+	assert.Nil(t, bodies[1]["schema"], "a nil schema is sent as null to clear it")
+}
+
+func TestGetSavedQuery(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/saved_query/4",
+		httpmock.NewStringResponder(200, `{"id": 4, "result": {
+			"id": 4, "label": "Orders", "description": "Daily orders", "catalog": null, "schema": "sales",
+			"database": {"id": 1, "database_name": "warehouse"}, "sql": "SELECT 1",
+			"template_parameters": "{\"region\": \"EU\"}", "sql_tables": [{"table": "orders"}]
+		}}`))
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/saved_query/5",
+		httpmock.NewStringResponder(404, `{"message": "Not found"}`))
+
+	query, err := client.GetSavedQuery(t.Context(), 4)
+	require.NoError(t, err)
+	assert.Equal(t, &SavedQuery{
+		ID:                 4,
+		Label:              "Orders",
+		Description:        "Daily orders",
+		Database:           DatabaseRef{ID: 1, DatabaseName: "warehouse"},
+		Schema:             "sales",
+		SQL:                "SELECT 1",
+		TemplateParameters: `{"region": "EU"}`,
+	}, query)
+
+	_, err = client.GetSavedQuery(t.Context(), 5)
+	assert.True(t, IsNotFound(err), "got %v", err)
+}
+
+func TestListSavedQueries_Filters(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET",
+		"http://test-host/api/v1/saved_query/?q=(filters:!((col:database,opr:rel_o_m,value:3),(col:label,opr:ct,value:orders)),order_column:id,order_direction:asc,page:0,page_size:100)",
+		httpmock.NewStringResponder(200, `{"count": 1, "result": [{"id": 4, "label": "Orders", "database": {"id": 3, "database_name": "warehouse"}, "sql": "SELECT 1"}]}`))
+
+	queries, err := client.ListSavedQueries(t.Context(), Filter{Col: "database", Opr: "rel_o_m", Value: int64(3)}, Filter{Col: "label", Opr: "ct", Value: "orders"})
+	require.NoError(t, err)
+	require.Len(t, queries, 1)
+	assert.Equal(t, "warehouse", queries[0].Database.DatabaseName)
+}
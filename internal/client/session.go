@@ -0,0 +1,204 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"sync"
+)
+
+// session is the browser-like state a Client keeps between requests: the cookies Superset hands
+// out and the CSRF token bound to them. Superset ties the token to the session cookie, so both
+// are kept for the life of the client and the token is only fetched again when Superset rejects it.
+type session struct {
+	mu   sync.Mutex
+	jar  *cookiejar.Jar
+	csrf string
+	// csrfMu serializes CSRF token fetches so concurrent mutations share one request.
+	csrfMu sync.Mutex
+}
+
+// cookieJar returns the session's jar, creating it on first use.
+func (s *session) cookieJar() *cookiejar.Jar {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.jar == nil {
+		// cookiejar.New only fails for invalid options.
+		s.jar, _ = cookiejar.New(nil)
+	}
+	return s.jar
+}
+
+// addCookies attaches the stored cookies for req's URL, keeping any the request already carries.
+func (s *session) addCookies(req *http.Request) {
+	for _, cookie := range s.cookieJar().Cookies(req.URL) {
+		if _, err := req.Cookie(cookie.Name); err != nil {
+			req.AddCookie(cookie)
+		}
+	}
+}
+
+// storeCookies records the cookies set by a response.
+func (s *session) storeCookies(u *url.URL, resp *http.Response) {
+	if cookies := resp.Cookies(); len(cookies) > 0 {
+		s.cookieJar().SetCookies(u, cookies)
+	}
+}
+
+func (s *session) csrfToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.csrf
+}
+
+func (s *session) setCSRFToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.csrf = token
+}
+
+// GetCSRFToken returns the CSRF token for the client's session, fetching it from
+// /api/v1/security/csrf_token/ the first time it is needed.
+func (c *Client) GetCSRFToken(ctx context.Context) (string, error) {
+	if token := c.session.csrfToken(); token != "" {
+		return token, nil
+	}
+	return c.renewCSRFToken(ctx, "")
+}
+
+// renewCSRFToken fetches a new CSRF token unless another request already replaced stale.
+func (c *Client) renewCSRFToken(ctx context.Context, stale string) (string, error) {
+	c.session.csrfMu.Lock()
+	defer c.session.csrfMu.Unlock()
+
+	if token := c.session.csrfToken(); token != "" && token != stale {
+		return token, nil
+	}
+
+	resp, err := c.send(ctx, apiRequest{
+		Method:   "GET",
+		Endpoint: "/api/v1/security/csrf_token/",
+		Headers:  map[string]string{"Referer": c.Host},
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", newAPIError(resp, "get CSRF token")
+	}
+
+	var result struct {
+		Result string `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	if result.Result == "" {
+		return "", fmt.Errorf("failed to retrieve CSRF token from response")
+	}
+
+	c.session.setCSRFToken(result.Result)
+	return result.Result, nil
+}
+
+// isCSRFError reports whether Superset rejected a request because of a missing, expired or
+// mismatched CSRF token. The response body is left readable.
+func isCSRFError(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	return bytes.Contains(bytes.ToLower(peekBody(resp, maxErrorBodySize)), []byte("csrf"))
+}
+
+// apiRequest describes one call to the Superset API.
+type apiRequest struct {
+	Method   string
+	Endpoint string
+	// Body is sent as is; ContentType defaults to application/json.
+	Body []byte
+	// Stream, when set, replaces Body. It is called for every attempt and its reader is sent
+	// with chunked encoding, so large uploads are never buffered.
+	Stream      func() (io.ReadCloser, error)
+	ContentType string
+	Headers     map[string]string
+	// CSRF attaches the session's CSRF token. When Superset rejects the token, a new one is
+	// fetched and the request is replayed once.
+	CSRF bool
+}
+
+// send is the single request pipeline every API call goes through. It adds credentials, the
+// CSRF token and session cookies, and handles retries, re-authentication and CSRF renewal.
+func (c *Client) send(ctx context.Context, r apiRequest) (*http.Response, error) {
+	if !r.CSRF {
+		return c.do(ctx, c.builder(r, ""))
+	}
+
+	token, err := c.GetCSRFToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(ctx, c.builder(r, token))
+	if err != nil || !isCSRFError(resp) {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	logDebug(ctx, "Superset rejected the CSRF token, fetching a new one", map[string]interface{}{
+		"method":   r.Method,
+		"endpoint": r.Endpoint,
+	})
+	token, err = c.renewCSRFToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, c.builder(r, token))
+}
+
+// builder returns a requestBuilder for r carrying csrfToken, if set.
+func (c *Client) builder(r apiRequest, csrfToken string) requestBuilder {
+	target := c.Host + r.Endpoint
+	contentType := r.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	return func(ctx context.Context) (*http.Request, error) {
+		var body io.Reader = bytes.NewReader(r.Body)
+		if r.Stream != nil {
+			stream, err := r.Stream()
+			if err != nil {
+				return nil, err
+			}
+			body = stream
+		}
+		req, err := http.NewRequestWithContext(ctx, r.Method, target, body)
+		if err != nil {
+			if closer, ok := body.(io.Closer); ok {
+				closer.Close()
+			}
+			return nil, err
+		}
+		if r.Stream != nil {
+			req.GetBody = r.Stream
+		}
+		req.Header.Set("Content-Type", contentType)
+		for key, value := range r.Headers {
+			req.Header.Set(key, value)
+		}
+		if csrfToken != "" {
+			req.Header.Set("X-CSRFToken", csrfToken)
+			if req.Header.Get("Referer") == "" {
+				req.Header.Set("Referer", c.Host)
+			}
+		}
+		c.authorize(req)
+		return req, nil
+	}
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const csrfEndpoint = "GET http://test-host/api/v1/security/csrf_token/"
+
+// csrfResponder hands out csrf-token-1, csrf-token-2, ... and sets a session cookie.
+func csrfResponder() httpmock.Responder {
+	issued := 0
+	return func(req *http.Request) (*http.Response, error) {
+		issued++
+		resp := httpmock.NewStringResponse(200, fmt.Sprintf(`{"result": "csrf-token-%d"}`, issued))
+		resp.Header.Add("Set-Cookie", "session=session-1; Path=/; HttpOnly")
+		return resp, nil
+	}
+}
+
+func TestDoRequestWithCSRF_ReusesTokenAndSession(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/csrf_token/", csrfResponder())
+	httpmock.RegisterResponder("DELETE", `=~^http://test-host/api/v1/(chart|dataset)/\d+\z`,
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "csrf-token-1", req.Header.Get("X-CSRFToken"))
+			assert.Equal(t, "http://test-host", req.Header.Get("Referer"))
+			cookie, err := req.Cookie("session")
+			if assert.NoError(t, err) {
+				assert.Equal(t, "session-1", cookie.Value)
+			}
+			return httpmock.NewStringResponse(200, `{"message": "OK"}`), nil
+		})
+
+	require.NoError(t, client.DeleteChart(t.Context(), 1))
+	require.NoError(t, client.DeleteChart(t.Context(), 2))
+	require.NoError(t, client.DeleteDataset(t.Context(), 3))
+
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()[csrfEndpoint])
+	assert.Equal(t, 4, httpmock.GetTotalCallCount())
+}
+
+func TestDoRequestWithCSRF_RenewsRejectedToken(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/csrf_token/", csrfResponder())
+	httpmock.RegisterResponder("DELETE", "http://test-host/api/v1/chart/1",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-CSRFToken") != "csrf-token-2" {
+				return httpmock.NewStringResponse(400, "400 Bad Request: The CSRF token has expired."), nil
+			}
+			return httpmock.NewStringResponse(200, `{"message": "OK"}`), nil
+		})
+
+	require.NoError(t, client.DeleteChart(t.Context(), 1))
+	assert.Equal(t, 2, httpmock.GetCallCountInfo()[csrfEndpoint])
+	assert.Equal(t, 2, httpmock.GetCallCountInfo()["DELETE http://test-host/api/v1/chart/1"])
+
+	// The renewed token is kept for later mutations.
+	require.NoError(t, client.DeleteChart(t.Context(), 1))
+	assert.Equal(t, 2, httpmock.GetCallCountInfo()[csrfEndpoint])
+}
+
+func TestDoRequestWithCSRF_OtherBadRequestNotReplayed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{Host: "http://test-host", Token: "test-token"}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/security/csrf_token/", csrfResponder())
+	httpmock.RegisterResponder("DELETE", "http://test-host/api/v1/chart/1",
+		httpmock.NewStringResponder(400, `{"message": "Chart is referenced by a report"}`))
+
+	err := client.DeleteChart(t.Context(), 1)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Chart is referenced by a report")
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()[csrfEndpoint])
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["DELETE http://test-host/api/v1/chart/1"])
+}
+
+func TestSetSession_DropsCSRFToken(t *testing.T) {
+	client := &Client{Host: "http://test-host"}
+	client.session.setCSRFToken("csrf-token-1")
+
+	client.setSession("new-token", "")
+
+	assert.Empty(t, client.session.csrfToken())
+}
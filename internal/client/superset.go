@@ -1,24 +1,19 @@
 package client
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// Global cache for GetAllDatabases to avoid multiple API calls across different client instances.
-var (
-	globalDatabasesCache      []map[string]interface{}
-	globalDatabasesCacheTime  time.Time
-	globalDatabasesCacheTTL   = 5 * time.Minute // Cache for 5 minutes
-	globalDatabasesCacheMutex sync.RWMutex
-)
-
 // Client represents a client for Superset API.
 type Client struct {
 	Host     string
@@ -26,163 +21,123 @@ type Client struct {
 	Password string
 	Provider string
 	Token    string
-	Cookies  []*http.Cookie
-}
 
-// NewClient creates a new Superset client with the specified host, username, password, and provider.
-// It returns a pointer to the created Client and an error if authentication fails.
-func NewClient(host, username, password, provider string) (*Client, error) {
-	client := &Client{
-		Host:     host,
-		Username: username,
-		Password: password,
-		Provider: provider,
-	}
+	// RefreshToken is exchanged for a new access token when Superset answers 401.
+	RefreshToken string
 
-	err := client.authenticate()
-	if err != nil {
-		return nil, err
-	}
+	// MaxRetries is the number of times a transient failure is retried. Zero disables retries.
+	MaxRetries int
+	// RetryMaxWait caps a single backoff sleep. Zero means DefaultRetryMaxWait.
+	RetryMaxWait time.Duration
 
-	return client, nil
-}
+	auth         Authenticator
+	httpClient   *http.Client
+	extraHeaders map[string]string
 
-// authenticate sends an authentication request to the Superset API using the provided username, password, and provider.
-// It returns an error if the authentication fails or if there is an error during the request.
-func (c *Client) authenticate() error {
-	url := fmt.Sprintf("%s/api/v1/security/login", c.Host)
-	payload := map[string]string{
-		"username": c.Username,
-		"password": c.Password,
-		"provider": c.Provider,
-	}
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		return err
-	}
+	limiter          *limiter
+	serializeImports bool
+	maxBundleSize    int64
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", "application/json")
+	tokenMu  sync.RWMutex  // guards Token and RefreshToken
+	reauthMu sync.Mutex    // serializes refresh and re-login attempts
+	sessions atomic.Uint64 // counts successful re-logins, so concurrent 401s renew once
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
+	session      session
+	cache        clientCache
+	capabilities Capabilities
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to authenticate with Superset, status code: %d", resp.StatusCode)
-	}
+// Config holds the settings used by NewClient.
+type Config struct {
+	Host     string
+	Username string
+	Password string
+	Provider string
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
+	MaxRetries   int
+	RetryMaxWait time.Duration
 
-	var result map[string]interface{}
-	err = json.Unmarshal(body, &result)
-	if err != nil {
-		return err
-	}
+	// MaxConcurrentRequests caps the number of requests in flight at once. Zero means unlimited.
+	MaxConcurrentRequests int
+	// RequestsPerSecond caps the request rate. Zero means unlimited.
+	RequestsPerSecond float64
+	// SerializeImports runs bundle imports one at a time across every client in the process.
+	SerializeImports bool
+	// MaxBundleSize caps the size in bytes of an import bundle's ZIP. Zero means DefaultMaxBundleSize.
+	MaxBundleSize int64
 
-	token, ok := result["access_token"].(string)
-	if !ok {
-		return fmt.Errorf("failed to retrieve access token from response")
-	}
+	// Auth selects how the client authenticates. Nil means a username/password login.
+	Auth Authenticator
 
-	c.Token = token
-	c.Cookies = resp.Cookies()
-	return nil
+	// Transport configures the shared HTTP client used for every request.
+	Transport TransportConfig
+	// ExtraHeaders are added to every request to Superset, including login and CSRF calls. They
+	// are not sent to an OAuth2 token URL.
+	ExtraHeaders map[string]string
 }
 
-// DoRequest sends an HTTP request to the specified endpoint using the specified method.
-// It takes the HTTP method, endpoint URL, and payload as input parameters.
-// If a payload is provided, it will be serialized to JSON before sending the request.
-// The function returns the HTTP response and an error, if any.
-func (c *Client) DoRequest(method, endpoint string, payload interface{}) (*http.Response, error) {
-	url := fmt.Sprintf("%s%s", c.Host, endpoint)
-	var jsonPayload []byte
-	var err error
-
-	if payload != nil {
-		jsonPayload, err = json.Marshal(payload)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonPayload))
+// NewClient creates a new Superset client from cfg, authenticates against the host and detects
+// the server's capabilities. The login request is bound to ctx, so a cancelled or expired context aborts it.
+// It returns a pointer to the created Client and an error if authentication fails.
+func NewClient(ctx context.Context, cfg Config) (*Client, error) {
+	httpClient, err := NewHTTPClient(cfg.Transport)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
-
-	client := &http.Client{}
-	return client.Do(req)
-}
 
-// DoRequestWithHeadersAndCookies performs an HTTP request with additional headers and cookies.
-func (c *Client) DoRequestWithHeadersAndCookies(method, endpoint string, payload interface{}, headers map[string]string, cookies []*http.Cookie) (*http.Response, error) {
-	url := fmt.Sprintf("%s%s", c.Host, endpoint)
-	var jsonPayload []byte
-	var err error
+	client := &Client{
+		Host:         cfg.Host,
+		Username:     cfg.Username,
+		Password:     cfg.Password,
+		Provider:     cfg.Provider,
+		MaxRetries:   cfg.MaxRetries,
+		RetryMaxWait: cfg.RetryMaxWait,
+		auth:         cfg.Auth,
+		httpClient:   httpClient,
+		extraHeaders: cfg.ExtraHeaders,
 
-	if payload != nil {
-		jsonPayload, err = json.Marshal(payload)
-		if err != nil {
-			return nil, err
-		}
+		limiter:          newLimiter(cfg.MaxConcurrentRequests, cfg.RequestsPerSecond),
+		serializeImports: cfg.SerializeImports,
+		maxBundleSize:    cfg.MaxBundleSize,
 	}
 
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonPayload))
+	err = client.authenticate(ctx)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
-	for key, value := range headers {
-		req.Header.Set(key, value)
-	}
-	for _, cookie := range cookies {
-		req.AddCookie(cookie)
-	}
 
-	client := &http.Client{}
-	return client.Do(req)
+	client.detectCapabilities(ctx)
+
+	return client, nil
 }
 
-// GetCSRFToken retrieves the CSRF token.
-func (c *Client) GetCSRFToken() (string, []*http.Cookie, error) {
-	headers := map[string]string{
-		"Referer": c.Host,
-	}
-	resp, err := c.DoRequestWithHeadersAndCookies("GET", "/api/v1/security/csrf_token/", nil, headers, nil)
+// DoRequest sends an HTTP request to the specified endpoint using the specified method.
+// If a payload is provided, it will be serialized to JSON before sending the request.
+// The function returns the HTTP response and an error, if any.
+func (c *Client) DoRequest(ctx context.Context, method, endpoint string, payload interface{}) (*http.Response, error) {
+	body, err := marshalPayload(payload)
 	if err != nil {
-		return "", nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", nil, fmt.Errorf("failed to get CSRF token, status code: %d", resp.StatusCode)
+		return nil, err
 	}
+	return c.send(ctx, apiRequest{Method: method, Endpoint: endpoint, Body: body})
+}
 
-	var result map[string]interface{}
-	err = json.NewDecoder(resp.Body).Decode(&result)
+// DoRequestWithCSRF is DoRequest for endpoints that require the session's CSRF token,
+// which is fetched once and reused until Superset rejects it.
+func (c *Client) DoRequestWithCSRF(ctx context.Context, method, endpoint string, payload interface{}) (*http.Response, error) {
+	body, err := marshalPayload(payload)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
+	return c.send(ctx, apiRequest{Method: method, Endpoint: endpoint, Body: body, CSRF: true})
+}
 
-	csrfToken, ok := result["result"].(string)
-	if !ok {
-		return "", nil, fmt.Errorf("failed to retrieve CSRF token from response")
+// marshalPayload serializes a request payload to JSON; a nil payload yields an empty body.
+func marshalPayload(payload interface{}) ([]byte, error) {
+	if payload == nil {
+		return nil, nil
 	}
-
-	return csrfToken, resp.Cookies(), nil
+	return json.Marshal(payload)
 }
 
 // GetRoleIDByName retrieves the ID of a role by its name from the Superset API.
@@ -191,51 +146,39 @@ func (c *Client) GetCSRFToken() (string, []*http.Cookie, error) {
 // The function expects a valid Superset client to be passed as the receiver (c).
 // The roleName parameter specifies the name of the role to search for.
 // The function returns the ID of the role and an error, if any.
-func (c *Client) GetRoleIDByName(roleName string) (int64, error) {
-	endpoint := "/api/v1/security/roles?q=(page_size:5000)"
-	resp, err := c.DoRequest("GET", endpoint, nil)
-	if err != nil {
-		return 0, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("failed to fetch roles from Superset, status code: %d", resp.StatusCode)
-	}
-
-	var result struct {
-		Roles []struct {
-			ID   int64  `json:"id"`
-			Name string `json:"name"`
-		} `json:"result"`
-	}
-
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
-		return 0, err
+func (c *Client) GetRoleIDByName(ctx context.Context, roleName string) (int64, error) {
+	if id, ok, complete := c.cache.roleIDs.lookup(c.Host, roleName); ok {
+		return id, nil
+	} else if complete {
+		return 0, fmt.Errorf("role %s %w", roleName, ErrNotFound)
 	}
 
-	for _, role := range result.Roles {
+	for role, err := range paginate[Role](ctx, c, "/api/v1/security/roles", "roles", ListQuery{}) {
+		if err != nil {
+			return 0, err
+		}
+		c.cache.roleIDs.store(c.Host, role.Name, role.ID)
 		if role.Name == roleName {
 			return role.ID, nil
 		}
 	}
+	c.cache.roleIDs.markComplete(c.Host)
 
-	return 0, fmt.Errorf("role %s not found", roleName)
+	return 0, fmt.Errorf("role %s %w", roleName, ErrNotFound)
 }
 
 // GetRolePermissions retrieves the permissions associated with a given role ID from Superset.
 // It makes a GET request to the Superset API and returns a slice of Permission objects and an error, if any.
-func (c *Client) GetRolePermissions(roleID int64) ([]Permission, error) {
+func (c *Client) GetRolePermissions(ctx context.Context, roleID int64) ([]Permission, error) {
 	endpoint := fmt.Sprintf("/api/v1/security/roles/%d/permissions/", roleID)
-	resp, err := c.DoRequest("GET", endpoint, nil)
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch permissions from Superset, status code: %d", resp.StatusCode)
+		return nil, newAPIError(resp, "fetch permissions from Superset")
 	}
 
 	var result struct {
@@ -247,15 +190,15 @@ func (c *Client) GetRolePermissions(roleID int64) ([]Permission, error) {
 		return nil, err
 	}
 
+	c.cache.permissions.learn(c.Host, result.Permissions)
 	return result.Permissions, nil
 }
 
 // GetPermissionViewMenuIDs retrieves the IDs of permissions and view menus
-// based on the provided permissions. It sends a GET request to the Superset
-// API to fetch the permissions resources and filters the results based on
-// the provided permissions. It returns a slice of int64 IDs that match the
-// provided permissions, or an error if the request fails or the decoding of
-// the response fails.
+// based on the provided permissions. The IDs are resolved through the client's
+// permission catalogue, which is loaded from the Superset API on first use and
+// reloaded only when a permission is not found in it. Permissions that do not
+// exist are skipped, and each ID is returned once.
 //
 // Parameters:
 //   - permissions: A slice of maps containing the permission and view menu names
@@ -264,103 +207,81 @@ func (c *Client) GetRolePermissions(roleID int64) ([]Permission, error) {
 // Returns:
 // - A slice of int64 IDs that match the provided permissions.
 // - An error if the request fails or the decoding of the response fails.
-func (c *Client) GetPermissionViewMenuIDs(permissions []map[string]string) ([]int64, error) {
-	page := 0
-	pageSize := 100
-	var ids []int64
-	found := make(map[string]bool)
-
+func (c *Client) GetPermissionViewMenuIDs(ctx context.Context, permissions []map[string]string) ([]int64, error) {
+	pairs := make([]PermissionPair, 0, len(permissions))
 	for _, perm := range permissions {
-		key := perm["permission"] + "|" + perm["view_menu"]
-		found[key] = false
+		pairs = append(pairs, PermissionPair{Permission: perm["permission"], ViewMenu: perm["view_menu"]})
 	}
 
-	for {
-		url := fmt.Sprintf("%s/api/v1/security/permissions-resources/?q=(page:%d,page_size:%d)", c.Host, page, pageSize)
-		req, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Authorization", "Bearer "+c.Token)
-		req.Header.Set("Content-Type", "application/json")
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
+	found, _, err := c.resolvePermissions(ctx, pairs)
+	if err != nil {
+		return nil, err
+	}
 
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("failed to fetch permissions resources from Superset, status code: %d", resp.StatusCode)
+	var ids []int64
+	seen := make(map[PermissionPair]bool)
+	for _, pair := range pairs {
+		if id, ok := found[pair]; ok && !seen[pair] {
+			seen[pair] = true
+			ids = append(ids, id)
 		}
+	}
 
-		var result struct {
-			Resources []struct {
-				ID         int64 `json:"id"`
-				Permission struct {
-					Name string `json:"name"`
-				} `json:"permission"`
-				ViewMenu struct {
-					Name string `json:"name"`
-				} `json:"view_menu"`
-			} `json:"result"`
-		}
-		err = json.NewDecoder(resp.Body).Decode(&result)
-		if err != nil {
-			return nil, err
-		}
+	return ids, nil
+}
 
-		for _, perm := range permissions {
-			key := perm["permission"] + "|" + perm["view_menu"]
-			if !found[key] {
-				for _, res := range result.Resources {
-					if res.Permission.Name == perm["permission"] && res.ViewMenu.Name == perm["view_menu"] {
-						ids = append(ids, res.ID)
-						found[key] = true
-						break
-					}
-				}
-			}
+// ResolvePermissions returns the permission-view ID of every pair. A role with many permissions
+// is resolved with at most one walk of the permission list; when some pairs do not exist the
+// error wraps ErrNotFound and names all of them.
+func (c *Client) ResolvePermissions(ctx context.Context, pairs []PermissionPair) (map[PermissionPair]int64, error) {
+	ids, missing, err := c.resolvePermissions(ctx, pairs)
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for _, pair := range missing {
+			names = append(names, pair.String())
 		}
+		return nil, fmt.Errorf("permissions %s %w", strings.Join(names, ", "), ErrNotFound)
+	}
+	return ids, nil
+}
 
-		allFound := true
-		for _, v := range found {
-			if !v {
-				allFound = false
-				break
+func (c *Client) resolvePermissions(ctx context.Context, pairs []PermissionPair) (map[PermissionPair]int64, []PermissionPair, error) {
+	return c.cache.permissions.resolve(c.Host, pairs, func() (map[PermissionPair]int64, error) {
+		all := make(map[PermissionPair]int64)
+		for res, err := range paginate[permissionResource](ctx, c, "/api/v1/security/permissions-resources/", "permissions resources", ListQuery{}) {
+			if err != nil {
+				return nil, err
 			}
+			all[PermissionPair{Permission: res.Permission.Name, ViewMenu: res.ViewMenu.Name}] = res.ID
 		}
-
-		if allFound || len(result.Resources) < pageSize {
-			break
-		}
-		page++
-	}
-
-	return ids, nil
+		return all, nil
+	})
 }
 
 // CreateRole creates a role with the specified name in the Superset application.
 // If the role already exists, it returns the existing role ID.
 // It returns the ID of the created role and any error encountered.
-func (c *Client) CreateRole(name string) (int64, error) {
+func (c *Client) CreateRole(ctx context.Context, name string) (int64, error) {
 	// Check if role already exists
-	existingID, err := c.GetRoleIDByName(name)
+	existingID, err := c.GetRoleIDByName(ctx, name)
 	if err == nil {
 		return existingID, nil
 	}
+	defer c.cache.roleIDs.invalidate()
 
 	endpoint := "/api/v1/security/roles/"
 	payload := map[string]string{"name": name}
-	resp, err := c.DoRequest("POST", endpoint, payload)
+	resp, err := c.DoRequest(ctx, "POST", endpoint, payload)
 	if err != nil {
 		return 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body) // Read the response body
-		return 0, fmt.Errorf("failed to create role, status code: %d, response: %s", resp.StatusCode, string(body))
+		return 0, newAPIError(resp, "create role")
 	}
 
 	var result map[string]interface{}
@@ -386,17 +307,16 @@ func (c *Client) CreateRole(name string) (int64, error) {
 // and returns the role as a *Role object if successful.
 // If there is an error during the request or response handling,
 // it returns nil and an error describing the issue.
-func (c *Client) GetRole(id int64) (*Role, error) {
+func (c *Client) GetRole(ctx context.Context, id int64) (*Role, error) {
 	endpoint := fmt.Sprintf("/api/v1/security/roles/%d", id)
-	resp, err := c.DoRequest("GET", endpoint, nil)
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error making GET request to %s: %v", endpoint, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body) // Read the response body for detailed error logging
-		return nil, fmt.Errorf("failed to fetch role, status code: %d, response: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "fetch role")
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -433,31 +353,32 @@ func (c *Client) GetRole(id int64) (*Role, error) {
 // The updated role name is sent to the Superset API using a PUT request.
 // If the update is successful, the function returns nil.
 // If the update fails, an error is returned with the corresponding status code and response body.
-func (c *Client) UpdateRole(id int64, name string) error {
-	existingRole, err := c.GetRole(id)
+func (c *Client) UpdateRole(ctx context.Context, id int64, name string) error {
+	defer c.cache.roleIDs.invalidate()
+
+	existingRole, err := c.GetRole(ctx, id)
 	if err != nil {
 		return err
 	}
 
 	if existingRole.Name == name {
-		fmt.Printf("Role with ID %d already has the name '%s'. No update necessary.\n", id, name)
+		logDebug(ctx, "Role already has the requested name, skipping update", map[string]interface{}{"id": id, "name": name})
 		return nil
 	}
 
 	endpoint := fmt.Sprintf("/api/v1/security/roles/%d", id)
 	payload := map[string]string{"name": name}
-	resp, err := c.DoRequest("PUT", endpoint, payload)
+	resp, err := c.DoRequest(ctx, "PUT", endpoint, payload)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body) // Read the response body
-		return fmt.Errorf("failed to update role, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "update role")
 	}
 
-	fmt.Printf("Role with ID %d successfully updated to name '%s'.\n", id, name)
+	logDebug(ctx, "Updated role name", map[string]interface{}{"id": id, "name": name})
 	return nil
 }
 
@@ -466,26 +387,27 @@ func (c *Client) UpdateRole(id int64, name string) error {
 // If the request is successful and the role is deleted, it returns nil.
 // If there is an error or the response status code is not 204 (No Content) or 200 (OK),
 // it returns an error with the corresponding status code and response body.
-func (c *Client) DeleteRole(id int64) error {
+func (c *Client) DeleteRole(ctx context.Context, id int64) error {
+	defer c.cache.roleIDs.invalidate()
+
 	endpoint := fmt.Sprintf("/api/v1/security/roles/%d", id)
-	resp, err := c.DoRequest("DELETE", endpoint, nil)
+	resp, err := c.DoRequest(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body) // Read the response body
-		return fmt.Errorf("failed to delete role, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "delete role")
 	}
 
 	return nil
 }
 
 // GetPermissionIDByNameAndView retrieves the ID of a permission by its name and view menu name.
-// It sends a GET request to the Superset API to fetch the permissions resources and searches for the resource
-// that matches the given permission name and view menu name. If a match is found, it returns the ID of the resource.
-// If no match is found, it returns an error indicating that the permission with the given name and view menu name was not found.
+// The ID is resolved through the client's permission catalogue, so looking up every permission of
+// a role walks the permissions resources list at most once.
+// If no match is found, it returns an error wrapping ErrNotFound.
 //
 // Parameters:
 // - permissionName: The name of the permission to search for.
@@ -494,83 +416,35 @@ func (c *Client) DeleteRole(id int64) error {
 // Returns:
 // - int64: The ID of the permission resource if found.
 // - error: An error if the request fails or if the permission resource is not found.
-func (c *Client) GetPermissionIDByNameAndView(permissionName, viewMenuName string) (int64, error) {
-	page := 0
-	pageSize := 100
-
-	for {
-		endpoint := fmt.Sprintf("/api/v1/security/permissions-resources?q=(page:%d,page_size:%d)", page, pageSize)
-		resp, err := c.DoRequest("GET", endpoint, nil)
-		if err != nil {
-			return 0, err
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			return 0, fmt.Errorf("failed to fetch permissions resources from Superset, status code: %d", resp.StatusCode)
-		}
-
-		var result struct {
-			Resources []struct {
-				ID         int64 `json:"id"`
-				Permission struct {
-					Name string `json:"name"`
-				} `json:"permission"`
-				ViewMenu struct {
-					Name string `json:"name"`
-				} `json:"view_menu"`
-			} `json:"result"`
-			Count int `json:"count"`
-		}
-
-		err = json.NewDecoder(resp.Body).Decode(&result)
-		if err != nil {
-			return 0, err
-		}
-
-		for _, resource := range result.Resources {
-			if resource.Permission.Name == permissionName && resource.ViewMenu.Name == viewMenuName {
-				return resource.ID, nil
-			}
-		}
-
-		if len(result.Resources) < pageSize {
-			break
-		}
-		page++
+func (c *Client) GetPermissionIDByNameAndView(ctx context.Context, permissionName, viewMenuName string) (int64, error) {
+	pair := PermissionPair{Permission: permissionName, ViewMenu: viewMenuName}
+	ids, _, err := c.resolvePermissions(ctx, []PermissionPair{pair})
+	if err != nil {
+		return 0, err
 	}
-
-	return 0, fmt.Errorf("permission %s with view menu %s not found", permissionName, viewMenuName)
+	id, ok := ids[pair]
+	if !ok {
+		return 0, fmt.Errorf("permission %s with view menu %s %w", permissionName, viewMenuName, ErrNotFound)
+	}
+	return id, nil
 }
 
 // UpdateRolePermissions updates the permissions of a role in the Superset application.
 // It takes the role ID and a slice of permission IDs as parameters.
 // The function sends a POST request to the Superset API to update the role permissions.
 // It returns an error if the request fails or if the response status code is not 200 OK.
-func (c *Client) UpdateRolePermissions(roleID int64, permissionIDs []int64) error {
-	url := fmt.Sprintf("%s/api/v1/security/roles/%d/permissions", c.Host, roleID)
+func (c *Client) UpdateRolePermissions(ctx context.Context, roleID int64, permissionIDs []int64) error {
+	endpoint := fmt.Sprintf("/api/v1/security/roles/%d/permissions", roleID)
 	data := map[string][]int64{"permission_view_menu_ids": permissionIDs}
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return err
-	}
-
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Authorization", "Bearer "+c.Token)
-	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.DoRequest(ctx, "POST", endpoint, data)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update role permissions, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "update role permissions")
 	}
 
 	return nil
@@ -579,65 +453,43 @@ func (c *Client) UpdateRolePermissions(roleID int64, permissionIDs []int64) erro
 // ClearRolePermissions clears the permissions for a given role ID in Superset.
 // It sends a POST request to the Superset API to update the role's permissions.
 // The function returns an error if the request fails or if the response status code is not 200 OK.
-func (c *Client) ClearRolePermissions(roleID int64) error {
+func (c *Client) ClearRolePermissions(ctx context.Context, roleID int64) error {
 	endpoint := fmt.Sprintf("/api/v1/security/roles/%d/permissions", roleID)
 	payload := map[string]interface{}{
 		"permission_view_menu_ids": []int64{},
 	}
-	resp, err := c.DoRequest("POST", endpoint, payload)
+	resp, err := c.DoRequest(ctx, "POST", endpoint, payload)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body) // Read the response body
-		return fmt.Errorf("failed to clear role permissions, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "clear role permissions")
 	}
 
 	return nil
 }
 
-// FetchRoles fetches the roles from the Superset API.
-// It sends a GET request to the "/api/v1/security/roles?q=(page_size:5000)" endpoint
-// and returns a slice of rawRoleModel and an error.
-func (c *Client) FetchRoles() ([]rawRoleModel, error) {
-	endpoint := "/api/v1/security/roles?q=(page_size:5000)"
-	resp, err := c.DoRequest("GET", endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch roles from Superset, status code: %d", resp.StatusCode)
-	}
-
-	var result struct {
-		Roles []rawRoleModel `json:"result"`
-	}
-
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
-		return nil, err
-	}
-
-	return result.Roles, nil
+// FetchRoles fetches all roles from the Superset API, walking every page of
+// the "/api/v1/security/roles" endpoint, and returns a slice of Role and an error.
+func (c *Client) FetchRoles(ctx context.Context) ([]Role, error) {
+	return collect(paginate[Role](ctx, c, "/api/v1/security/roles", "roles", ListQuery{}))
 }
 
 // GetDatabaseSchemasByID retrieves the database schemas by the given database ID.
 // It makes a GET request to the Superset API and returns a list of schema names.
 // If the request fails or the response status code is not 200 OK, an error is returned.
-func (c *Client) GetDatabaseSchemasByID(databaseID int64) ([]string, error) {
+func (c *Client) GetDatabaseSchemasByID(ctx context.Context, databaseID int64) ([]string, error) {
 	endpoint := fmt.Sprintf("/api/v1/database/%d/schemas/", databaseID)
-	resp, err := c.DoRequest("GET", endpoint, nil)
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch schemas from Superset, status code: %d", resp.StatusCode)
+		return nil, newAPIError(resp, "fetch schemas from Superset")
 	}
 
 	var result struct {
@@ -651,86 +503,37 @@ func (c *Client) GetDatabaseSchemasByID(databaseID int64) ([]string, error) {
 	return result.Result, nil
 }
 
-// GetDatabaseConnectionByID retrieves the database connection information by its ID from Superset.
-// It makes a GET request to the Superset API and returns the response as a map[string]interface{}.
+// GetDatabaseConnectionByID retrieves the database connection information by its ID from Superset,
+// including the connection parameters and SSH tunnel.
 // If the request fails or the response status code is not 200 OK, an error is returned.
-func (c *Client) GetDatabaseConnectionByID(databaseID int64) (map[string]interface{}, error) {
+func (c *Client) GetDatabaseConnectionByID(ctx context.Context, databaseID int64) (*Database, error) {
 	endpoint := fmt.Sprintf("/api/v1/database/%d/connection", databaseID)
-	resp, err := c.DoRequest("GET", endpoint, nil)
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch database connection from Superset, status code: %d", resp.StatusCode)
-	}
-
-	var result map[string]interface{}
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
-		return nil, err
+		return nil, newAPIError(resp, "fetch database connection from Superset")
 	}
 
-	return result, nil
+	return decodeItem(resp.Body, "database connection", databaseID, func(db *Database) *int64 { return &db.ID })
 }
 
-// GetAllDatabases retrieves all databases from Superset with global caching.
-func (c *Client) GetAllDatabases() ([]map[string]interface{}, error) {
-	// Check global cache first (read lock)
-	globalDatabasesCacheMutex.RLock()
-	if len(globalDatabasesCache) > 0 && time.Since(globalDatabasesCacheTime) < globalDatabasesCacheTTL {
-		fmt.Printf("DEBUG GetAllDatabases: Using global cached result with %d databases (age: %v)\n",
-			len(globalDatabasesCache), time.Since(globalDatabasesCacheTime))
-		result := globalDatabasesCache
-		globalDatabasesCacheMutex.RUnlock()
-		return result, nil
-	}
-	globalDatabasesCacheMutex.RUnlock()
-
-	// Need to fetch data - acquire write lock
-	globalDatabasesCacheMutex.Lock()
-	defer globalDatabasesCacheMutex.Unlock()
-
-	// Double-check in case another goroutine already fetched while we were waiting
-	if len(globalDatabasesCache) > 0 && time.Since(globalDatabasesCacheTime) < globalDatabasesCacheTTL {
-		fmt.Printf("DEBUG GetAllDatabases: Using global cached result (double-check) with %d databases\n", len(globalDatabasesCache))
-		return globalDatabasesCache, nil
-	}
-
-	endpoint := "/api/v1/database/?q=(page_size:5000)"
-	fmt.Printf("DEBUG GetAllDatabases: Making API call to %s\n", endpoint)
-	resp, err := c.DoRequest("GET", endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch databases from Superset, status code: %d", resp.StatusCode)
-	}
-
-	var result struct {
-		Result []map[string]interface{} `json:"result"`
-	}
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
-		return nil, err
-	}
-
-	// Cache the result globally
-	globalDatabasesCache = result.Result
-	globalDatabasesCacheTime = time.Now()
-
-	fmt.Printf("DEBUG GetAllDatabases: Retrieved and cached globally %d databases total\n", len(result.Result))
-	return result.Result, nil
+// GetAllDatabases retrieves all databases from Superset. The list is cached on the client
+// until it expires or the client creates, updates or deletes a database.
+func (c *Client) GetAllDatabases(ctx context.Context) ([]Database, error) {
+	return c.cache.databases.load(c.Host, func() ([]Database, error) {
+		return collect(paginate[Database](ctx, c, "/api/v1/database/", "databases", ListQuery{}))
+	})
 }
 
 // GetDatabasesInfos retrieves information about all databases.
 // It returns a map containing the details of each database, including the database ID, name, schemas, and SQLAlchemy URI.
 // If an error occurs during the retrieval process, it returns nil and the error.
-func (c *Client) GetDatabasesInfos() (map[string]interface{}, error) {
-	databasesInfo, err := c.GetAllDatabases()
+func (c *Client) GetDatabasesInfos(ctx context.Context) (map[string]interface{}, error) {
+	databasesInfo, err := c.GetAllDatabases(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -743,20 +546,12 @@ func (c *Client) GetDatabasesInfos() (map[string]interface{}, error) {
 	}
 
 	for _, db := range databasesInfo[:limit] {
-		dbID, ok := db["id"].(float64)
-		if !ok {
-			continue
-		}
-		databaseDetails, err := c.GetDatabaseConnectionByID(int64(dbID))
+		databaseDetails, err := c.GetDatabaseConnectionByID(ctx, db.ID)
 		if err != nil {
 			return nil, err
 		}
 
-		var sqlalchemyURI, databaseName string
-		if result, ok := databaseDetails["result"].(map[string]interface{}); ok {
-			sqlalchemyURI, _ = result["sqlalchemy_uri"].(string)
-			databaseName, _ = result["database_name"].(string)
-		}
+		sqlalchemyURI, databaseName := databaseDetails.SQLAlchemyURI, databaseDetails.DatabaseName
 
 		if sqlalchemyURI == "" {
 			sqlalchemyURI = "URI not provided"
@@ -766,13 +561,13 @@ func (c *Client) GetDatabasesInfos() (map[string]interface{}, error) {
 			databaseName = "Name not provided"
 		}
 
-		schemas, err := c.GetDatabaseSchemasByID(int64(dbID))
+		schemas, err := c.GetDatabaseSchemasByID(ctx, db.ID)
 		if err != nil {
 			return nil, err
 		}
 
 		databasesList = append(databasesList, map[string]interface{}{
-			"id":             int64(dbID),
+			"id":             db.ID,
 			"database_name":  databaseName,
 			"schemas":        schemas,
 			"sqlalchemy_uri": sqlalchemyURI,
@@ -784,245 +579,159 @@ func (c *Client) GetDatabasesInfos() (map[string]interface{}, error) {
 
 // CreateDatabase creates a new database in the Superset application.
 // It takes a payload map[string]interface{} as input, which contains the necessary data for creating the database.
-// The function returns a map[string]interface{} containing the response from the API and an error, if any.
-func (c *Client) CreateDatabase(payload map[string]interface{}) (map[string]interface{}, error) {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return nil, err
-	}
+// The function returns the created Database and an error, if any.
+func (c *Client) CreateDatabase(ctx context.Context, payload map[string]interface{}) (*Database, error) {
+	defer c.cache.databases.invalidate()
 
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
-
-	resp, err := c.DoRequestWithHeadersAndCookies("POST", "/api/v1/database/", payload, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "POST", "/api/v1/database/", payload)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to create database, status code: %d, response: %s", resp.StatusCode, string(body))
-	}
-
-	var result map[string]interface{}
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
-		return nil, err
+		return nil, newAPIError(resp, "create database")
 	}
 
-	return result, nil
+	return decodeCreated(resp.Body, "created database", func(db *Database, id int64) { db.ID = id })
 }
 
 // UpdateDatabase updates a database with the given ID using the provided payload.
-// It returns the updated database as a map[string]interface{} and an error if any.
-func (c *Client) UpdateDatabase(databaseID int64, payload map[string]interface{}) (map[string]interface{}, error) {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return nil, err
-	}
+// It returns the updated Database and an error if any.
+func (c *Client) UpdateDatabase(ctx context.Context, databaseID int64, payload map[string]interface{}) (*Database, error) {
+	defer c.cache.databases.invalidate()
 
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
-
-	resp, err := c.DoRequestWithHeadersAndCookies("PUT", fmt.Sprintf("/api/v1/database/%d", databaseID), payload, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "PUT", fmt.Sprintf("/api/v1/database/%d", databaseID), payload)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to update database, status code: %d, response: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "update database")
 	}
 
-	var result map[string]interface{}
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
-		return nil, err
-	}
-
-	return result, nil
+	return decodeCreated(resp.Body, "updated database", func(db *Database, id int64) { db.ID = id })
 }
 
 // DeleteDatabase deletes a database with the given databaseID.
 // It sends a DELETE request to the Superset API to delete the database.
 // If the request is successful, it returns nil. Otherwise, it returns an error.
-func (c *Client) DeleteDatabase(databaseID int64) error {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return err
-	}
-
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
+func (c *Client) DeleteDatabase(ctx context.Context, databaseID int64) error {
+	defer c.cache.databases.invalidate()
 
-	resp, err := c.DoRequestWithHeadersAndCookies("DELETE", fmt.Sprintf("/api/v1/database/%d", databaseID), nil, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "DELETE", fmt.Sprintf("/api/v1/database/%d", databaseID), nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete database, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "delete database")
 	}
 
 	return nil
 }
 
 // GetAllDatasets fetches all datasets from Superset.
-func (c *Client) GetAllDatasets() ([]map[string]interface{}, error) {
-	endpoint := "/api/v1/dataset/?q=(page_size:5000)"
-	resp, err := c.DoRequest("GET", endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch datasets from Superset, status code: %d", resp.StatusCode)
-	}
-
-	var result struct {
-		Result []map[string]interface{} `json:"result"`
-	}
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
-		return nil, err
-	}
-
-	return result.Result, nil
+func (c *Client) GetAllDatasets(ctx context.Context) ([]Dataset, error) {
+	return collect(paginate[Dataset](ctx, c, "/api/v1/dataset/", "datasets", ListQuery{}))
 }
 
 // DatasetRequest represents the request structure for creating/updating a dataset.
+// Catalog is only sent when set, as servers before 4.1 reject the field.
 type DatasetRequest struct {
 	TableName string `json:"table_name"`
 	Database  int64  `json:"database"`
+	Catalog   string `json:"catalog,omitempty"`
 	Schema    string `json:"schema,omitempty"`
 	SQL       string `json:"sql,omitempty"`
 }
 
 // CreateDataset creates a new dataset in Superset.
-func (c *Client) CreateDataset(dataset DatasetRequest) (*map[string]interface{}, error) {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return nil, err
-	}
-
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
+func (c *Client) CreateDataset(ctx context.Context, dataset DatasetRequest) (*Dataset, error) {
+	if dataset.Catalog != "" {
+		if err := c.require(FeatureCatalog); err != nil {
+			return nil, err
+		}
 	}
 
 	endpoint := "/api/v1/dataset/"
 
-	// Debug: log the request payload
-	fmt.Printf("DEBUG CreateDataset: Sending request to %s with payload: %+v\n", endpoint, dataset)
-
-	resp, err := c.DoRequestWithHeadersAndCookies("POST", endpoint, dataset, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "POST", endpoint, dataset)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to create dataset, status code: %d, response: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "create dataset")
 	}
 
-	var result map[string]interface{}
-	err = json.NewDecoder(resp.Body).Decode(&result)
+	// The create response echoes the request, with the database as a bare ID.
+	var id int64
+	created, err := decodeCreated(resp.Body, "created dataset", func(_ *DatasetRequest, createdID int64) { id = createdID })
 	if err != nil {
 		return nil, err
 	}
 
-	return &result, nil
-}
-
-// ClearGlobalDatabaseCache clears the global database cache (useful for tests).
-func ClearGlobalDatabaseCache() {
-	globalDatabasesCacheMutex.Lock()
-	defer globalDatabasesCacheMutex.Unlock()
-	globalDatabasesCache = nil
-	globalDatabasesCacheTime = time.Time{}
-	fmt.Printf("DEBUG ClearGlobalDatabaseCache: Global database cache cleared\n")
+	return &Dataset{
+		ID:        id,
+		TableName: created.TableName,
+		Catalog:   created.Catalog,
+		Schema:    created.Schema,
+		SQL:       created.SQL,
+		Database:  DatabaseRef{ID: created.Database},
+	}, nil
 }
 
 // GetDataset fetches a specific dataset by ID.
-func (c *Client) GetDataset(id int64) (*map[string]interface{}, error) {
+func (c *Client) GetDataset(ctx context.Context, id int64) (*Dataset, error) {
 	endpoint := fmt.Sprintf("/api/v1/dataset/%d", id)
-	resp, err := c.DoRequest("GET", endpoint, nil)
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("dataset with ID %d not found", id)
+		return nil, fmt.Errorf("dataset with ID %d not found: %w", id, newAPIError(resp, "fetch dataset"))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to fetch dataset, status code: %d, response: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "fetch dataset")
 	}
 
-	var result struct {
-		Result map[string]interface{} `json:"result"`
-	}
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
-		return nil, err
-	}
-
-	return &result.Result, nil
+	return decodeItem(resp.Body, "dataset", id, func(ds *Dataset) *int64 { return &ds.ID })
 }
 
 // DatasetUpdateRequest represents the request structure for updating a dataset (excludes database field).
+// Catalog is only sent when set, as servers before 4.1 reject the field.
 type DatasetUpdateRequest struct {
 	TableName string `json:"table_name"`
+	Catalog   string `json:"catalog,omitempty"`
 	Schema    string `json:"schema,omitempty"`
 	SQL       string `json:"sql,omitempty"`
 }
 
 // UpdateDataset updates an existing dataset (database field cannot be changed).
-func (c *Client) UpdateDataset(id int64, tableName, schema, sql string) error {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return err
-	}
-
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
+func (c *Client) UpdateDataset(ctx context.Context, id int64, dataset DatasetUpdateRequest) error {
+	if dataset.Catalog != "" {
+		if err := c.require(FeatureCatalog); err != nil {
+			return err
+		}
 	}
 
 	endpoint := fmt.Sprintf("/api/v1/dataset/%d", id)
 
-	updateReq := DatasetUpdateRequest{
-		TableName: tableName,
-		Schema:    schema,
-		SQL:       sql,
-	}
-
-	// Debug: log the update request payload
-	fmt.Printf("DEBUG UpdateDataset: Sending UPDATE request to %s with payload: %+v\n", endpoint, updateReq)
-
-	resp, err := c.DoRequestWithHeadersAndCookies("PUT", endpoint, updateReq, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "PUT", endpoint, dataset)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update dataset, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "update dataset")
 	}
 
 	return nil
@@ -1030,19 +739,9 @@ func (c *Client) UpdateDataset(id int64, tableName, schema, sql string) error {
 
 // DeleteDataset deletes a dataset by ID.
 // Returns nil if the dataset is already deleted (404).
-func (c *Client) DeleteDataset(id int64) error {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return err
-	}
-
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
-
+func (c *Client) DeleteDataset(ctx context.Context, id int64) error {
 	endpoint := fmt.Sprintf("/api/v1/dataset/%d", id)
-	resp, err := c.DoRequestWithHeadersAndCookies("DELETE", endpoint, nil, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return err
 	}
@@ -1052,8 +751,7 @@ func (c *Client) DeleteDataset(id int64) error {
 		return nil // already deleted
 	}
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete dataset, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "delete dataset")
 	}
 
 	return nil
@@ -1061,74 +759,91 @@ func (c *Client) DeleteDataset(id int64) error {
 
 // GetDatasetIDByUUID finds a dataset ID by its UUID using the Superset API.
 // Returns 0 and nil if not found.
-func (c *Client) GetDatasetIDByUUID(uuid string) (int64, error) {
-	endpoint := fmt.Sprintf("/api/v1/dataset/?q=(filters:!((col:uuid,opr:eq,value:'%s')))", uuid)
-	resp, err := c.DoRequest("GET", endpoint, nil)
-	if err != nil {
-		return 0, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("failed to fetch dataset by uuid %q, status code: %d, response: %s", uuid, resp.StatusCode, string(body))
-	}
-
-	var result struct {
-		Result []struct {
-			ID float64 `json:"id"`
-		} `json:"result"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return 0, err
-	}
-	if len(result.Result) == 0 {
-		return 0, nil
-	}
-	return int64(result.Result[0].ID), nil
+func (c *Client) GetDatasetIDByUUID(ctx context.Context, uuid string) (int64, error) {
+	return c.findIDByFilter(ctx, "/api/v1/dataset/", fmt.Sprintf("dataset by uuid %q", uuid), Filter{Col: "uuid", Opr: "eq", Value: uuid})
 }
 
 // GetChartIDByUUID finds a chart ID by its UUID using the Superset API.
 // Returns 0 and nil if not found.
-func (c *Client) GetChartIDByUUID(uuid string) (int64, error) {
-	endpoint := fmt.Sprintf("/api/v1/chart/?q=(filters:!((col:uuid,opr:eq,value:'%s')))", uuid)
-	resp, err := c.DoRequest("GET", endpoint, nil)
-	if err != nil {
-		return 0, err
-	}
-	defer resp.Body.Close()
+func (c *Client) GetChartIDByUUID(ctx context.Context, uuid string) (int64, error) {
+	return c.findIDByFilter(ctx, "/api/v1/chart/", fmt.Sprintf("chart by uuid %q", uuid), Filter{Col: "uuid", Opr: "eq", Value: uuid})
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("failed to fetch chart by uuid %q, status code: %d, response: %s", uuid, resp.StatusCode, string(body))
-	}
+// GetChartUUIDsByIDs returns a map of chart UUID -> chart ID for the given chart IDs. IDs that
+// do not exist are left out of the map.
+func (c *Client) GetChartUUIDsByIDs(ctx context.Context, ids []int64) (map[string]int64, error) {
+	return lookupIDsAndUUIDs(ctx, c, "/api/v1/chart/", "charts by id", "id", ids)
+}
 
-	var result struct {
-		Result []struct {
-			ID float64 `json:"id"`
-		} `json:"result"`
+// GetChartIDsByUUIDs returns a map of chart UUID -> chart ID for the given UUIDs. UUIDs that do
+// not exist are left out of the map.
+func (c *Client) GetChartIDsByUUIDs(ctx context.Context, uuids []string) (map[string]int64, error) {
+	return lookupIDsAndUUIDs(ctx, c, "/api/v1/chart/", "charts by uuid", "uuid", uuids)
+}
+
+// GetDatasetIDsByUUIDs returns a map of dataset UUID -> dataset ID for the given UUIDs. UUIDs
+// that do not exist are left out of the map.
+func (c *Client) GetDatasetIDsByUUIDs(ctx context.Context, uuids []string) (map[string]int64, error) {
+	return lookupIDsAndUUIDs(ctx, c, "/api/v1/dataset/", "datasets by uuid", "uuid", uuids)
+}
+
+// lookupChunkSize bounds the values of a single "in" filter. Fifty UUIDs keep the encoded
+// query under the 4 KiB request line gunicorn accepts by default; every chunk is paginated, so
+// a server with a lower FAB_API_MAX_PAGE_SIZE is handled as well.
+const lookupChunkSize = 50
+
+// lookupIDsAndUUIDs resolves many objects of a list endpoint at once with col "in" filters,
+// fetching only their id and uuid columns, and returns a map of UUID -> ID.
+func lookupIDsAndUUIDs[K comparable](ctx context.Context, c *Client, endpoint, noun, col string, keys []K) (map[string]int64, error) {
+	result := make(map[string]int64, len(keys))
+	for chunk := range slices.Chunk(keys, lookupChunkSize) {
+		query := ListQuery{
+			Filters: []Filter{{Col: col, Opr: "in", Value: chunk}},
+			Columns: []string{"id", "uuid"},
+		}
+		items, err := collect(paginate[struct {
+			ID   int64  `json:"id"`
+			UUID string `json:"uuid"`
+		}](ctx, c, endpoint, noun, query))
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if item.UUID != "" {
+				result[item.UUID] = item.ID
+			}
+		}
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	return result, nil
+}
+
+// findIDByFilter returns the ID of the first object on a list endpoint matching all filters.
+// Returns 0 and nil if nothing matches.
+func (c *Client) findIDByFilter(ctx context.Context, endpoint, noun string, filters ...Filter) (int64, error) {
+	query := ListQuery{Filters: filters, Columns: []string{"id"}, PageSize: 1}
+	items, _, err := fetchPage[struct {
+		ID int64 `json:"id"`
+	}](ctx, c, query.Endpoint(endpoint), noun)
+	if err != nil {
 		return 0, err
 	}
-	if len(result.Result) == 0 {
+	if len(items) == 0 {
 		return 0, nil
 	}
-	return int64(result.Result[0].ID), nil
+	return items[0].ID, nil
 }
 
 // GetChartDashboardCount returns the number of dashboards referencing a chart.
-func (c *Client) GetChartDashboardCount(chartID int64) (int, error) {
+func (c *Client) GetChartDashboardCount(ctx context.Context, chartID int64) (int, error) {
 	endpoint := fmt.Sprintf("/api/v1/chart/%d", chartID)
-	resp, err := c.DoRequest("GET", endpoint, nil)
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("failed to get chart %d, status code: %d, response: %s", chartID, resp.StatusCode, string(body))
+		return 0, newAPIError(resp, fmt.Sprintf("get chart %d", chartID))
 	}
 
 	var result struct {
@@ -1145,17 +860,20 @@ func (c *Client) GetChartDashboardCount(chartID int64) (int, error) {
 }
 
 // GetDatasetChartCount returns the number of charts referencing a dataset.
-func (c *Client) GetDatasetChartCount(datasetID int64) (int, error) {
-	endpoint := fmt.Sprintf("/api/v1/chart/?q=(filters:!((col:datasource_id,opr:eq,value:%d)))", datasetID)
-	resp, err := c.DoRequest("GET", endpoint, nil)
+func (c *Client) GetDatasetChartCount(ctx context.Context, datasetID int64) (int, error) {
+	query := ListQuery{
+		Filters: []Filter{{Col: "datasource_id", Opr: "eq", Value: datasetID}},
+		Columns: []string{"id"},
+	}
+	endpoint := query.Endpoint("/api/v1/chart/")
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("failed to get charts for dataset %d, status code: %d, response: %s", datasetID, resp.StatusCode, string(body))
+		return 0, newAPIError(resp, fmt.Sprintf("get charts for dataset %d", datasetID))
 	}
 
 	var result struct {
@@ -1169,19 +887,9 @@ func (c *Client) GetDatasetChartCount(datasetID int64) (int, error) {
 
 // DeleteChart deletes a chart by ID.
 // Returns nil if the chart is already deleted (404).
-func (c *Client) DeleteChart(id int64) error {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return err
-	}
-
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
-
+func (c *Client) DeleteChart(ctx context.Context, id int64) error {
 	endpoint := fmt.Sprintf("/api/v1/chart/%d", id)
-	resp, err := c.DoRequestWithHeadersAndCookies("DELETE", endpoint, nil, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return err
 	}
@@ -1191,214 +899,196 @@ func (c *Client) DeleteChart(id int64) error {
 		return nil // already deleted
 	}
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete chart, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "delete chart")
 	}
 
 	return nil
 }
 
 // GetDatabaseIDByName finds database ID by name using cached database list.
-func (c *Client) GetDatabaseIDByName(databaseName string) (int64, error) {
-	databases, err := c.GetAllDatabases()
+func (c *Client) GetDatabaseIDByName(ctx context.Context, databaseName string) (int64, error) {
+	databases, err := c.GetAllDatabases(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to fetch databases: %w", err)
 	}
 
 	for _, db := range databases {
-		if name, ok := db["database_name"].(string); ok && name == databaseName {
-			if id, ok := db["id"].(float64); ok {
-				return int64(id), nil
-			}
+		if db.DatabaseName == databaseName {
+			return db.ID, nil
 		}
 	}
 
-	return 0, fmt.Errorf("database with name '%s' not found", databaseName)
+	return 0, fmt.Errorf("database with name '%s' %w", databaseName, ErrNotFound)
 }
 
 // GetDatabaseNameByID finds database name by ID using cached database list.
-func (c *Client) GetDatabaseNameByID(databaseID int64) (string, error) {
-	databases, err := c.GetAllDatabases()
+func (c *Client) GetDatabaseNameByID(ctx context.Context, databaseID int64) (string, error) {
+	databases, err := c.GetAllDatabases(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch databases: %w", err)
 	}
 
 	for _, db := range databases {
-		if id, ok := db["id"].(float64); ok && int64(id) == databaseID {
-			if name, ok := db["database_name"].(string); ok {
-				return name, nil
-			}
+		if db.ID == databaseID {
+			return db.DatabaseName, nil
 		}
 	}
 
-	return "", fmt.Errorf("database with ID %d not found", databaseID)
+	return "", fmt.Errorf("database with ID %d %w", databaseID, ErrNotFound)
 }
 
-// ImportDashboard imports a dashboard from a ZIP file.
+// ImportDashboard imports a dashboard from a ZIP bundle.
 // passwords is a JSON string mapping "databases/file.yaml" to password.
-func (c *Client) ImportDashboard(zipData []byte, overwrite bool, passwords string) error {
-	return c.importViaEndpoint("/api/v1/dashboard/import/", zipData, overwrite, passwords)
+func (c *Client) ImportDashboard(ctx context.Context, bundle Bundle, overwrite bool, passwords string) error {
+	return c.importViaEndpoint(ctx, "/api/v1/dashboard/import/", bundle, overwrite, passwords)
 }
 
-// ImportDataset imports datasets from a ZIP file via the dataset import endpoint.
+// ImportDataset imports datasets from a ZIP bundle via the dataset import endpoint.
 // This endpoint properly respects overwrite=true for datasets.
-func (c *Client) ImportDataset(zipData []byte, overwrite bool, passwords string) error {
-	return c.importViaEndpoint("/api/v1/dataset/import/", zipData, overwrite, passwords)
+func (c *Client) ImportDataset(ctx context.Context, bundle Bundle, overwrite bool, passwords string) error {
+	return c.importViaEndpoint(ctx, "/api/v1/dataset/import/", bundle, overwrite, passwords)
 }
 
-// ImportChart imports charts from a ZIP file via the chart import endpoint.
+// ImportChart imports charts from a ZIP bundle via the chart import endpoint.
 // This endpoint properly respects overwrite=true for charts.
-func (c *Client) ImportChart(zipData []byte, overwrite bool, passwords string) error {
-	return c.importViaEndpoint("/api/v1/chart/import/", zipData, overwrite, passwords)
+func (c *Client) ImportChart(ctx context.Context, bundle Bundle, overwrite bool, passwords string) error {
+	return c.importViaEndpoint(ctx, "/api/v1/chart/import/", bundle, overwrite, passwords)
 }
 
-// importViaEndpoint is a shared helper that posts a ZIP to any Superset import endpoint.
-func (c *Client) importViaEndpoint(endpoint string, zipData []byte, overwrite bool, passwords string) error {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return err
+// importViaEndpoint is a shared helper that posts a ZIP bundle to any Superset import endpoint.
+// The bundle is written straight into the multipart request body, so it is never held in memory.
+func (c *Client) importViaEndpoint(ctx context.Context, endpoint string, bundle Bundle, overwrite bool, passwords string) error {
+	if c.serializeImports {
+		importMu.Lock()
+		defer importMu.Unlock()
 	}
 
-	var body bytes.Buffer
-	writer := multipart.NewWriter(&body)
+	// Bundles may create or overwrite database connections.
+	defer c.cache.databases.invalidate()
 
-	part, err := writer.CreateFormFile("formData", "export.zip")
-	if err != nil {
-		return err
-	}
-	if _, err := part.Write(zipData); err != nil {
-		return err
+	maxSize := c.maxBundleSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxBundleSize
 	}
+	upload := newBundleUpload(bundle, maxSize, overwrite, passwords)
 
-	if overwrite {
-		_ = writer.WriteField("overwrite", "true")
-	}
-	if passwords != "" {
-		_ = writer.WriteField("passwords", passwords)
+	// The request timeout only bounds the wait for Superset's answer, not the upload itself.
+	var responseTimeout time.Duration
+	if c.httpClient != nil {
+		responseTimeout = c.httpClient.Timeout
 	}
-	writer.Close()
+	uploadCtx, stop := withUploadTimeout(ctx, responseTimeout)
+	defer stop()
 
-	url := fmt.Sprintf("%s%s", c.Host, endpoint)
-	req, err := http.NewRequest("POST", url, &body)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set("X-CSRFToken", csrfToken)
-	req.Header.Set("Referer", c.Host)
-	for _, cookie := range cookies {
-		req.AddCookie(cookie)
+	resp, err := c.send(uploadCtx, apiRequest{
+		Method:      "POST",
+		Endpoint:    endpoint,
+		Stream:      upload.open,
+		ContentType: upload.contentType(),
+		CSRF:        true,
+	})
+	var respBody []byte
+	if err == nil {
+		respBody, _ = io.ReadAll(resp.Body)
+		resp.Body.Close()
 	}
-	for _, cookie := range c.Cookies {
-		req.AddCookie(cookie)
+	var timeoutErr *ResponseTimeoutError
+	if cause := context.Cause(uploadCtx); err != nil && errors.As(cause, &timeoutErr) {
+		err = cause
 	}
 
-	resp, err := (&http.Client{}).Do(req)
+	// A failing bundle explains a failed request better than the transport error it causes.
+	size, bundleErr := upload.finish()
+	if bundleErr != nil {
+		return bundleErr
+	}
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	respBody, _ := io.ReadAll(resp.Body)
+	logDebug(ctx, "Uploaded import bundle", map[string]interface{}{
+		"endpoint":        endpoint,
+		"bundle_bytes":    size,
+		"max_bundle_size": maxSize,
+		"status":          resp.StatusCode,
+	})
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("import failed at %s, status code: %d, response: %s", endpoint, resp.StatusCode, string(respBody))
+		return newAPIErrorWithBody(resp, fmt.Sprintf("import bundle via %s", endpoint), respBody)
 	}
 
 	return nil
 }
 
 // GetDashboardIDByUUID finds a dashboard ID by its UUID using the Superset API.
-func (c *Client) GetDashboardIDByUUID(uuid string) (int64, error) {
-	endpoint := fmt.Sprintf("/api/v1/dashboard/?q=(filters:!((col:uuid,opr:eq,value:'%s')))", uuid)
-	resp, err := c.DoRequest("GET", endpoint, nil)
+func (c *Client) GetDashboardIDByUUID(ctx context.Context, uuid string) (int64, error) {
+	id, err := c.findIDByFilter(ctx, "/api/v1/dashboard/", fmt.Sprintf("dashboard by uuid %q", uuid), Filter{Col: "uuid", Opr: "eq", Value: uuid})
 	if err != nil {
 		return 0, err
 	}
+	if id == 0 {
+		return 0, fmt.Errorf("dashboard with uuid %q %w", uuid, ErrNotFound)
+	}
+	return id, nil
+}
+
+// GetDashboard fetches a dashboard by ID, including its layout, metadata, owners and roles.
+func (c *Client) GetDashboard(ctx context.Context, id int64) (*Dashboard, error) {
+	resp, err := c.DoRequest(ctx, "GET", fmt.Sprintf("/api/v1/dashboard/%d", id), nil)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("failed to fetch dashboard by uuid %q, status code: %d, response: %s", uuid, resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "fetch dashboard")
 	}
 
-	var result struct {
-		Result []struct {
-			ID float64 `json:"id"`
-		} `json:"result"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return 0, err
-	}
-	if len(result.Result) == 0 {
-		return 0, fmt.Errorf("dashboard with uuid %q not found", uuid)
-	}
-	return int64(result.Result[0].ID), nil
+	return decodeItem(resp.Body, "dashboard", id, func(d *Dashboard) *int64 { return &d.ID })
 }
 
 // DashboardExistsByID checks if a dashboard with the given ID exists via the Superset API.
-func (c *Client) DashboardExistsByID(id int64) (bool, error) {
-	endpoint := fmt.Sprintf("/api/v1/dashboard/%d", id)
-	resp, err := c.DoRequest("GET", endpoint, nil)
-	if err != nil {
-		return false, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode == http.StatusNotFound {
+func (c *Client) DashboardExistsByID(ctx context.Context, id int64) (bool, error) {
+	_, err := c.GetDashboard(ctx, id)
+	if IsNotFound(err) {
 		return false, nil
 	}
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return false, fmt.Errorf("failed to check dashboard existence, status code: %d, response: %s", resp.StatusCode, string(body))
+	if err != nil {
+		return false, err
 	}
 	return true, nil
 }
 
 // ClearDashboardLayout clears position_json and json_metadata of a dashboard.
-func (c *Client) ClearDashboardLayout(dashboardID int64) error {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return err
-	}
-
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
-
+func (c *Client) ClearDashboardLayout(ctx context.Context, dashboardID int64) error {
 	payload := map[string]interface{}{
 		"position_json": "{}",
 		"json_metadata": "{}",
 	}
 
 	endpoint := fmt.Sprintf("/api/v1/dashboard/%d", dashboardID)
-	resp, err := c.DoRequestWithHeadersAndCookies("PUT", endpoint, payload, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "PUT", endpoint, payload)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to clear dashboard layout, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "clear dashboard layout")
 	}
 	return nil
 }
 
 // GetDashboardChartUUIDs returns a map of chart UUID -> chart ID for all charts on a dashboard.
-func (c *Client) GetDashboardChartUUIDs(dashboardID int64) (map[string]int64, error) {
+func (c *Client) GetDashboardChartUUIDs(ctx context.Context, dashboardID int64) (map[string]int64, error) {
 	endpoint := fmt.Sprintf("/api/v1/dashboard/%d/charts", dashboardID)
-	resp, err := c.DoRequest("GET", endpoint, nil)
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get dashboard charts, status code: %d, response: %s", resp.StatusCode, string(body))
+		apiErr := newAPIError(resp, "get dashboard charts")
+		resp.Body.Close()
+		return nil, apiErr
 	}
 
 	var chartsResult struct {
@@ -1406,47 +1096,29 @@ func (c *Client) GetDashboardChartUUIDs(dashboardID int64) (map[string]int64, er
 			ID int64 `json:"id"`
 		} `json:"result"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&chartsResult); err != nil {
+	err = json.NewDecoder(resp.Body).Decode(&chartsResult)
+	// Close the body before looking up the UUIDs, so this request's concurrency slot is free.
+	resp.Body.Close()
+	if err != nil {
 		return nil, err
 	}
 
-	result := make(map[string]int64)
+	ids := make([]int64, 0, len(chartsResult.Result))
 	for _, chart := range chartsResult.Result {
-		chartResp, err := c.DoRequest("GET", fmt.Sprintf("/api/v1/chart/%d", chart.ID), nil)
-		if err != nil {
-			continue
-		}
-		var chartData struct {
-			Result struct {
-				UUID string `json:"uuid"`
-			} `json:"result"`
-		}
-		if err := json.NewDecoder(chartResp.Body).Decode(&chartData); err == nil && chartData.Result.UUID != "" {
-			result[chartData.Result.UUID] = chart.ID
-		}
-		chartResp.Body.Close()
+		ids = append(ids, chart.ID)
 	}
-	return result, nil
+	return c.GetChartUUIDsByIDs(ctx, ids)
 }
 
 // UnlinkChartsFromDashboard removes the dashboard from the given charts' dashboards list.
-func (c *Client) UnlinkChartsFromDashboard(chartIDs []int64, dashboardID int64) error {
+func (c *Client) UnlinkChartsFromDashboard(ctx context.Context, chartIDs []int64, dashboardID int64) error {
 	if len(chartIDs) == 0 {
 		return nil
 	}
 
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return err
-	}
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
-
 	for _, chartID := range chartIDs {
 		// Get chart's current dashboards
-		chartResp, err := c.DoRequest("GET", fmt.Sprintf("/api/v1/chart/%d", chartID), nil)
+		chartResp, err := c.DoRequest(ctx, "GET", fmt.Sprintf("/api/v1/chart/%d", chartID), nil)
 		if err != nil {
 			continue
 		}
@@ -1474,69 +1146,68 @@ func (c *Client) UnlinkChartsFromDashboard(chartIDs []int64, dashboardID int64)
 		payload := map[string]interface{}{
 			"dashboards": remaining,
 		}
-		updateResp, err := c.DoRequestWithHeadersAndCookies("PUT", fmt.Sprintf("/api/v1/chart/%d", chartID), payload, headers, cookies)
+		updateResp, err := c.DoRequestWithCSRF(ctx, "PUT", fmt.Sprintf("/api/v1/chart/%d", chartID), payload)
 		if err != nil {
 			return fmt.Errorf("failed to update chart %d: %w", chartID, err)
 		}
-		updateResp.Body.Close()
 		if updateResp.StatusCode != http.StatusOK {
-			return fmt.Errorf("failed to unlink chart %d from dashboard, status code: %d", chartID, updateResp.StatusCode)
+			apiErr := newAPIError(updateResp, fmt.Sprintf("unlink chart %d from dashboard", chartID))
+			updateResp.Body.Close()
+			return apiErr
 		}
+		updateResp.Body.Close()
 	}
 	return nil
 }
 
 // SetDashboardRoles sets the roles on a dashboard by ID.
-func (c *Client) SetDashboardRoles(dashboardID int64, roleIDs []int64) error {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return err
-	}
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
+func (c *Client) SetDashboardRoles(ctx context.Context, dashboardID int64, roleIDs []int64) error {
 	payload := map[string]interface{}{
 		"roles": roleIDs,
 	}
-	resp, err := c.DoRequestWithHeadersAndCookies("PUT", fmt.Sprintf("/api/v1/dashboard/%d", dashboardID), payload, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "PUT", fmt.Sprintf("/api/v1/dashboard/%d", dashboardID), payload)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to set dashboard roles, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "set dashboard roles")
 	}
 	return nil
 }
 
 // DeleteDashboard deletes a dashboard by ID.
-func (c *Client) DeleteDashboard(id int64) error {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return err
-	}
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
-	resp, err := c.DoRequestWithHeadersAndCookies("DELETE", fmt.Sprintf("/api/v1/dashboard/%d", id), nil, headers, cookies)
+func (c *Client) DeleteDashboard(ctx context.Context, id int64) error {
+	resp, err := c.DoRequestWithCSRF(ctx, "DELETE", fmt.Sprintf("/api/v1/dashboard/%d", id), nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete dashboard, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "delete dashboard")
 	}
 	return nil
 }
 
-// rawRoleModel represents a raw role model in the Superset client.
-type rawRoleModel struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+// permissionResource is an entry of /api/v1/security/permissions-resources/, pairing a permission with a view menu.
+type permissionResource struct {
+	ID         int64 `json:"id"`
+	Permission struct {
+		Name string `json:"name"`
+	} `json:"permission"`
+	ViewMenu struct {
+		Name string `json:"name"`
+	} `json:"view_menu"`
+}
+
+// PermissionPair names a permission on a view menu, e.g. can_read on Dashboard.
+type PermissionPair struct {
+	Permission string
+	ViewMenu   string
+}
+
+func (p PermissionPair) String() string {
+	return fmt.Sprintf("%s on %s", p.Permission, p.ViewMenu)
 }
 
 // Permission represents a permission in the Superset application.
@@ -1573,11 +1244,8 @@ type MetaDatabase struct {
 
 // CreateMetaDatabase creates a meta database connection in Superset.
 // It takes a MetaDatabase struct and returns the created database ID and an error.
-func (c *Client) CreateMetaDatabase(metaDB *MetaDatabase) (int64, error) {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return 0, err
-	}
+func (c *Client) CreateMetaDatabase(ctx context.Context, metaDB *MetaDatabase) (int64, error) {
+	defer c.cache.databases.invalidate()
 
 	// Build extra JSON with allowed_dbs
 	extraData := map[string]interface{}{
@@ -1609,20 +1277,14 @@ func (c *Client) CreateMetaDatabase(metaDB *MetaDatabase) (int64, error) {
 		"external_url":          metaDB.ExternalURL,
 	}
 
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
-
-	resp, err := c.DoRequestWithHeadersAndCookies("POST", "/api/v1/database/", payload, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "POST", "/api/v1/database/", payload)
 	if err != nil {
 		return 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("failed to create meta database, status code: %d, response: %s", resp.StatusCode, string(body))
+		return 0, newAPIError(resp, "create meta database")
 	}
 
 	var result map[string]interface{}
@@ -1640,101 +1302,71 @@ func (c *Client) CreateMetaDatabase(metaDB *MetaDatabase) (int64, error) {
 }
 
 // GetMetaDatabase retrieves a meta database by its ID from the Superset API.
-func (c *Client) GetMetaDatabase(id int64) (*MetaDatabase, error) {
+func (c *Client) GetMetaDatabase(ctx context.Context, id int64) (*MetaDatabase, error) {
 	endpoint := fmt.Sprintf("/api/v1/database/%d", id)
-	resp, err := c.DoRequest("GET", endpoint, nil)
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to fetch meta database, status code: %d, response: %s", resp.StatusCode, string(body))
+		apiErr := newAPIError(resp, "fetch meta database")
+		resp.Body.Close()
+		return nil, apiErr
 	}
 
 	var result struct {
 		Result MetaDatabase `json:"result"`
 	}
 	err = json.NewDecoder(resp.Body).Decode(&result)
+	// Close the body before listing the databases, so this request's concurrency slot is free.
+	resp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
 
 	metaDB := &result.Result
 
-	// For debugging, also try to get full database info from list endpoint
-	fmt.Printf("DEBUG GetMetaDatabase: Trying to get extra field from list endpoint for ID %d\n", id)
-	allDBs, listErr := c.GetAllDatabases()
+	// The single-item endpoint omits "extra", so take it from the list endpoint.
+	allDBs, listErr := c.GetAllDatabases(ctx)
 	if listErr == nil {
-		fmt.Printf("DEBUG GetMetaDatabase: Successfully got %d databases from list endpoint\n", len(allDBs))
-		found := false
 		for _, db := range allDBs {
-			if dbIDFloat, ok := db["id"].(float64); ok && int64(dbIDFloat) == id {
-				found = true
-				fmt.Printf("DEBUG GetMetaDatabase: Found matching database in list: %+v\n", db)
-				if extraStr, ok := db["extra"].(string); ok {
-					if extraStr != "" {
-						fmt.Printf("DEBUG GetMetaDatabase: Found extra field from list endpoint: %q\n", extraStr)
-						metaDB.Extra = extraStr
-					} else {
-						fmt.Printf("DEBUG GetMetaDatabase: Extra field is empty even in list endpoint\n")
-					}
-				} else {
-					fmt.Printf("DEBUG GetMetaDatabase: No extra field found in list endpoint response\n")
+			if db.ID == id {
+				if db.Extra != "" {
+					metaDB.Extra = db.Extra.String()
 				}
 				break
 			}
 		}
-		if !found {
-			fmt.Printf("DEBUG GetMetaDatabase: Database ID %d not found in list of %d databases\n", id, len(allDBs))
-		}
 	} else {
-		fmt.Printf("DEBUG GetMetaDatabase: Failed to get from list endpoint: %v\n", listErr)
+		logDebug(ctx, "Could not read meta database extra from list endpoint", map[string]interface{}{"id": id, "error": listErr.Error()})
 	}
 
 	// Parse allowed_dbs from extra field
-	fmt.Printf("DEBUG GetMetaDatabase: Raw extra field = %q\n", metaDB.Extra)
 	if metaDB.Extra != "" {
 		var extraData map[string]interface{}
 		if err := json.Unmarshal([]byte(metaDB.Extra), &extraData); err == nil {
-			fmt.Printf("DEBUG GetMetaDatabase: Parsed extraData = %+v\n", extraData)
 			if engineParams, ok := extraData["engine_params"].(map[string]interface{}); ok {
-				fmt.Printf("DEBUG GetMetaDatabase: Found engine_params = %+v\n", engineParams)
 				if allowedDBs, ok := engineParams["allowed_dbs"].([]interface{}); ok {
-					fmt.Printf("DEBUG GetMetaDatabase: Found allowed_dbs = %+v (length: %d)\n", allowedDBs, len(allowedDBs))
 					metaDB.AllowedDBs = make([]string, len(allowedDBs))
 					for i, db := range allowedDBs {
 						if dbStr, ok := db.(string); ok {
 							metaDB.AllowedDBs[i] = dbStr
-							fmt.Printf("DEBUG GetMetaDatabase: Added allowed_db[%d] = %q\n", i, dbStr)
-						} else {
-							fmt.Printf("DEBUG GetMetaDatabase: Failed to convert allowed_db[%d] to string: %+v (type: %T)\n", i, db, db)
 						}
 					}
-				} else {
-					fmt.Printf("DEBUG GetMetaDatabase: No 'allowed_dbs' found in engine_params\n")
 				}
-			} else {
-				fmt.Printf("DEBUG GetMetaDatabase: No 'engine_params' found in extraData\n")
 			}
 		} else {
-			fmt.Printf("DEBUG GetMetaDatabase: Failed to unmarshal extra field as JSON: %v\n", err)
+			logDebug(ctx, "Meta database extra is not valid JSON", map[string]interface{}{"id": id, "error": err.Error()})
 		}
-	} else {
-		fmt.Printf("DEBUG GetMetaDatabase: Extra field is empty\n")
 	}
-	fmt.Printf("DEBUG GetMetaDatabase: Final AllowedDBs = %+v (length: %d)\n", metaDB.AllowedDBs, len(metaDB.AllowedDBs))
+	logDebug(ctx, "Read meta database", map[string]interface{}{"id": id, "allowed_dbs": metaDB.AllowedDBs})
 
 	return metaDB, nil
 }
 
 // UpdateMetaDatabase updates a meta database with the given ID.
-func (c *Client) UpdateMetaDatabase(id int64, metaDB *MetaDatabase) error {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
-		return err
-	}
+func (c *Client) UpdateMetaDatabase(ctx context.Context, id int64, metaDB *MetaDatabase) error {
+	defer c.cache.databases.invalidate()
 
 	// Build extra JSON with allowed_dbs
 	extraData := map[string]interface{}{
@@ -1766,46 +1398,36 @@ func (c *Client) UpdateMetaDatabase(id int64, metaDB *MetaDatabase) error {
 		"external_url":          metaDB.ExternalURL,
 	}
 
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
-
-	resp, err := c.DoRequestWithHeadersAndCookies("PUT", fmt.Sprintf("/api/v1/database/%d", id), payload, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "PUT", fmt.Sprintf("/api/v1/database/%d", id), payload)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update meta database, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "update meta database")
 	}
 
 	return nil
 }
 
 // DeleteMetaDatabase deletes a meta database with the given ID.
-func (c *Client) DeleteMetaDatabase(id int64) error {
-	return c.DeleteDatabase(id) // Reuse existing delete method
+func (c *Client) DeleteMetaDatabase(ctx context.Context, id int64) error {
+	return c.DeleteDatabase(ctx, id) // Reuse existing delete method
 }
 
 // FindMetaDatabaseByName finds a meta database by name and sqlalchemy_uri = "superset://".
 // Returns the meta database if found, nil if not found, error if search failed.
-func (c *Client) FindMetaDatabaseByName(databaseName string) (*MetaDatabase, error) {
-	allDBs, err := c.GetAllDatabases()
+func (c *Client) FindMetaDatabaseByName(ctx context.Context, databaseName string) (*MetaDatabase, error) {
+	allDBs, err := c.GetAllDatabases(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, db := range allDBs {
 		// Check if it's a meta database with matching name
-		if dbName, ok := db["database_name"].(string); ok && dbName == databaseName {
-			if sqlalchemyURI, ok := db["sqlalchemy_uri"].(string); ok && sqlalchemyURI == "superset://" {
-				if dbID, ok := db["id"].(float64); ok {
-					return c.GetMetaDatabase(int64(dbID))
-				}
-			}
+		if db.DatabaseName == databaseName && db.SQLAlchemyURI == "superset://" {
+			return c.GetMetaDatabase(ctx, db.ID)
 		}
 	}
 
@@ -1823,45 +1445,21 @@ type User struct {
 	Roles     []int64 `json:"roles,omitempty"`
 }
 
-// rawUserModel represents a raw user model in the Superset client.
-type rawUserModel struct {
+// UserListItem is a user as returned by the users endpoints, with its roles expanded.
+type UserListItem struct {
 	ID        int64  `json:"id"`
 	Username  string `json:"username"`
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
 	Email     string `json:"email"`
 	Active    bool   `json:"active"`
-	Roles     []struct {
-		ID   int64  `json:"id"`
-		Name string `json:"name"`
-	} `json:"roles"`
+	Roles     []Role `json:"roles"`
 }
 
-// FetchUsers fetches the users from the Superset API.
-// It sends a GET request to the "/api/v1/security/users/?q=(page_size:5000)" endpoint
-// and returns a slice of rawUserModel and an error.
-func (c *Client) FetchUsers() ([]rawUserModel, error) {
-	endpoint := "/api/v1/security/users/?q=(page_size:5000)"
-	resp, err := c.DoRequest("GET", endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch users from Superset, status code: %d", resp.StatusCode)
-	}
-
-	var result struct {
-		Users []rawUserModel `json:"result"`
-	}
-
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
-		return nil, err
-	}
-
-	return result.Users, nil
+// FetchUsers fetches all users from the Superset API, walking every page of
+// the "/api/v1/security/users/" endpoint, and returns a slice of UserListItem and an error.
+func (c *Client) FetchUsers(ctx context.Context) ([]UserListItem, error) {
+	return collect(paginate[UserListItem](ctx, c, "/api/v1/security/users/", "users", ListQuery{}))
 }
 
 // GetUser retrieves a user by its ID from the Superset API.
@@ -1869,17 +1467,16 @@ func (c *Client) FetchUsers() ([]rawUserModel, error) {
 // and returns the user as a *User object if successful.
 // If there is an error during the request or response handling,
 // it returns nil and an error describing the issue.
-func (c *Client) GetUser(id int64) (*User, error) {
+func (c *Client) GetUser(ctx context.Context, id int64) (*User, error) {
 	endpoint := fmt.Sprintf("/api/v1/security/users/%d", id)
-	resp, err := c.DoRequest("GET", endpoint, nil)
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error making GET request to %s: %v", endpoint, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to fetch user, status code: %d, response: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "fetch user")
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -1889,7 +1486,7 @@ func (c *Client) GetUser(id int64) (*User, error) {
 
 	var result struct {
 		ID     int64        `json:"id"`
-		Result rawUserModel `json:"result"`
+		Result UserListItem `json:"result"`
 	}
 
 	err = json.Unmarshal(body, &result)
@@ -1897,7 +1494,7 @@ func (c *Client) GetUser(id int64) (*User, error) {
 		return nil, fmt.Errorf("error unmarshalling response to struct: %v", err)
 	}
 
-	// Convert rawUserModel to User
+	// Convert UserListItem to User
 	user := &User{
 		ID:        result.Result.ID,
 		Username:  result.Result.Username,
@@ -1917,7 +1514,7 @@ func (c *Client) GetUser(id int64) (*User, error) {
 
 // CreateUser creates a user with the specified parameters in the Superset application.
 // It returns the ID of the created user and any error encountered.
-func (c *Client) CreateUser(username, firstName, lastName, email, password string, active bool, roles []int64) (int64, error) {
+func (c *Client) CreateUser(ctx context.Context, username, firstName, lastName, email, password string, active bool, roles []int64) (int64, error) {
 	endpoint := "/api/v1/security/users/"
 	payload := map[string]interface{}{
 		"username":   username,
@@ -1929,15 +1526,14 @@ func (c *Client) CreateUser(username, firstName, lastName, email, password strin
 		"roles":      roles,
 	}
 
-	resp, err := c.DoRequest("POST", endpoint, payload)
+	resp, err := c.DoRequest(ctx, "POST", endpoint, payload)
 	if err != nil {
 		return 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("failed to create user, status code: %d, response: %s", resp.StatusCode, string(body))
+		return 0, newAPIError(resp, "create user")
 	}
 
 	var result map[string]interface{}
@@ -1959,7 +1555,7 @@ func (c *Client) CreateUser(username, firstName, lastName, email, password strin
 // The updated user data is sent to the Superset API using a PUT request.
 // If the update is successful, the function returns nil.
 // If the update fails, an error is returned with the corresponding status code and response body.
-func (c *Client) UpdateUser(id int64, username, firstName, lastName, email, password string, active bool, roles []int64) error {
+func (c *Client) UpdateUser(ctx context.Context, id int64, username, firstName, lastName, email, password string, active bool, roles []int64) error {
 	endpoint := fmt.Sprintf("/api/v1/security/users/%d", id)
 	payload := map[string]interface{}{
 		"username":   username,
@@ -1975,15 +1571,14 @@ func (c *Client) UpdateUser(id int64, username, firstName, lastName, email, pass
 		payload["password"] = password
 	}
 
-	resp, err := c.DoRequest("PUT", endpoint, payload)
+	resp, err := c.DoRequest(ctx, "PUT", endpoint, payload)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update user, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "update user")
 	}
 
 	return nil
@@ -1994,17 +1589,16 @@ func (c *Client) UpdateUser(id int64, username, firstName, lastName, email, pass
 // If the request is successful and the user is deleted, it returns nil.
 // If there is an error or the response status code is not 204 (No Content) or 200 (OK),
 // it returns an error with the corresponding status code and response body.
-func (c *Client) DeleteUser(id int64) error {
+func (c *Client) DeleteUser(ctx context.Context, id int64) error {
 	endpoint := fmt.Sprintf("/api/v1/security/users/%d", id)
-	resp, err := c.DoRequest("DELETE", endpoint, nil)
+	resp, err := c.DoRequest(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete user, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "delete user")
 	}
 
 	return nil
@@ -2023,17 +1617,11 @@ type RowLevelSecurity struct {
 }
 
 // CreateRowLevelSecurity creates a new RLS rule in Superset.
-func (c *Client) CreateRowLevelSecurity(name string, tables []int64, clause string, roleIDs []int64, groupKey, filterType, description string) (int64, error) {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
+func (c *Client) CreateRowLevelSecurity(ctx context.Context, name string, tables []int64, clause string, roleIDs []int64, groupKey, filterType, description string) (int64, error) {
+	if err := c.require(FeatureRowLevelSecurityAPI); err != nil {
 		return 0, err
 	}
 
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
-
 	endpoint := "/api/v1/rowlevelsecurity/"
 	payload := map[string]interface{}{
 		"name":        name,
@@ -2045,15 +1633,14 @@ func (c *Client) CreateRowLevelSecurity(name string, tables []int64, clause stri
 		"description": description,
 	}
 
-	resp, err := c.DoRequestWithHeadersAndCookies("POST", endpoint, payload, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "POST", endpoint, payload)
 	if err != nil {
 		return 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("failed to create RLS rule, status code: %d, response: %s", resp.StatusCode, string(body))
+		return 0, newAPIError(resp, "create RLS rule")
 	}
 
 	var result map[string]interface{}
@@ -2071,17 +1658,20 @@ func (c *Client) CreateRowLevelSecurity(name string, tables []int64, clause stri
 }
 
 // GetRowLevelSecurity retrieves an RLS rule by its ID.
-func (c *Client) GetRowLevelSecurity(id int64) (*RowLevelSecurity, error) {
+func (c *Client) GetRowLevelSecurity(ctx context.Context, id int64) (*RowLevelSecurity, error) {
+	if err := c.require(FeatureRowLevelSecurityAPI); err != nil {
+		return nil, err
+	}
+
 	endpoint := fmt.Sprintf("/api/v1/rowlevelsecurity/%d", id)
-	resp, err := c.DoRequest("GET", endpoint, nil)
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to fetch RLS rule, status code: %d, response: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "fetch RLS rule")
 	}
 
 	var result struct {
@@ -2129,17 +1719,11 @@ func (c *Client) GetRowLevelSecurity(id int64) (*RowLevelSecurity, error) {
 }
 
 // UpdateRowLevelSecurity updates an existing RLS rule.
-func (c *Client) UpdateRowLevelSecurity(id int64, name string, tables []int64, clause string, roleIDs []int64, groupKey, filterType, description string) error {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
+func (c *Client) UpdateRowLevelSecurity(ctx context.Context, id int64, name string, tables []int64, clause string, roleIDs []int64, groupKey, filterType, description string) error {
+	if err := c.require(FeatureRowLevelSecurityAPI); err != nil {
 		return err
 	}
 
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
-
 	endpoint := fmt.Sprintf("/api/v1/rowlevelsecurity/%d", id)
 	payload := map[string]interface{}{
 		"name":        name,
@@ -2151,42 +1735,34 @@ func (c *Client) UpdateRowLevelSecurity(id int64, name string, tables []int64, c
 		"description": description,
 	}
 
-	resp, err := c.DoRequestWithHeadersAndCookies("PUT", endpoint, payload, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "PUT", endpoint, payload)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update RLS rule, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "update RLS rule")
 	}
 
 	return nil
 }
 
 // DeleteRowLevelSecurity deletes an RLS rule by ID.
-func (c *Client) DeleteRowLevelSecurity(id int64) error {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
+func (c *Client) DeleteRowLevelSecurity(ctx context.Context, id int64) error {
+	if err := c.require(FeatureRowLevelSecurityAPI); err != nil {
 		return err
 	}
 
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
-
 	endpoint := fmt.Sprintf("/api/v1/rowlevelsecurity/%d", id)
-	resp, err := c.DoRequestWithHeadersAndCookies("DELETE", endpoint, nil, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete RLS rule, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "delete RLS rule")
 	}
 
 	return nil
@@ -2199,9 +1775,13 @@ type DashboardEmbedded struct {
 }
 
 // GetDashboardEmbedded retrieves the embedded configuration for a dashboard.
-func (c *Client) GetDashboardEmbedded(dashboardID int64) (*DashboardEmbedded, error) {
+func (c *Client) GetDashboardEmbedded(ctx context.Context, dashboardID int64) (*DashboardEmbedded, error) {
+	if err := c.require(FeatureEmbeddedDashboards); err != nil {
+		return nil, err
+	}
+
 	endpoint := fmt.Sprintf("/api/v1/dashboard/%d/embedded", dashboardID)
-	resp, err := c.DoRequest("GET", endpoint, nil)
+	resp, err := c.DoRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2211,8 +1791,7 @@ func (c *Client) GetDashboardEmbedded(dashboardID int64) (*DashboardEmbedded, er
 		return nil, nil
 	}
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get embedded dashboard, status code: %d, response: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "get embedded dashboard")
 	}
 
 	var result struct {
@@ -2225,28 +1804,23 @@ func (c *Client) GetDashboardEmbedded(dashboardID int64) (*DashboardEmbedded, er
 }
 
 // CreateDashboardEmbedded creates or updates the embedded configuration for a dashboard.
-func (c *Client) CreateDashboardEmbedded(dashboardID int64, allowedDomains []string) (*DashboardEmbedded, error) {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
+func (c *Client) CreateDashboardEmbedded(ctx context.Context, dashboardID int64, allowedDomains []string) (*DashboardEmbedded, error) {
+	if err := c.require(FeatureEmbeddedDashboards); err != nil {
 		return nil, err
 	}
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
+
 	payload := map[string]interface{}{
 		"allowed_domains": allowedDomains,
 	}
 	endpoint := fmt.Sprintf("/api/v1/dashboard/%d/embedded", dashboardID)
-	resp, err := c.DoRequestWithHeadersAndCookies("POST", endpoint, payload, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "POST", endpoint, payload)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to create embedded dashboard, status code: %d, response: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp, "create embedded dashboard")
 	}
 
 	var result struct {
@@ -2259,25 +1833,20 @@ func (c *Client) CreateDashboardEmbedded(dashboardID int64, allowedDomains []str
 }
 
 // DeleteDashboardEmbedded removes the embedded configuration for a dashboard.
-func (c *Client) DeleteDashboardEmbedded(dashboardID int64) error {
-	csrfToken, cookies, err := c.GetCSRFToken()
-	if err != nil {
+func (c *Client) DeleteDashboardEmbedded(ctx context.Context, dashboardID int64) error {
+	if err := c.require(FeatureEmbeddedDashboards); err != nil {
 		return err
 	}
-	headers := map[string]string{
-		"X-CSRFToken": csrfToken,
-		"Referer":     c.Host,
-	}
+
 	endpoint := fmt.Sprintf("/api/v1/dashboard/%d/embedded", dashboardID)
-	resp, err := c.DoRequestWithHeadersAndCookies("DELETE", endpoint, nil, headers, cookies)
+	resp, err := c.DoRequestWithCSRF(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete embedded dashboard, status code: %d, response: %s", resp.StatusCode, string(body))
+		return newAPIError(resp, "delete embedded dashboard")
 	}
 	return nil
 }
@@ -22,7 +22,7 @@ func TestCreateRowLevelSecurity(t *testing.T) {
 	httpmock.RegisterResponder("POST", "http://test-host/api/v1/rowlevelsecurity/",
 		httpmock.NewStringResponder(201, `{"id": 123}`))
 
-	id, err := client.CreateRowLevelSecurity("test_rls", []int64{1}, "user_id = 1", []int64{2}, "group1", "Regular", "Test RLS")
+	id, err := client.CreateRowLevelSecurity(t.Context(), "test_rls", []int64{1}, "user_id = 1", []int64{2}, "group1", "Regular", "Test RLS")
 
 	assert.NoError(t, err)
 	assert.Equal(t, int64(123), id)
@@ -51,7 +51,7 @@ func TestGetRowLevelSecurity(t *testing.T) {
 			}
 		}`))
 
-	rls, err := client.GetRowLevelSecurity(123)
+	rls, err := client.GetRowLevelSecurity(t.Context(), 123)
 
 	assert.NoError(t, err)
 	assert.Equal(t, int64(123), rls.ID)
@@ -76,7 +76,7 @@ func TestUpdateRowLevelSecurity(t *testing.T) {
 	httpmock.RegisterResponder("PUT", "http://test-host/api/v1/rowlevelsecurity/123",
 		httpmock.NewStringResponder(200, `{}`))
 
-	err := client.UpdateRowLevelSecurity(123, "updated_rls", []int64{1, 2}, "user_id = 2", []int64{3}, "group2", "Base", "Updated RLS")
+	err := client.UpdateRowLevelSecurity(t.Context(), 123, "updated_rls", []int64{1, 2}, "user_id = 2", []int64{3}, "group2", "Base", "Updated RLS")
 
 	assert.NoError(t, err)
 }
@@ -96,7 +96,7 @@ func TestDeleteRowLevelSecurity(t *testing.T) {
 	httpmock.RegisterResponder("DELETE", "http://test-host/api/v1/rowlevelsecurity/123",
 		httpmock.NewStringResponder(200, ``))
 
-	err := client.DeleteRowLevelSecurity(123)
+	err := client.DeleteRowLevelSecurity(t.Context(), 123)
 
 	assert.NoError(t, err)
 }
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DefaultRequestTimeout bounds a single HTTP request when the provider does not configure request_timeout.
+const DefaultRequestTimeout = 120 * time.Second
+
+// TransportConfig describes how the shared *http.Client talks to Superset.
+type TransportConfig struct {
+	// RequestTimeout bounds a single request, including reading the response body. Zero means no timeout.
+	// Streamed uploads are the exception; see withUploadTimeout.
+	RequestTimeout time.Duration
+	// CACertPEM holds extra PEM-encoded certificate authorities trusted in addition to the system pool.
+	CACertPEM string
+	// ClientCertPEM and ClientKeyPEM hold the PEM-encoded certificate and key presented for mTLS.
+	ClientCertPEM string
+	ClientKeyPEM  string
+	// InsecureSkipVerify disables TLS certificate verification.
+	InsecureSkipVerify bool
+	// ProxyURL routes every request through the given proxy instead of the *_PROXY environment variables.
+	ProxyURL string
+	// WrapTransport, when set, wraps the transport built from the settings above, e.g. to record
+	// or replay traffic in tests. It receives http.DefaultTransport when nothing is customized.
+	WrapTransport func(http.RoundTripper) http.RoundTripper
+}
+
+// customizesTransport reports whether the settings need a dedicated *http.Transport.
+func (tc TransportConfig) customizesTransport() bool {
+	return tc.CACertPEM != "" || tc.ClientCertPEM != "" || tc.ClientKeyPEM != "" || tc.InsecureSkipVerify || tc.ProxyURL != ""
+}
+
+// NewHTTPClient builds the *http.Client shared by every request a Client makes.
+// Without TLS or proxy settings it keeps using http.DefaultTransport, so connections are pooled
+// across the whole provider process.
+func NewHTTPClient(tc TransportConfig) (*http.Client, error) {
+	httpClient, err := newHTTPClient(tc)
+	if err != nil {
+		return nil, err
+	}
+	if tc.WrapTransport != nil {
+		base := httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		httpClient.Transport = tc.WrapTransport(base)
+	}
+	return httpClient, nil
+}
+
+func newHTTPClient(tc TransportConfig) (*http.Client, error) {
+	httpClient := &http.Client{Timeout: tc.RequestTimeout}
+	if !tc.customizesTransport() {
+		return httpClient, nil
+	}
+
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("http.DefaultTransport is %T, cannot apply TLS or proxy settings", http.DefaultTransport)
+	}
+	transport := base.Clone()
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: tc.InsecureSkipVerify, //nolint:gosec // explicitly requested by the practitioner
+	}
+
+	if tc.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(tc.CACertPEM)) {
+			return nil, fmt.Errorf("no valid PEM certificates found in CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if tc.ClientCertPEM != "" || tc.ClientKeyPEM != "" {
+		if tc.ClientCertPEM == "" || tc.ClientKeyPEM == "" {
+			return nil, fmt.Errorf("both a client certificate and a client key are required for mTLS")
+		}
+		cert, err := tls.X509KeyPair([]byte(tc.ClientCertPEM), []byte(tc.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	if tc.ProxyURL != "" {
+		proxy, err := url.Parse(tc.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing proxy URL: %w", err)
+		}
+		if proxy.Scheme == "" || proxy.Host == "" {
+			return nil, fmt.Errorf("proxy URL %q must include a scheme and host", tc.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	httpClient.Transport = transport
+	return httpClient, nil
+}
+
+// uploadKey marks a context whose requests stream an upload.
+type uploadKey struct{}
+
+// ResponseTimeoutError reports that Superset did not start answering a streamed upload in time.
+type ResponseTimeoutError struct {
+	Timeout time.Duration
+}
+
+// Error implements error.
+func (e *ResponseTimeoutError) Error() string {
+	return fmt.Sprintf("Superset did not respond within %s of receiving the upload", e.Timeout)
+}
+
+// withUploadTimeout prepares ctx for a streamed upload. An overall request timeout would also
+// cover sending the body, so a large bundle on a slow link would fail partway through; requests
+// made with the returned context ignore it. Instead, timeout bounds the wait for the response
+// headers once the body has been sent, and the context deadline, if any, bounds the rest. The
+// returned stop function must be called when the response has been read.
+func withUploadTimeout(ctx context.Context, timeout time.Duration) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	var mu sync.Mutex
+	var timer *time.Timer
+	stopTimer := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+
+	trace := &httptrace.ClientTrace{
+		// Each attempt resets the timer once its body is on the wire.
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			if timeout <= 0 {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(timeout, func() { cancel(&ResponseTimeoutError{Timeout: timeout}) })
+		},
+		GotFirstResponseByte: stopTimer,
+	}
+
+	ctx = httptrace.WithClientTrace(context.WithValue(ctx, uploadKey{}, true), trace)
+	return ctx, func() {
+		stopTimer()
+		cancel(nil)
+	}
+}
+
+// withoutUploadTimeout returns httpClient, or a copy without its overall timeout when ctx
+// streams an upload.
+func withoutUploadTimeout(ctx context.Context, httpClient *http.Client) *http.Client {
+	if httpClient.Timeout <= 0 || ctx.Value(uploadKey{}) == nil {
+		return httpClient
+	}
+	unbounded := *httpClient
+	unbounded.Timeout = 0
+	return &unbounded
+}
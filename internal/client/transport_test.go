@@ -0,0 +1,141 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// selfSignedPEM returns a throwaway certificate and key in PEM form.
+func selfSignedPEM(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "superset-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
+
+func TestNewHTTPClient_DefaultKeepsSharedTransport(t *testing.T) {
+	httpClient, err := NewHTTPClient(TransportConfig{RequestTimeout: 5 * time.Second})
+
+	require.NoError(t, err)
+	assert.Nil(t, httpClient.Transport)
+	assert.Equal(t, 5*time.Second, httpClient.Timeout)
+}
+
+func TestNewHTTPClient_TLSAndProxy(t *testing.T) {
+	certPEM, keyPEM := selfSignedPEM(t)
+
+	httpClient, err := NewHTTPClient(TransportConfig{
+		CACertPEM:     certPEM,
+		ClientCertPEM: certPEM,
+		ClientKeyPEM:  keyPEM,
+		ProxyURL:      "http://proxy.internal:3128",
+	})
+	require.NoError(t, err)
+
+	transport, ok := httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.NotNil(t, transport.TLSClientConfig.RootCAs)
+	assert.Len(t, transport.TLSClientConfig.Certificates, 1)
+
+	req, _ := http.NewRequest("GET", "https://superset.internal/health", nil)
+	proxy, err := transport.Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, "proxy.internal:3128", proxy.Host)
+}
+
+type wrappedTransport struct{ base http.RoundTripper }
+
+func (w wrappedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return w.base.RoundTrip(req)
+}
+
+func TestNewHTTPClient_WrapTransport(t *testing.T) {
+	wrap := func(base http.RoundTripper) http.RoundTripper { return wrappedTransport{base: base} }
+
+	httpClient, err := NewHTTPClient(TransportConfig{WrapTransport: wrap})
+	require.NoError(t, err)
+	wrapped, ok := httpClient.Transport.(wrappedTransport)
+	require.True(t, ok)
+	assert.Equal(t, http.DefaultTransport, wrapped.base)
+
+	httpClient, err = NewHTTPClient(TransportConfig{ProxyURL: "http://proxy.internal:3128", WrapTransport: wrap})
+	require.NoError(t, err)
+	wrapped, ok = httpClient.Transport.(wrappedTransport)
+	require.True(t, ok)
+	assert.IsType(t, &http.Transport{}, wrapped.base)
+	assert.NotEqual(t, http.DefaultTransport, wrapped.base)
+}
+
+func TestNewHTTPClient_InvalidSettings(t *testing.T) {
+	certPEM, _ := selfSignedPEM(t)
+
+	tests := []struct {
+		name    string
+		config  TransportConfig
+		wantErr string
+	}{
+		{name: "bad CA", config: TransportConfig{CACertPEM: "not a cert"}, wantErr: "no valid PEM certificates"},
+		{name: "cert without key", config: TransportConfig{ClientCertPEM: certPEM}, wantErr: "both a client certificate and a client key"},
+		{name: "proxy without scheme", config: TransportConfig{ProxyURL: "proxy.internal"}, wantErr: "must include a scheme and host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHTTPClient(tt.config)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestDoRequest_SendsExtraHeaders(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := &Client{
+		Host:         "http://test-host",
+		Token:        "test-token",
+		extraHeaders: map[string]string{"X-Tenant": "analytics", "Authorization": "ignored"},
+	}
+
+	httpmock.RegisterResponder("GET", "http://test-host/api/v1/css_template/42",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "analytics", req.Header.Get("X-Tenant"))
+			assert.Equal(t, "Bearer test-token", req.Header.Get("Authorization"))
+			return httpmock.NewStringResponse(200, `{"result": {"id": 42, "template_name": "Test", "css": "body {}"}}`), nil
+		})
+
+	_, err := client.GetCSSTemplate(t.Context(), 42)
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"terraform-provider-superset/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// requireFeature adds an error diagnostic and returns false when the configured Superset
+// server is known not to support f. An unconfigured client or an undetected version passes.
+func requireFeature(c client.SupersetAPI, f client.Feature, diags *diag.Diagnostics) bool {
+	if c == nil {
+		return true
+	}
+	if err := c.Capabilities().Require(f); err != nil {
+		diags.AddError("Unsupported Superset version", err.Error())
+		return false
+	}
+	return true
+}
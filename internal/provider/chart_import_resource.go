@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"terraform-provider-superset/internal/client"
 
@@ -30,7 +31,7 @@ func NewChartImportResource() resource.Resource {
 }
 
 type chartImportResource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 type chartImportResourceModel struct {
@@ -108,10 +109,10 @@ func (r *chartImportResource) Configure(_ context.Context, req resource.Configur
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	c, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T.", req.ProviderData))
 		return
 	}
 	r.client = c
@@ -219,7 +220,7 @@ func (r *chartImportResource) Delete(ctx context.Context, req resource.DeleteReq
 	var ownerDashboardID int64
 	dashUUIDs, _ := readUUIDsFromDir(sourceDir, "dashboards/")
 	if len(dashUUIDs) > 0 {
-		ownerDashboardID, _ = r.client.GetDashboardIDByUUID(dashUUIDs[0])
+		ownerDashboardID, _ = r.client.GetDashboardIDByUUID(ctx, dashUUIDs[0])
 	}
 
 	// Read chart UUIDs from the source directory
@@ -229,18 +230,20 @@ func (r *chartImportResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	ids, err := r.client.GetChartIDsByUUIDs(ctx, uuids)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Failed to look up chart UUIDs: %s", err))
+		return
+	}
+
 	for _, uuid := range uuids {
-		id, err := r.client.GetChartIDByUUID(uuid)
-		if err != nil {
-			tflog.Warn(ctx, fmt.Sprintf("Failed to look up chart UUID %s: %s", uuid, err))
-			continue
-		}
-		if id == 0 {
+		id, ok := ids[uuid]
+		if !ok {
 			continue
 		}
 
 		// Check how many dashboards reference this chart
-		dashCount, err := r.client.GetChartDashboardCount(id)
+		dashCount, err := r.client.GetChartDashboardCount(ctx, id)
 		if err != nil {
 			tflog.Warn(ctx, fmt.Sprintf("Failed to check chart %d references: %s", id, err))
 			// Can't determine — try to delete anyway
@@ -250,19 +253,19 @@ func (r *chartImportResource) Delete(ctx context.Context, req resource.DeleteReq
 		// Always unlink from this resource's dashboard first
 		if ownerDashboardID > 0 && dashCount > 0 {
 			tflog.Info(ctx, fmt.Sprintf("Unlinking chart %d (UUID %s) from dashboard %d", id, uuid, ownerDashboardID))
-			if err := r.client.UnlinkChartsFromDashboard([]int64{id}, ownerDashboardID); err != nil {
+			if err := r.client.UnlinkChartsFromDashboard(ctx, []int64{id}, ownerDashboardID); err != nil {
 				tflog.Warn(ctx, fmt.Sprintf("Failed to unlink chart %d from dashboard %d: %s", id, ownerDashboardID, err))
 			}
 		}
 
 		// Re-check: if no dashboards reference it now, delete it
-		newDashCount, err := r.client.GetChartDashboardCount(id)
+		newDashCount, err := r.client.GetChartDashboardCount(ctx, id)
 		if err != nil {
 			newDashCount = 0 // assume safe to delete
 		}
 		if newDashCount == 0 {
 			tflog.Info(ctx, fmt.Sprintf("Deleting chart %d (UUID %s) — no longer referenced", id, uuid))
-			if err := r.client.DeleteChart(id); err != nil {
+			if err := r.client.DeleteChart(ctx, id); err != nil {
 				resp.Diagnostics.AddWarning("Failed to delete chart",
 					fmt.Sprintf("Chart %d (UUID %s): %s", id, uuid, err))
 			}
@@ -317,15 +320,17 @@ func (r *chartImportResource) doImport(ctx context.Context, plan *chartImportRes
 		passwords = string(b)
 	}
 
-	zipData, err := zipDirectoryFiltered(sourceDir, overrides, chartImportPrefixes, "Slice", skipPatterns)
-	if err != nil {
-		return fmt.Errorf("creating ZIP: %w", err)
+	bundle := func(w io.Writer) error {
+		if err := zipDirectoryFiltered(w, sourceDir, overrides, chartImportPrefixes, "Slice", skipPatterns); err != nil {
+			return fmt.Errorf("creating ZIP: %w", err)
+		}
+		return nil
 	}
 
 	overwrite := plan.ForceOverwrite.ValueBool()
 	tflog.Info(ctx, fmt.Sprintf("Importing charts from %s (overwrite=%v)", sourceDir, overwrite))
 
-	if err := r.client.ImportChart(zipData, overwrite, passwords); err != nil {
+	if err := r.client.ImportChart(ctx, bundle, overwrite, passwords); err != nil {
 		return err
 	}
 
@@ -0,0 +1,410 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &chartResource{}
+	_ resource.ResourceWithConfigure   = &chartResource{}
+	_ resource.ResourceWithImportState = &chartResource{}
+)
+
+// NewChartResource is a helper function to simplify the provider implementation.
+func NewChartResource() resource.Resource {
+	return &chartResource{}
+}
+
+// chartResource is the resource implementation.
+type chartResource struct {
+	client client.SupersetAPI
+}
+
+// chartResourceModel maps the resource schema data.
+type chartResourceModel struct {
+	ID             types.Int64    `tfsdk:"id"`
+	UUID           types.String   `tfsdk:"uuid"`
+	SliceName      types.String   `tfsdk:"slice_name"`
+	VizType        types.String   `tfsdk:"viz_type"`
+	DatasourceID   types.Int64    `tfsdk:"datasource_id"`
+	DatasourceType types.String   `tfsdk:"datasource_type"`
+	Params         normalizedJSON `tfsdk:"params"`
+	QueryContext   normalizedJSON `tfsdk:"query_context"`
+	Description    types.String   `tfsdk:"description"`
+	CacheTimeout   types.Int64    `tfsdk:"cache_timeout"`
+	Owners         types.Set      `tfsdk:"owners"`
+	Dashboards     types.Set      `tfsdk:"dashboards"`
+}
+
+// Metadata returns the resource type name.
+func (r *chartResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_chart"
+}
+
+// Schema defines the schema for the resource.
+func (r *chartResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a chart in Superset through its attributes. Use superset_chart_import to manage charts from export directories instead.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the chart.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"uuid": schema.StringAttribute{
+				Description: "UUID of the chart.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"slice_name": schema.StringAttribute{
+				Description: "Name of the chart.",
+				Required:    true,
+			},
+			"viz_type": schema.StringAttribute{
+				Description: "Visualization type of the chart, for example 'table' or 'echarts_timeseries_line'.",
+				Required:    true,
+			},
+			"datasource_id": schema.Int64Attribute{
+				Description: "ID of the dataset (or saved query) the chart queries.",
+				Required:    true,
+			},
+			"datasource_type": schema.StringAttribute{
+				Description: "Type of the datasource: 'table' for datasets or 'query' for saved queries. Defaults to 'table'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("table"),
+			},
+			"params": schema.StringAttribute{
+				Description: "Form data of the chart as a JSON document. Differences in whitespace and key order are ignored.",
+				CustomType:  normalizedJSONType{},
+				Optional:    true,
+			},
+			"query_context": schema.StringAttribute{
+				Description: "Query context of the chart as a JSON document, used by the chart data API. Differences in whitespace and key order are ignored.",
+				CustomType:  normalizedJSONType{},
+				Optional:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the chart.",
+				Optional:    true,
+			},
+			"cache_timeout": schema.Int64Attribute{
+				Description: "Cache timeout of the chart in seconds. Unset uses the dataset's or database's timeout.",
+				Optional:    true,
+			},
+			"owners": schema.SetAttribute{
+				Description: "IDs of the users owning the chart. Superset makes the creating user the owner when unset. Removing the attribute keeps the current owners; set it to [] to remove them.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dashboards": schema.SetAttribute{
+				Description: "IDs of the dashboards the chart belongs to. Leave it unset for charts placed by a superset_dashboard layout, which links them itself. Removing the attribute keeps the current links; set it to [] to unlink the chart from every dashboard.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *chartResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan chartResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	chartReq, diags := plan.request(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating chart", map[string]interface{}{
+		"slice_name":    chartReq.SliceName,
+		"datasource_id": chartReq.DatasourceID,
+	})
+
+	id, err := r.client.CreateChart(ctx, chartReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating chart",
+			"Could not create chart: "+err.Error(),
+		)
+		return
+	}
+
+	// Save the ID right away so a failed read-back does not leak the chart.
+	plan.ID = types.Int64Value(id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+
+	chart, err := r.client.GetChart(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading chart",
+			"Could not read chart ID "+fmt.Sprintf("%d", id)+" after creating it: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(plan.apply(ctx, chart)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Created chart", map[string]interface{}{
+		"id":   id,
+		"uuid": chart.UUID,
+	})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *chartResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state chartResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	chart, err := r.client.GetChart(ctx, state.ID.ValueInt64())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Chart ID %d not found, removing from state", state.ID.ValueInt64()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading chart",
+			"Could not read chart ID "+fmt.Sprintf("%d", state.ID.ValueInt64())+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.apply(ctx, chart)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *chartResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan chartResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	chartReq, diags := plan.request(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.UpdateChart(ctx, plan.ID.ValueInt64(), chartReq); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating chart",
+			"Could not update chart ID "+fmt.Sprintf("%d", plan.ID.ValueInt64())+": "+err.Error(),
+		)
+		return
+	}
+
+	chart, err := r.client.GetChart(ctx, plan.ID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading chart",
+			"Could not read chart ID "+fmt.Sprintf("%d", plan.ID.ValueInt64())+" after updating it: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(plan.apply(ctx, chart)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updated chart", map[string]interface{}{
+		"id": plan.ID.ValueInt64(),
+	})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *chartResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state chartResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteChart(ctx, state.ID.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting chart",
+			"Could not delete chart, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted chart", map[string]interface{}{
+		"id": state.ID.ValueInt64(),
+	})
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *chartResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.SupersetAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ImportState imports a chart by its numeric ID or its UUID.
+func (r *chartResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		id, err = r.client.GetChartIDByUUID(ctx, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error importing chart",
+				fmt.Sprintf("Could not look up chart with UUID '%s': %s", req.ID, err.Error()),
+			)
+			return
+		}
+		if id == 0 {
+			resp.Diagnostics.AddError(
+				"Error importing chart",
+				fmt.Sprintf("Import ID '%s' is neither a chart ID nor the UUID of an existing chart.", req.ID),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// request builds the create or update payload from the model. Unset optional attributes are
+// sent empty so that removing them from the configuration clears them in Superset. Owners and
+// dashboards are the exception: they keep their state when removed from the configuration, as
+// Superset picks the owner and dashboard layouts link charts, so only a configured value,
+// including an empty set, is sent.
+func (m *chartResourceModel) request(ctx context.Context) (client.ChartRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	description := m.Description.ValueString()
+	params := m.Params.ValueString()
+	queryContext := m.QueryContext.ValueString()
+	chartReq := client.ChartRequest{
+		SliceName:      m.SliceName.ValueString(),
+		VizType:        m.VizType.ValueString(),
+		DatasourceID:   m.DatasourceID.ValueInt64(),
+		DatasourceType: m.DatasourceType.ValueString(),
+		Description:    &description,
+		Params:         &params,
+		QueryContext:   &queryContext,
+		CacheTimeout:   m.CacheTimeout.ValueInt64Pointer(),
+	}
+
+	if !m.Owners.IsUnknown() && !m.Owners.IsNull() {
+		owners := []int64{}
+		diags.Append(m.Owners.ElementsAs(ctx, &owners, false)...)
+		chartReq.Owners = &owners
+	}
+	if !m.Dashboards.IsUnknown() && !m.Dashboards.IsNull() {
+		dashboards := []int64{}
+		diags.Append(m.Dashboards.ElementsAs(ctx, &dashboards, false)...)
+		chartReq.Dashboards = &dashboards
+	}
+
+	return chartReq, diags
+}
+
+// apply copies a chart as Superset returned it into the model, so that changes made outside
+// Terraform show up as drift.
+func (m *chartResourceModel) apply(ctx context.Context, chart *client.Chart) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.Int64Value(chart.ID)
+	m.UUID = types.StringValue(chart.UUID)
+	m.SliceName = types.StringValue(chart.SliceName)
+	m.VizType = types.StringValue(chart.VizType)
+	m.DatasourceID = types.Int64Value(chart.DatasourceID)
+	m.DatasourceType = types.StringValue(chart.DatasourceType)
+	m.CacheTimeout = types.Int64PointerValue(chart.CacheTimeout)
+
+	m.Description = optionalString(chart.Description, m.Description)
+	if chart.Params != "" {
+		m.Params = newNormalizedJSONValue(chart.Params.String())
+	} else {
+		m.Params = newNormalizedJSONNull()
+	}
+	if chart.QueryContext != "" {
+		m.QueryContext = newNormalizedJSONValue(chart.QueryContext.String())
+	} else {
+		m.QueryContext = newNormalizedJSONNull()
+	}
+
+	owners := make([]int64, 0, len(chart.Owners))
+	for _, owner := range chart.Owners {
+		owners = append(owners, owner.ID)
+	}
+	ownerSet, d := types.SetValueFrom(ctx, types.Int64Type, owners)
+	diags.Append(d...)
+	m.Owners = ownerSet
+
+	dashboards := make([]int64, 0, len(chart.Dashboards))
+	for _, dashboard := range chart.Dashboards {
+		dashboards = append(dashboards, dashboard.ID)
+	}
+	dashboardSet, d := types.SetValueFrom(ctx, types.Int64Type, dashboards)
+	diags.Append(d...)
+	m.Dashboards = dashboardSet
+
+	return diags
+}
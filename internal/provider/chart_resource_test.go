@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-superset/internal/client"
+	"terraform-provider-superset/internal/testing/fakesuperset"
+)
+
+// addChartDataset creates a database and a dataset on the fake server for charts to query.
+func addChartDataset(t *testing.T, c *client.Client) int64 {
+	t.Helper()
+	db, err := c.CreateDatabase(t.Context(), map[string]interface{}{"database_name": "warehouse", "sqlalchemy_uri": "sqlite://"})
+	require.NoError(t, err)
+	ds, err := c.CreateDataset(t.Context(), client.DatasetRequest{TableName: "orders", Database: db.ID})
+	require.NoError(t, err)
+	return ds.ID
+}
+
+func TestAccChartResource_FakeSuperset(t *testing.T) {
+	fake := fakesuperset.New(t)
+	datasetID := addChartDataset(t, fakeClient(t, fake))
+
+	config := func(name, params string) string {
+		return fakeProviderConfig(fake) + fmt.Sprintf(`
+resource "superset_chart" "test" {
+  slice_name    = %q
+  viz_type      = "table"
+  datasource_id = %d
+  params        = %q
+  cache_timeout = 300
+}
+`, name, datasetID, params)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			_, err := fakeClient(t, fake).GetChart(t.Context(), 1)
+			if !client.IsNotFound(err) {
+				return fmt.Errorf("chart left after destroy: %v", err)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("Orders", `{"row_limit": 100, "viz_type": "table"}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_chart.test", "id", "1"),
+					resource.TestCheckResourceAttr("superset_chart.test", "datasource_type", "table"),
+					resource.TestCheckResourceAttrSet("superset_chart.test", "uuid"),
+				),
+			},
+			{
+				// Reformatting the params is not a change.
+				Config:   config("Orders", `{"viz_type":"table","row_limit":100}`),
+				PlanOnly: true,
+			},
+			{
+				Config: config("Orders by day", `{"row_limit": 50, "viz_type": "table"}`),
+				Check:  resource.TestCheckResourceAttr("superset_chart.test", "slice_name", "Orders by day"),
+			},
+			{
+				ResourceName:      "superset_chart.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "superset_chart.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["superset_chart.test"].Primary.Attributes["uuid"], nil
+				},
+			},
+		},
+	})
+}
+
+func TestChartResource_ReadDetectsDrift(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	datasetID := addChartDataset(t, c)
+	r := &chartResource{client: c}
+
+	createResp := &fwresource.CreateResponse{State: emptyState(t, r)}
+	r.Create(t.Context(), fwresource.CreateRequest{
+		Plan: planFor(t, r, chartResourceModel{
+			ID:             types.Int64Unknown(),
+			UUID:           types.StringUnknown(),
+			SliceName:      types.StringValue("Orders"),
+			VizType:        types.StringValue("table"),
+			DatasourceID:   types.Int64Value(datasetID),
+			DatasourceType: types.StringValue("table"),
+			Params:         newNormalizedJSONValue(`{"row_limit": 100}`),
+			QueryContext:   newNormalizedJSONNull(),
+			Description:    types.StringNull(),
+			CacheTimeout:   types.Int64Null(),
+			Owners:         types.SetUnknown(types.Int64Type),
+			Dashboards:     types.SetUnknown(types.Int64Type),
+		}),
+	}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+	var created chartResourceModel
+	require.False(t, createResp.State.Get(t.Context(), &created).HasError())
+	assert.NotEmpty(t, created.UUID.ValueString())
+	assert.True(t, created.Description.IsNull())
+	assert.Empty(t, created.Dashboards.Elements())
+
+	// Someone edits the chart in the Superset UI.
+	description := "Edited by hand"
+	timeout := int64(60)
+	require.NoError(t, c.UpdateChart(t.Context(), created.ID.ValueInt64(), client.ChartRequest{
+		SliceName:      "Orders (copy)",
+		VizType:        "bar",
+		DatasourceID:   datasetID,
+		DatasourceType: "table",
+		Description:    &description,
+		CacheTimeout:   &timeout,
+	}))
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(t.Context(), fwresource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+
+	var refreshed chartResourceModel
+	require.False(t, readResp.State.Get(t.Context(), &refreshed).HasError())
+	assert.Equal(t, "Orders (copy)", refreshed.SliceName.ValueString())
+	assert.Equal(t, "bar", refreshed.VizType.ValueString())
+	assert.Equal(t, "Edited by hand", refreshed.Description.ValueString())
+	assert.Equal(t, int64(60), refreshed.CacheTimeout.ValueInt64())
+
+	require.NoError(t, c.DeleteChart(t.Context(), created.ID.ValueInt64()))
+	readResp = &fwresource.ReadResponse{State: readResp.State}
+	r.Read(t.Context(), fwresource.ReadRequest{State: readResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull())
+}
+
+func TestChartResource_EmptyDashboardsUnlinks(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	datasetID := addChartDataset(t, c)
+	dashboardID, err := c.CreateDashboard(t.Context(), client.DashboardRequest{DashboardTitle: "Sales"})
+	require.NoError(t, err)
+	r := &chartResource{client: c}
+
+	linked, diags := types.SetValueFrom(t.Context(), types.Int64Type, []int64{dashboardID})
+	require.False(t, diags.HasError())
+	model := chartResourceModel{
+		ID:             types.Int64Unknown(),
+		UUID:           types.StringUnknown(),
+		SliceName:      types.StringValue("Orders"),
+		VizType:        types.StringValue("table"),
+		DatasourceID:   types.Int64Value(datasetID),
+		DatasourceType: types.StringValue("table"),
+		Params:         newNormalizedJSONNull(),
+		QueryContext:   newNormalizedJSONNull(),
+		Description:    types.StringNull(),
+		CacheTimeout:   types.Int64Null(),
+		Owners:         types.SetUnknown(types.Int64Type),
+		Dashboards:     linked,
+	}
+	createResp := &fwresource.CreateResponse{State: emptyState(t, r)}
+	r.Create(t.Context(), fwresource.CreateRequest{Plan: planFor(t, r, model)}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+	require.False(t, createResp.State.Get(t.Context(), &model).HasError())
+
+	model.Dashboards = types.SetValueMust(types.Int64Type, nil)
+	updateResp := &fwresource.UpdateResponse{State: createResp.State}
+	r.Update(t.Context(), fwresource.UpdateRequest{Plan: planFor(t, r, model), State: createResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "%v", updateResp.Diagnostics)
+
+	chart, err := c.GetChart(t.Context(), model.ID.ValueInt64())
+	require.NoError(t, err)
+	assert.Empty(t, chart.Dashboards, "an empty set unlinks the chart")
+}
+
+func TestChartResource_ImportState(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	datasetID := addChartDataset(t, c)
+	r := &chartResource{client: c}
+
+	id, err := c.CreateChart(t.Context(), client.ChartRequest{SliceName: "Orders", VizType: "table", DatasourceID: datasetID, DatasourceType: "table"})
+	require.NoError(t, err)
+	chart, err := c.GetChart(t.Context(), id)
+	require.NoError(t, err)
+
+	for _, importID := range []string{fmt.Sprint(id), chart.UUID} {
+		resp := &fwresource.ImportStateResponse{State: emptyState(t, r)}
+		r.ImportState(t.Context(), fwresource.ImportStateRequest{ID: importID}, resp)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+		var imported types.Int64
+		require.False(t, resp.State.GetAttribute(t.Context(), path.Root("id"), &imported).HasError())
+		assert.Equal(t, id, imported.ValueInt64(), "imported by %s", importID)
+	}
+
+	resp := &fwresource.ImportStateResponse{State: emptyState(t, r)}
+	r.ImportState(t.Context(), fwresource.ImportStateRequest{ID: "00000000-0000-0000-0000-000000000000"}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "neither a chart ID nor the UUID of an existing chart")
+}
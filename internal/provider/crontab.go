@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// crontabField describes one of the five fields of a crontab expression.
+type crontabField struct {
+	name     string
+	min, max int
+	// names maps the lower-case aliases of the field, such as "jan" or "mon", to their values.
+	names map[string]int
+}
+
+var crontabFields = []crontabField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	// Both 0 and 7 are Sunday.
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+// crontabMacros are the shorthand schedules Superset's cron library accepts in place of the five
+// fields.
+var crontabMacros = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// parseCrontab checks that expr is a five-field crontab expression, such as "0 9 * * mon-fri",
+// or one of the @ macros. Each field is a comma-separated list of "*", values and ranges, each
+// optionally followed by a "/step".
+func parseCrontab(expr string) error {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@") {
+		if !crontabMacros[strings.ToLower(expr)] {
+			return fmt.Errorf("unknown macro %q", expr)
+		}
+		return nil
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != len(crontabFields) {
+		return fmt.Errorf("expected 5 fields (minute, hour, day of month, month, day of week), got %d", len(fields))
+	}
+	for i, field := range fields {
+		for _, part := range strings.Split(field, ",") {
+			if err := crontabFields[i].parsePart(part); err != nil {
+				return fmt.Errorf("%s field %q: %w", crontabFields[i].name, field, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parsePart checks one comma-separated element of a field: "*", "N", "N-M", each optionally
+// followed by "/step".
+func (f crontabField) parsePart(part string) error {
+	rng, step, hasStep := strings.Cut(part, "/")
+	if hasStep {
+		n, err := strconv.Atoi(step)
+		if err != nil || n < 1 {
+			return fmt.Errorf("step %q is not a positive number", step)
+		}
+	}
+	if rng == "*" {
+		return nil
+	}
+
+	lo, hi, isRange := strings.Cut(rng, "-")
+	first, err := f.value(lo)
+	if err != nil {
+		return err
+	}
+	if !isRange {
+		return nil
+	}
+	last, err := f.value(hi)
+	if err != nil {
+		return err
+	}
+	if first > last {
+		return fmt.Errorf("range %q starts after it ends", rng)
+	}
+	return nil
+}
+
+// value parses a number or name and checks it is within the field's bounds.
+func (f crontabField) value(s string) (int, error) {
+	if n, ok := f.names[strings.ToLower(s)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%d is outside %d-%d", n, f.min, f.max)
+	}
+	return n, nil
+}
+
+// crontabValidator validates that a string is a crontab expression Superset can schedule.
+type crontabValidator struct{}
+
+func (v crontabValidator) Description(_ context.Context) string {
+	return "value must be a five-field crontab expression or an @ macro such as @daily"
+}
+
+func (v crontabValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v crontabValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := parseCrontab(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Crontab",
+			fmt.Sprintf("%q is not a valid crontab expression: %s.", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCrontab(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: "* * * * *"},
+		{expr: "*/15 * * * *"},
+		{expr: "0 9 * * mon-fri"},
+		{expr: "30 6,18 1-15/2 JAN,jul 0"},
+		{expr: "0 0 * * 7"},
+		{expr: "5/10 * * * *"},
+		{expr: "  0 12 * * *  "},
+		{expr: "@daily"},
+		{expr: "@Weekly"},
+		{expr: "0 9 * *", wantErr: "expected 5 fields"},
+		{expr: "0 0 9 * * *", wantErr: "got 6"},
+		{expr: "60 * * * *", wantErr: `minute field "60": 60 is outside 0-59`},
+		{expr: "0 24 * * *", wantErr: "hour field"},
+		{expr: "0 0 0 * *", wantErr: "day of month field"},
+		{expr: "0 0 * 13 *", wantErr: "month field"},
+		{expr: "0 0 * * 8", wantErr: "day of week field"},
+		{expr: "0 0 * * fri-mon", wantErr: "starts after it ends"},
+		{expr: "*/0 * * * *", wantErr: "not a positive number"},
+		{expr: "a * * * *", wantErr: `"a" is not a number`},
+		{expr: "1,,2 * * * *", wantErr: `"" is not a number`},
+		{expr: "@every 5m", wantErr: "unknown macro"},
+		{expr: "", wantErr: "got 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			err := parseCrontab(tt.expr)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCrontabValidator(t *testing.T) {
+	validate := func(value types.String) *validator.StringResponse {
+		resp := &validator.StringResponse{}
+		crontabValidator{}.ValidateString(t.Context(), validator.StringRequest{Path: path.Root("crontab"), ConfigValue: value}, resp)
+		return resp
+	}
+
+	assert.False(t, validate(types.StringValue("0 9 * * 1")).Diagnostics.HasError())
+	assert.False(t, validate(types.StringUnknown()).Diagnostics.HasError())
+
+	resp := validate(types.StringValue("0 9 * *"))
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Invalid Crontab", resp.Diagnostics.Errors()[0].Summary())
+}
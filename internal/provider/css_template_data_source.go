@@ -20,7 +20,7 @@ func NewCSSTemplateDataSource() datasource.DataSource {
 }
 
 type cssTemplateDataSource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 type cssTemplateDataSourceModel struct {
@@ -69,7 +69,7 @@ func (d *cssTemplateDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 	name := data.Name.ValueString()
 
-	tmpl, err := d.client.FindCSSTemplateByName(name)
+	tmpl, err := d.client.FindCSSTemplateByName(ctx, name)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Superset CSS Template",
@@ -91,11 +91,11 @@ func (d *cssTemplateDataSource) Configure(_ context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
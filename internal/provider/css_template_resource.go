@@ -31,7 +31,7 @@ func NewCSSTemplateResource() resource.Resource {
 
 // cssTemplateResource is the resource implementation.
 type cssTemplateResource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 // cssTemplateResourceModel maps the resource schema data.
@@ -172,7 +172,7 @@ func (r *cssTemplateResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// Check for existing templates with the same name
-	existing, err := r.client.FindCSSTemplatesByName(plan.TemplateName.ValueString())
+	existing, err := r.client.FindCSSTemplatesByName(ctx, plan.TemplateName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Check CSS Template Uniqueness",
@@ -188,7 +188,7 @@ func (r *cssTemplateResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	tmpl, err := r.client.CreateCSSTemplate(plan.TemplateName.ValueString(), plan.CSS.ValueString())
+	tmpl, err := r.client.CreateCSSTemplate(ctx, plan.TemplateName.ValueString(), plan.CSS.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Superset CSS Template",
@@ -227,9 +227,9 @@ func (r *cssTemplateResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	tmpl, err := r.client.GetCSSTemplate(id)
+	tmpl, err := r.client.GetCSSTemplate(ctx, id)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if client.IsNotFound(err) {
 			tflog.Info(ctx, fmt.Sprintf("CSS template ID %d not found, removing from state", id))
 			resp.State.RemoveResource(ctx)
 			return
@@ -278,9 +278,9 @@ func (r *cssTemplateResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	tmpl, err := r.client.UpdateCSSTemplate(id, plan.TemplateName.ValueString(), plan.CSS.ValueString())
+	tmpl, err := r.client.UpdateCSSTemplate(ctx, id, plan.TemplateName.ValueString(), plan.CSS.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if client.IsNotFound(err) {
 			tflog.Info(ctx, fmt.Sprintf("CSS template ID %d not found during update, removing from state", id))
 			resp.State.RemoveResource(ctx)
 			resp.Diagnostics.AddError(
@@ -327,7 +327,7 @@ func (r *cssTemplateResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	err = r.client.DeleteCSSTemplate(id)
+	err = r.client.DeleteCSSTemplate(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Delete Superset CSS Template",
@@ -369,11 +369,11 @@ func (r *cssTemplateResource) Configure(_ context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
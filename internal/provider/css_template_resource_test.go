@@ -1,10 +1,14 @@
 package provider
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/jarcoal/httpmock"
+
+	"terraform-provider-superset/internal/testing/fakesuperset"
 )
 
 func TestAccCSSTemplateResource(t *testing.T) {
@@ -62,3 +66,46 @@ resource "superset_css_template" "test" {
 		},
 	})
 }
+
+func TestAccCSSTemplateResource_FakeSuperset(t *testing.T) {
+	fake := fakesuperset.New(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			templates, err := fakeClient(t, fake).FindCSSTemplatesByName(t.Context(), "Test Template")
+			if err != nil {
+				return err
+			}
+			if len(templates) > 0 {
+				return fmt.Errorf("CSS templates left after destroy: %v", templates)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig(fake) + `
+resource "superset_css_template" "test" {
+  template_name = "Test Template"
+  css           = "body { color: red; }"
+}
+`,
+				Check: resource.TestCheckResourceAttr("superset_css_template.test", "css", "body { color: red; }"),
+			},
+			{
+				Config: fakeProviderConfig(fake) + `
+resource "superset_css_template" "test" {
+  template_name = "Test Template"
+  css           = "body { color: blue; }"
+}
+`,
+				Check: resource.TestCheckResourceAttr("superset_css_template.test", "css", "body { color: blue; }"),
+			},
+			{
+				ResourceName:      "superset_css_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -18,6 +18,7 @@ var (
 	_ resource.Resource                = &dashboardEmbeddingResource{}
 	_ resource.ResourceWithConfigure   = &dashboardEmbeddingResource{}
 	_ resource.ResourceWithImportState = &dashboardEmbeddingResource{}
+	_ resource.ResourceWithModifyPlan  = &dashboardEmbeddingResource{}
 )
 
 func NewDashboardEmbeddingResource() resource.Resource {
@@ -25,7 +26,7 @@ func NewDashboardEmbeddingResource() resource.Resource {
 }
 
 type dashboardEmbeddingResource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 type dashboardEmbeddingResourceModel struct {
@@ -74,10 +75,10 @@ func (r *dashboardEmbeddingResource) Configure(_ context.Context, req resource.C
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	c, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T.", req.ProviderData))
 		return
 	}
 	r.client = c
@@ -96,7 +97,7 @@ func (r *dashboardEmbeddingResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
-	embedded, err := r.client.CreateDashboardEmbedded(plan.DashboardID.ValueInt64(), domains)
+	embedded, err := r.client.CreateDashboardEmbedded(ctx, plan.DashboardID.ValueInt64(), domains)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create dashboard embedding", err.Error())
 		return
@@ -115,7 +116,7 @@ func (r *dashboardEmbeddingResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
-	embedded, err := r.client.GetDashboardEmbedded(state.DashboardID.ValueInt64())
+	embedded, err := r.client.GetDashboardEmbedded(ctx, state.DashboardID.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to read dashboard embedding", err.Error())
 		return
@@ -136,6 +137,14 @@ func (r *dashboardEmbeddingResource) Read(ctx context.Context, req resource.Read
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
+// ModifyPlan fails the plan early when the server cannot embed dashboards.
+func (r *dashboardEmbeddingResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	requireFeature(r.client, client.FeatureEmbeddedDashboards, &resp.Diagnostics)
+}
+
 func (r *dashboardEmbeddingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan dashboardEmbeddingResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -149,7 +158,7 @@ func (r *dashboardEmbeddingResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
-	embedded, err := r.client.CreateDashboardEmbedded(plan.DashboardID.ValueInt64(), domains)
+	embedded, err := r.client.CreateDashboardEmbedded(ctx, plan.DashboardID.ValueInt64(), domains)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to update dashboard embedding", err.Error())
 		return
@@ -168,7 +177,7 @@ func (r *dashboardEmbeddingResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
-	if err := r.client.DeleteDashboardEmbedded(state.DashboardID.ValueInt64()); err != nil {
+	if err := r.client.DeleteDashboardEmbedded(ctx, state.DashboardID.ValueInt64()); err != nil {
 		resp.Diagnostics.AddError("Failed to delete dashboard embedding", err.Error())
 	}
 }
@@ -180,7 +189,7 @@ func (r *dashboardEmbeddingResource) ImportState(ctx context.Context, req resour
 		return
 	}
 
-	embedded, err := r.client.GetDashboardEmbedded(dashboardID)
+	embedded, err := r.client.GetDashboardEmbedded(ctx, dashboardID)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to import dashboard embedding", err.Error())
 		return
@@ -2,11 +2,11 @@ package provider
 
 import (
 	"archive/zip"
-	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -41,7 +41,7 @@ func NewDashboardImportResource() resource.Resource {
 }
 
 type dashboardImportResource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 type dashboardImportResourceModel struct {
@@ -137,10 +137,10 @@ func (r *dashboardImportResource) Configure(_ context.Context, req resource.Conf
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	c, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T.", req.ProviderData))
 		return
 	}
 	r.client = c
@@ -228,7 +228,7 @@ func (r *dashboardImportResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	if !state.DashboardID.IsNull() && !state.DashboardID.IsUnknown() {
-		exists, err := r.client.DashboardExistsByID(state.DashboardID.ValueInt64())
+		exists, err := r.client.DashboardExistsByID(ctx, state.DashboardID.ValueInt64())
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to check dashboard existence", err.Error())
 			return
@@ -274,7 +274,7 @@ func (r *dashboardImportResource) Delete(ctx context.Context, req resource.Delet
 	}
 
 	if !state.DashboardID.IsNull() && !state.DashboardID.IsUnknown() {
-		if err := r.client.DeleteDashboard(state.DashboardID.ValueInt64()); err != nil {
+		if err := r.client.DeleteDashboard(ctx, state.DashboardID.ValueInt64()); err != nil {
 			resp.Diagnostics.AddError("Failed to delete dashboard", err.Error())
 			return
 		}
@@ -314,9 +314,12 @@ func (r *dashboardImportResource) importDashboard(ctx context.Context, plan *das
 	}
 	plan.FileHashes = toStringMap(fileHashes)
 
-	zipData, err := zipDirectoryWithOverrides(sourceDir, overrides, skipPatterns, cssOverride)
-	if err != nil {
-		return fmt.Errorf("creating ZIP: %w", err)
+	// The ZIP is written while it is uploaded, so nothing is buffered in memory.
+	bundle := func(w io.Writer) error {
+		if err := zipDirectoryWithOverrides(w, sourceDir, overrides, skipPatterns, cssOverride); err != nil {
+			return fmt.Errorf("creating ZIP: %w", err)
+		}
+		return nil
 	}
 
 	secrets := make(map[string]string)
@@ -342,11 +345,11 @@ func (r *dashboardImportResource) importDashboard(ctx context.Context, plan *das
 	// If dashboard already exists, unlink all charts and clear layout before importing
 	existingID := plan.DashboardID.ValueInt64()
 	if existingID == 0 {
-		existingID, _ = r.client.GetDashboardIDByUUID(meta.UUID)
+		existingID, _ = r.client.GetDashboardIDByUUID(ctx, meta.UUID)
 	}
 	if existingID > 0 {
 		// Get all chart IDs on the dashboard
-		chartUUIDMap, err := r.client.GetDashboardChartUUIDs(existingID)
+		chartUUIDMap, err := r.client.GetDashboardChartUUIDs(ctx, existingID)
 		if err != nil {
 			tflog.Warn(ctx, fmt.Sprintf("Failed to get dashboard chart UUIDs: %s", err))
 		} else {
@@ -356,29 +359,33 @@ func (r *dashboardImportResource) importDashboard(ctx context.Context, plan *das
 			}
 			// Unlink all charts from dashboard
 			if len(allChartIDs) > 0 {
-				if err := r.client.UnlinkChartsFromDashboard(allChartIDs, existingID); err != nil {
+				if err := r.client.UnlinkChartsFromDashboard(ctx, allChartIDs, existingID); err != nil {
 					tflog.Warn(ctx, fmt.Sprintf("Failed to unlink charts: %s", err))
 				}
 			}
 		}
 		// Clear position_json and json_metadata
-		if err := r.client.ClearDashboardLayout(existingID); err != nil {
+		if err := r.client.ClearDashboardLayout(ctx, existingID); err != nil {
 			tflog.Warn(ctx, fmt.Sprintf("Failed to clear dashboard layout: %s", err))
 		}
 		tflog.Info(ctx, fmt.Sprintf("Cleared all charts and layout from dashboard %d", existingID))
 	}
 
 	// Import dashboard
-	if err := r.client.ImportDashboard(zipData, overwrite, passwords); err != nil {
+	if err := r.client.ImportDashboard(ctx, bundle, overwrite, passwords); err != nil {
 		return err
 	}
 
 	var dashID int64
 	for attempt := 0; attempt < 5; attempt++ {
 		if attempt > 0 {
-			time.Sleep(2 * time.Second)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(2 * time.Second):
+			}
 		}
-		dashID, err = r.client.GetDashboardIDByUUID(meta.UUID)
+		dashID, err = r.client.GetDashboardIDByUUID(ctx, meta.UUID)
 		if err == nil {
 			break
 		}
@@ -396,7 +403,7 @@ func (r *dashboardImportResource) importDashboard(ctx context.Context, plan *das
 		if diags.HasError() {
 			return fmt.Errorf("reading roles")
 		}
-		if err := r.client.SetDashboardRoles(dashID, roleIDs); err != nil {
+		if err := r.client.SetDashboardRoles(ctx, dashID, roleIDs); err != nil {
 			return fmt.Errorf("setting dashboard roles: %w", err)
 		}
 	}
@@ -618,12 +625,11 @@ func computeFileHashesWithOverrides(dir string, overrides map[string]map[string]
 	return hashes, err
 }
 
-// zipDirectoryWithOverrides creates a ZIP of sourceDir, applying database overrides to databases/*.yaml.
+// zipDirectoryWithOverrides writes a ZIP of sourceDir to out, applying database overrides to databases/*.yaml.
 // If cssOverride is non-empty, it replaces the css field in dashboards/*.yaml files.
 // Files matching skipPatterns are excluded.
-func zipDirectoryWithOverrides(sourceDir string, overrides map[string]map[string]interface{}, skipPatterns []*regexp.Regexp, cssOverride string) ([]byte, error) {
-	var buf bytes.Buffer
-	w := zip.NewWriter(&buf)
+func zipDirectoryWithOverrides(out io.Writer, sourceDir string, overrides map[string]map[string]interface{}, skipPatterns []*regexp.Regexp, cssOverride string) error {
+	w := zip.NewWriter(out)
 	base := filepath.Base(sourceDir)
 	err := filepath.WalkDir(sourceDir, func(p string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -661,12 +667,9 @@ func zipDirectoryWithOverrides(sourceDir string, overrides map[string]map[string
 		return err
 	})
 	if err != nil {
-		return nil, err
-	}
-	if err := w.Close(); err != nil {
-		return nil, err
+		return err
 	}
-	return buf.Bytes(), nil
+	return w.Close()
 }
 
 // applyCSSOverride replaces the css field in a dashboard YAML file with the override value.
@@ -0,0 +1,437 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// dashboardGridColumns is the number of columns of a dashboard row; the widths of the items in
+// a row add up to it.
+const dashboardGridColumns = 12
+
+// defaultLayoutItemHeight is the height Superset gives a chart or markdown item dropped on a
+// dashboard, in grid units of 8px.
+const defaultLayoutItemHeight = 50
+
+// dashboardLayoutModel maps the layout attribute of superset_dashboard: either rows placed
+// directly on the dashboard grid, or tabs holding rows.
+type dashboardLayoutModel struct {
+	Rows types.List `tfsdk:"rows"`
+	Tabs types.List `tfsdk:"tabs"`
+}
+
+type dashboardLayoutTabModel struct {
+	Title types.String `tfsdk:"title"`
+	Rows  types.List   `tfsdk:"rows"`
+}
+
+// dashboardLayoutRowModel is a row of items, or a divider line when Divider is true.
+type dashboardLayoutRowModel struct {
+	Divider types.Bool `tfsdk:"divider"`
+	Items   types.List `tfsdk:"items"`
+}
+
+// dashboardLayoutItemModel is a chart, referenced by ID or UUID, or a markdown block.
+type dashboardLayoutItemModel struct {
+	ChartID   types.Int64  `tfsdk:"chart_id"`
+	ChartUUID types.String `tfsdk:"chart_uuid"`
+	Markdown  types.String `tfsdk:"markdown"`
+	Width     types.Int64  `tfsdk:"width"`
+	Height    types.Int64  `tfsdk:"height"`
+}
+
+var (
+	dashboardLayoutItemType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"chart_id":   types.Int64Type,
+		"chart_uuid": types.StringType,
+		"markdown":   types.StringType,
+		"width":      types.Int64Type,
+		"height":     types.Int64Type,
+	}}
+	dashboardLayoutRowType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"divider": types.BoolType,
+		"items":   types.ListType{ElemType: dashboardLayoutItemType},
+	}}
+	dashboardLayoutTabType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"title": types.StringType,
+		"rows":  types.ListType{ElemType: dashboardLayoutRowType},
+	}}
+	dashboardLayoutType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"rows": types.ListType{ElemType: dashboardLayoutRowType},
+		"tabs": types.ListType{ElemType: dashboardLayoutTabType},
+	}}
+)
+
+// dashboardLayoutAttribute returns the schema of the layout attribute of superset_dashboard.
+func dashboardLayoutAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Layout of the dashboard, from which position_json and the positions key of json_metadata are generated. " +
+			"Set either rows or tabs. The charts in the layout are linked to the dashboard and charts left out of it are unlinked. " +
+			"Conflicts with position_json.",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"rows": dashboardLayoutRowsAttribute("Rows placed directly on the dashboard, from top to bottom."),
+			"tabs": schema.ListNestedAttribute{
+				Description: "Tabs of the dashboard, from left to right.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"title": schema.StringAttribute{
+							Description: "Title of the tab.",
+							Required:    true,
+						},
+						"rows": dashboardLayoutRowsAttribute("Rows of the tab, from top to bottom."),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dashboardLayoutRowsAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: description,
+		Optional:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"divider": schema.BoolAttribute{
+					Description: "Whether the row is a divider line instead of a row of items.",
+					Optional:    true,
+				},
+				"items": schema.ListNestedAttribute{
+					Description: "Items of the row, from left to right. Their widths must add up to 12.",
+					Optional:    true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"chart_id": schema.Int64Attribute{
+								Description: "ID of the chart shown by the item. Set exactly one of chart_id, chart_uuid and markdown.",
+								Optional:    true,
+							},
+							"chart_uuid": schema.StringAttribute{
+								Description: "UUID of the chart shown by the item.",
+								Optional:    true,
+							},
+							"markdown": schema.StringAttribute{
+								Description: "Markdown text shown by the item.",
+								Optional:    true,
+							},
+							"width": schema.Int64Attribute{
+								Description: "Width of the item in columns, from 1 to 12.",
+								Required:    true,
+							},
+							"height": schema.Int64Attribute{
+								Description: "Height of the item in grid units of 8px. Defaults to 50.",
+								Optional:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// layoutElements decodes the elements of a layout list. A null or unknown list has none.
+func layoutElements[T any](ctx context.Context, list types.List) ([]T, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+	var elements []T
+	diags := list.ElementsAs(ctx, &elements, false)
+	return elements, diags
+}
+
+// validateDashboardLayout checks a configured layout for mistakes Superset would accept but
+// render broken, such as a row wider than the dashboard. Unknown values are not checked.
+func validateDashboardLayout(ctx context.Context, layout types.Object) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if layout.IsNull() || layout.IsUnknown() {
+		return diags
+	}
+
+	var model dashboardLayoutModel
+	diags.Append(layout.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return diags
+	}
+
+	root := path.Root("layout")
+	if !model.Rows.IsNull() && !model.Tabs.IsNull() {
+		diags.AddAttributeError(root, "Invalid Dashboard Layout", "Set either rows or tabs, not both. Rows of a dashboard with tabs go into its tabs.")
+		return diags
+	}
+	if model.Rows.IsNull() && model.Tabs.IsNull() {
+		diags.AddAttributeError(root, "Invalid Dashboard Layout", "Set rows or tabs.")
+		return diags
+	}
+
+	tabs, d := layoutElements[dashboardLayoutTabModel](ctx, model.Tabs)
+	diags.Append(d...)
+	for i, tab := range tabs {
+		diags.Append(validateLayoutRows(ctx, root.AtName("tabs").AtListIndex(i).AtName("rows"), tab.Rows)...)
+	}
+	diags.Append(validateLayoutRows(ctx, root.AtName("rows"), model.Rows)...)
+	return diags
+}
+
+func validateLayoutRows(ctx context.Context, rowsPath path.Path, list types.List) diag.Diagnostics {
+	rows, diags := layoutElements[dashboardLayoutRowModel](ctx, list)
+	for i, row := range rows {
+		rowPath := rowsPath.AtListIndex(i)
+		items, d := layoutElements[dashboardLayoutItemModel](ctx, row.Items)
+		diags.Append(d...)
+
+		if row.Divider.ValueBool() {
+			if len(items) > 0 {
+				diags.AddAttributeError(rowPath, "Invalid Dashboard Layout", "A divider has no items.")
+			}
+			continue
+		}
+		if len(items) == 0 && !row.Items.IsUnknown() && !row.Divider.IsUnknown() {
+			diags.AddAttributeError(rowPath, "Invalid Dashboard Layout", "A row needs at least one item; set divider = true for a divider line.")
+			continue
+		}
+
+		var total int64
+		widthsKnown := !row.Items.IsUnknown()
+		for j, item := range items {
+			itemPath := rowPath.AtName("items").AtListIndex(j)
+			diags.Append(validateLayoutItem(itemPath, item)...)
+			if item.Width.IsUnknown() {
+				widthsKnown = false
+			}
+			total += item.Width.ValueInt64()
+		}
+		if widthsKnown && total != dashboardGridColumns {
+			diags.AddAttributeError(
+				rowPath,
+				"Invalid Dashboard Layout",
+				fmt.Sprintf("The widths of the items in a row must add up to %d, got %d.", dashboardGridColumns, total),
+			)
+		}
+	}
+	return diags
+}
+
+func validateLayoutItem(itemPath path.Path, item dashboardLayoutItemModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	set := 0
+	for _, v := range []attr.Value{item.ChartID, item.ChartUUID, item.Markdown} {
+		if !v.IsNull() {
+			set++
+		}
+	}
+	if set != 1 {
+		diags.AddAttributeError(itemPath, "Invalid Dashboard Layout", "Set exactly one of chart_id, chart_uuid and markdown.")
+	}
+
+	if !item.ChartUUID.IsNull() && !item.ChartUUID.IsUnknown() && !uuidPattern.MatchString(item.ChartUUID.ValueString()) {
+		diags.AddAttributeError(itemPath.AtName("chart_uuid"), "Invalid Dashboard Layout", fmt.Sprintf("%q is not a UUID.", item.ChartUUID.ValueString()))
+	}
+	if !item.Width.IsUnknown() && (item.Width.ValueInt64() < 1 || item.Width.ValueInt64() > dashboardGridColumns) {
+		diags.AddAttributeError(itemPath.AtName("width"), "Invalid Dashboard Layout", fmt.Sprintf("Width must be between 1 and %d, got %d.", dashboardGridColumns, item.Width.ValueInt64()))
+	}
+	if !item.Height.IsNull() && !item.Height.IsUnknown() && item.Height.ValueInt64() < 1 {
+		diags.AddAttributeError(itemPath.AtName("height"), "Invalid Dashboard Layout", fmt.Sprintf("Height must be at least 1, got %d.", item.Height.ValueInt64()))
+	}
+	return diags
+}
+
+// layoutChartRefs returns the chart IDs and UUIDs a fully known layout references.
+func layoutChartRefs(ctx context.Context, layout dashboardLayoutModel) ([]int64, []string, diag.Diagnostics) {
+	var ids []int64
+	var uuids []string
+	diags := walkLayoutItems(ctx, layout, func(item dashboardLayoutItemModel) {
+		switch {
+		case !item.ChartID.IsNull():
+			ids = append(ids, item.ChartID.ValueInt64())
+		case !item.ChartUUID.IsNull():
+			uuids = append(uuids, strings.ToLower(item.ChartUUID.ValueString()))
+		}
+	})
+	return ids, uuids, diags
+}
+
+func walkLayoutItems(ctx context.Context, layout dashboardLayoutModel, visit func(dashboardLayoutItemModel)) diag.Diagnostics {
+	rowLists := []types.List{layout.Rows}
+	tabs, diags := layoutElements[dashboardLayoutTabModel](ctx, layout.Tabs)
+	for _, tab := range tabs {
+		rowLists = append(rowLists, tab.Rows)
+	}
+	for _, list := range rowLists {
+		rows, d := layoutElements[dashboardLayoutRowModel](ctx, list)
+		diags.Append(d...)
+		for _, row := range rows {
+			items, d := layoutElements[dashboardLayoutItemModel](ctx, row.Items)
+			diags.Append(d...)
+			for _, item := range items {
+				visit(item)
+			}
+		}
+	}
+	return diags
+}
+
+// layoutBuilder generates a Superset position_json document, the v2 dashboard layout format:
+// a flat map of components keyed by ID, each listing its children and its ancestors. Component
+// IDs are numbered in layout order, so the same layout always generates the same document.
+type layoutBuilder struct {
+	position map[string]any
+	counts   map[string]int
+	// chartUUIDs and chartIDs resolve chart references in both directions.
+	chartUUIDs map[int64]string
+	chartIDs   map[string]int64
+	diags      diag.Diagnostics
+}
+
+// buildDashboardPosition generates the position_json document of a fully known layout. charts
+// maps the UUID of every chart the layout references to its ID.
+func buildDashboardPosition(ctx context.Context, title string, layout dashboardLayoutModel, charts map[string]int64) (map[string]any, diag.Diagnostics) {
+	b := &layoutBuilder{
+		position: map[string]any{
+			"DASHBOARD_VERSION_KEY": "v2",
+			"HEADER_ID": map[string]any{
+				"id":   "HEADER_ID",
+				"type": "HEADER",
+				"meta": map[string]any{"text": title},
+			},
+		},
+		counts:     map[string]int{},
+		chartUUIDs: make(map[int64]string, len(charts)),
+		chartIDs:   charts,
+	}
+	for uuid, id := range charts {
+		b.chartUUIDs[id] = uuid
+	}
+
+	rootChildren := []string{}
+	if !layout.Tabs.IsNull() {
+		tabsID := b.add("TABS", []string{"ROOT_ID"}, map[string]any{})
+		rootChildren = append(rootChildren, tabsID)
+		tabs, d := layoutElements[dashboardLayoutTabModel](ctx, layout.Tabs)
+		b.diags.Append(d...)
+		for _, tab := range tabs {
+			tabID := b.add("TAB", []string{"ROOT_ID", tabsID}, map[string]any{"text": tab.Title.ValueString()})
+			b.child(tabsID, tabID)
+			b.addRows(ctx, tab.Rows, tabID, []string{"ROOT_ID", tabsID, tabID})
+		}
+	} else {
+		rootChildren = append(rootChildren, "GRID_ID")
+		b.position["GRID_ID"] = map[string]any{
+			"id":       "GRID_ID",
+			"type":     "GRID",
+			"children": []string{},
+			"parents":  []string{"ROOT_ID"},
+		}
+		b.addRows(ctx, layout.Rows, "GRID_ID", []string{"ROOT_ID", "GRID_ID"})
+	}
+	b.position["ROOT_ID"] = map[string]any{
+		"id":       "ROOT_ID",
+		"type":     "ROOT",
+		"children": rootChildren,
+	}
+
+	return b.position, b.diags
+}
+
+func (b *layoutBuilder) addRows(ctx context.Context, list types.List, parentID string, parents []string) {
+	rows, d := layoutElements[dashboardLayoutRowModel](ctx, list)
+	b.diags.Append(d...)
+	for _, row := range rows {
+		if row.Divider.ValueBool() {
+			b.child(parentID, b.add("DIVIDER", parents, map[string]any{}))
+			continue
+		}
+
+		rowID := b.add("ROW", parents, map[string]any{"background": "BACKGROUND_TRANSPARENT"})
+		b.child(parentID, rowID)
+		itemParents := append(slices.Clone(parents), rowID)
+
+		items, d := layoutElements[dashboardLayoutItemModel](ctx, row.Items)
+		b.diags.Append(d...)
+		for _, item := range items {
+			height := int64(defaultLayoutItemHeight)
+			if !item.Height.IsNull() {
+				height = item.Height.ValueInt64()
+			}
+			meta := map[string]any{"width": item.Width.ValueInt64(), "height": height}
+
+			kind := "CHART"
+			switch {
+			case !item.Markdown.IsNull():
+				kind = "MARKDOWN"
+				meta["code"] = item.Markdown.ValueString()
+			case !item.ChartID.IsNull():
+				id := item.ChartID.ValueInt64()
+				uuid, ok := b.chartUUIDs[id]
+				if !ok {
+					b.diags.AddError("Invalid Dashboard Layout", fmt.Sprintf("The layout references chart ID %d, which does not exist.", id))
+				}
+				meta["chartId"] = id
+				meta["uuid"] = uuid
+			default:
+				uuid := strings.ToLower(item.ChartUUID.ValueString())
+				id, ok := b.chartIDs[uuid]
+				if !ok {
+					b.diags.AddError("Invalid Dashboard Layout", fmt.Sprintf("The layout references chart UUID %s, which does not exist.", uuid))
+				}
+				meta["chartId"] = id
+				meta["uuid"] = uuid
+			}
+			b.child(rowID, b.add(kind, itemParents, meta))
+		}
+	}
+}
+
+// add adds a component without children and returns its ID.
+func (b *layoutBuilder) add(kind string, parents []string, meta map[string]any) string {
+	b.counts[kind]++
+	id := fmt.Sprintf("%s-%d", kind, b.counts[kind])
+	b.position[id] = map[string]any{
+		"id":       id,
+		"type":     kind,
+		"children": []string{},
+		"parents":  slices.Clone(parents),
+		"meta":     meta,
+	}
+	return id
+}
+
+// child appends childID to the children of the component parentID.
+func (b *layoutBuilder) child(parentID, childID string) {
+	component, ok := b.position[parentID].(map[string]any)
+	if !ok {
+		return
+	}
+	children, _ := component["children"].([]string)
+	component["children"] = append(children, childID)
+}
+
+// withPositions returns the json_metadata document metadata with its positions key set to
+// position. Superset keeps json_metadata.positions in step with position_json and links the
+// charts listed in it to the dashboard.
+func withPositions(metadata string, position map[string]any) (string, error) {
+	document := map[string]any{}
+	if metadata != "" {
+		if err := json.Unmarshal([]byte(metadata), &document); err != nil {
+			return "", fmt.Errorf("json_metadata is not a JSON object: %w", err)
+		}
+	}
+	document["positions"] = position
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func chartItem(id, width int64) dashboardLayoutItemModel {
+	return dashboardLayoutItemModel{
+		ChartID:   types.Int64Value(id),
+		ChartUUID: types.StringNull(),
+		Markdown:  types.StringNull(),
+		Width:     types.Int64Value(width),
+		Height:    types.Int64Null(),
+	}
+}
+
+func chartUUIDItem(uuid string, width int64) dashboardLayoutItemModel {
+	item := chartItem(0, width)
+	item.ChartID = types.Int64Null()
+	item.ChartUUID = types.StringValue(uuid)
+	return item
+}
+
+func markdownItem(code string, width, height int64) dashboardLayoutItemModel {
+	item := chartItem(0, width)
+	item.ChartID = types.Int64Null()
+	item.Markdown = types.StringValue(code)
+	item.Height = types.Int64Value(height)
+	return item
+}
+
+func layoutRow(t *testing.T, items ...dashboardLayoutItemModel) attr.Value {
+	t.Helper()
+	list, diags := types.ListValueFrom(t.Context(), dashboardLayoutItemType, items)
+	require.False(t, diags.HasError(), "%v", diags)
+	row, diags := types.ObjectValueFrom(t.Context(), dashboardLayoutRowType.AttrTypes, dashboardLayoutRowModel{Divider: types.BoolNull(), Items: list})
+	require.False(t, diags.HasError(), "%v", diags)
+	return row
+}
+
+func layoutDivider(t *testing.T) attr.Value {
+	t.Helper()
+	row, diags := types.ObjectValueFrom(t.Context(), dashboardLayoutRowType.AttrTypes, dashboardLayoutRowModel{
+		Divider: types.BoolValue(true),
+		Items:   types.ListNull(dashboardLayoutItemType),
+	})
+	require.False(t, diags.HasError(), "%v", diags)
+	return row
+}
+
+func layoutTab(t *testing.T, title string, rows ...attr.Value) attr.Value {
+	t.Helper()
+	tab, diags := types.ObjectValueFrom(t.Context(), dashboardLayoutTabType.AttrTypes, dashboardLayoutTabModel{
+		Title: types.StringValue(title),
+		Rows:  types.ListValueMust(dashboardLayoutRowType, rows),
+	})
+	require.False(t, diags.HasError(), "%v", diags)
+	return tab
+}
+
+// gridLayout returns a layout of rows placed directly on the dashboard.
+func gridLayout(t *testing.T, rows ...attr.Value) types.Object {
+	t.Helper()
+	return types.ObjectValueMust(dashboardLayoutType.AttrTypes, map[string]attr.Value{
+		"rows": types.ListValueMust(dashboardLayoutRowType, rows),
+		"tabs": types.ListNull(dashboardLayoutTabType),
+	})
+}
+
+// tabsLayout returns a layout of tabs.
+func tabsLayout(t *testing.T, tabs ...attr.Value) types.Object {
+	t.Helper()
+	return types.ObjectValueMust(dashboardLayoutType.AttrTypes, map[string]attr.Value{
+		"rows": types.ListNull(dashboardLayoutRowType),
+		"tabs": types.ListValueMust(dashboardLayoutTabType, tabs),
+	})
+}
+
+func TestValidateDashboardLayout(t *testing.T) {
+	const uuid = "6f2a1c9e-3b7d-4e8f-a1b2-c3d4e5f60718"
+	tests := []struct {
+		name    string
+		layout  types.Object
+		wantErr string
+	}{
+		{
+			name:   "valid grid",
+			layout: gridLayout(t, layoutRow(t, chartItem(1, 8), markdownItem("# Notes", 4, 30)), layoutDivider(t), layoutRow(t, chartUUIDItem(uuid, 12))),
+		},
+		{
+			name:   "valid tabs",
+			layout: tabsLayout(t, layoutTab(t, "Overview", layoutRow(t, chartItem(1, 12))), layoutTab(t, "Details", layoutRow(t, chartItem(2, 6), chartItem(3, 6)))),
+		},
+		{
+			name:    "row narrower than the dashboard",
+			layout:  gridLayout(t, layoutRow(t, chartItem(1, 6), chartItem(2, 4))),
+			wantErr: "must add up to 12, got 10",
+		},
+		{
+			name:    "row wider than the dashboard",
+			layout:  tabsLayout(t, layoutTab(t, "Overview", layoutRow(t, chartItem(1, 8), chartItem(2, 8)))),
+			wantErr: "must add up to 12, got 16",
+		},
+		{
+			name:    "width out of range",
+			layout:  gridLayout(t, layoutRow(t, chartItem(1, 13), chartItem(2, -1))),
+			wantErr: "Width must be between 1 and 12",
+		},
+		{
+			name: "two references in one item",
+			layout: func() types.Object {
+				item := chartItem(1, 12)
+				item.Markdown = types.StringValue("# Notes")
+				return gridLayout(t, layoutRow(t, item))
+			}(),
+			wantErr: "exactly one of chart_id, chart_uuid and markdown",
+		},
+		{
+			name:    "malformed chart UUID",
+			layout:  gridLayout(t, layoutRow(t, chartUUIDItem("not-a-uuid", 12))),
+			wantErr: `"not-a-uuid" is not a UUID`,
+		},
+		{
+			name:    "empty row",
+			layout:  gridLayout(t, layoutRow(t)),
+			wantErr: "A row needs at least one item",
+		},
+		{
+			name: "rows and tabs",
+			layout: types.ObjectValueMust(dashboardLayoutType.AttrTypes, map[string]attr.Value{
+				"rows": types.ListValueMust(dashboardLayoutRowType, []attr.Value{layoutRow(t, chartItem(1, 12))}),
+				"tabs": types.ListValueMust(dashboardLayoutTabType, []attr.Value{layoutTab(t, "Overview", layoutRow(t, chartItem(2, 12)))}),
+			}),
+			wantErr: "Set either rows or tabs, not both",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateDashboardLayout(t.Context(), tt.layout)
+			if tt.wantErr == "" {
+				assert.False(t, diags.HasError(), "%v", diags)
+				return
+			}
+			require.True(t, diags.HasError())
+			assert.Contains(t, diags.Errors()[0].Detail(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateDashboardLayout_SkipsUnknownWidths(t *testing.T) {
+	item := chartItem(1, 0)
+	item.Width = types.Int64Unknown()
+	diags := validateDashboardLayout(t.Context(), gridLayout(t, layoutRow(t, item, chartItem(2, 6))))
+	assert.False(t, diags.HasError(), "%v", diags)
+}
+
+func TestBuildDashboardPosition_Grid(t *testing.T) {
+	const uuid1 = "6f2a1c9e-3b7d-4e8f-a1b2-c3d4e5f60718"
+	const uuid2 = "0c9b8a7f-6e5d-4c3b-2a19-0f8e7d6c5b4a"
+	layout := gridLayout(t,
+		layoutRow(t, chartItem(1, 8), markdownItem("# Notes", 4, 30)),
+		layoutDivider(t),
+		layoutRow(t, chartUUIDItem("0C9B8A7F-6E5D-4C3B-2A19-0F8E7D6C5B4A", 12)),
+	)
+	var model dashboardLayoutModel
+	require.False(t, layout.As(t.Context(), &model, basetypes.ObjectAsOptions{}).HasError())
+
+	position, diags := buildDashboardPosition(t.Context(), "Sales", model, map[string]int64{uuid1: 1, uuid2: 2})
+	require.False(t, diags.HasError(), "%v", diags)
+
+	encoded, err := json.Marshal(position)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"DASHBOARD_VERSION_KEY": "v2",
+		"HEADER_ID": {"id": "HEADER_ID", "type": "HEADER", "meta": {"text": "Sales"}},
+		"ROOT_ID": {"id": "ROOT_ID", "type": "ROOT", "children": ["GRID_ID"]},
+		"GRID_ID": {"id": "GRID_ID", "type": "GRID", "children": ["ROW-1", "DIVIDER-1", "ROW-2"], "parents": ["ROOT_ID"]},
+		"ROW-1": {"id": "ROW-1", "type": "ROW", "children": ["CHART-1", "MARKDOWN-1"], "parents": ["ROOT_ID", "GRID_ID"], "meta": {"background": "BACKGROUND_TRANSPARENT"}},
+		"CHART-1": {"id": "CHART-1", "type": "CHART", "children": [], "parents": ["ROOT_ID", "GRID_ID", "ROW-1"], "meta": {"chartId": 1, "uuid": "`+uuid1+`", "width": 8, "height": 50}},
+		"MARKDOWN-1": {"id": "MARKDOWN-1", "type": "MARKDOWN", "children": [], "parents": ["ROOT_ID", "GRID_ID", "ROW-1"], "meta": {"code": "# Notes", "width": 4, "height": 30}},
+		"DIVIDER-1": {"id": "DIVIDER-1", "type": "DIVIDER", "children": [], "parents": ["ROOT_ID", "GRID_ID"], "meta": {}},
+		"ROW-2": {"id": "ROW-2", "type": "ROW", "children": ["CHART-2"], "parents": ["ROOT_ID", "GRID_ID"], "meta": {"background": "BACKGROUND_TRANSPARENT"}},
+		"CHART-2": {"id": "CHART-2", "type": "CHART", "children": [], "parents": ["ROOT_ID", "GRID_ID", "ROW-2"], "meta": {"chartId": 2, "uuid": "`+uuid2+`", "width": 12, "height": 50}}
+	}`, string(encoded))
+}
+
+func TestBuildDashboardPosition_Tabs(t *testing.T) {
+	layout := tabsLayout(t,
+		layoutTab(t, "Overview", layoutRow(t, chartItem(1, 12))),
+		layoutTab(t, "Notes", layoutRow(t, markdownItem("# Notes", 12, 50))),
+	)
+	var model dashboardLayoutModel
+	require.False(t, layout.As(t.Context(), &model, basetypes.ObjectAsOptions{}).HasError())
+
+	position, diags := buildDashboardPosition(t.Context(), "Sales", model, map[string]int64{"6f2a1c9e-3b7d-4e8f-a1b2-c3d4e5f60718": 1})
+	require.False(t, diags.HasError(), "%v", diags)
+
+	component := func(id string) map[string]any {
+		c, ok := position[id].(map[string]any)
+		require.True(t, ok, "component %s", id)
+		return c
+	}
+	assert.NotContains(t, position, "GRID_ID")
+	assert.Equal(t, []string{"TABS-1"}, component("ROOT_ID")["children"])
+	assert.Equal(t, []string{"TAB-1", "TAB-2"}, component("TABS-1")["children"])
+	assert.Equal(t, map[string]any{"text": "Notes"}, component("TAB-2")["meta"])
+	assert.Equal(t, []string{"ROOT_ID", "TABS-1", "TAB-2", "ROW-2"}, component("MARKDOWN-1")["parents"])
+}
+
+func TestBuildDashboardPosition_UnknownChart(t *testing.T) {
+	var model dashboardLayoutModel
+	require.False(t, gridLayout(t, layoutRow(t, chartItem(7, 12))).As(t.Context(), &model, basetypes.ObjectAsOptions{}).HasError())
+
+	_, diags := buildDashboardPosition(t.Context(), "Sales", model, map[string]int64{})
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags.Errors()[0].Detail(), "chart ID 7, which does not exist")
+}
+
+func TestWithPositions(t *testing.T) {
+	position := map[string]any{"DASHBOARD_VERSION_KEY": "v2"}
+
+	metadata, err := withPositions(`{"color_scheme": "bnbColors", "positions": {"stale": true}}`, position)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"color_scheme": "bnbColors", "positions": {"DASHBOARD_VERSION_KEY": "v2"}}`, metadata)
+
+	metadata, err = withPositions("", position)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"positions": {"DASHBOARD_VERSION_KEY": "v2"}}`, metadata)
+
+	_, err = withPositions(`[1, 2]`, position)
+	assert.Error(t, err)
+}
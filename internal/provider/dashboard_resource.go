@@ -0,0 +1,736 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &dashboardResource{}
+	_ resource.ResourceWithConfigure      = &dashboardResource{}
+	_ resource.ResourceWithImportState    = &dashboardResource{}
+	_ resource.ResourceWithModifyPlan     = &dashboardResource{}
+	_ resource.ResourceWithValidateConfig = &dashboardResource{}
+)
+
+// uuidPattern matches the canonical textual form of a UUID.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// NewDashboardResource is a helper function to simplify the provider implementation.
+func NewDashboardResource() resource.Resource {
+	return &dashboardResource{}
+}
+
+// dashboardResource is the resource implementation.
+type dashboardResource struct {
+	client client.SupersetAPI
+}
+
+// dashboardResourceModel maps the resource schema data.
+type dashboardResourceModel struct {
+	ID                   types.Int64    `tfsdk:"id"`
+	UUID                 types.String   `tfsdk:"uuid"`
+	URL                  types.String   `tfsdk:"url"`
+	DashboardTitle       types.String   `tfsdk:"dashboard_title"`
+	Slug                 types.String   `tfsdk:"slug"`
+	Published            types.Bool     `tfsdk:"published"`
+	Owners               types.Set      `tfsdk:"owners"`
+	Roles                types.Set      `tfsdk:"roles"`
+	CSS                  types.String   `tfsdk:"css"`
+	CertifiedBy          types.String   `tfsdk:"certified_by"`
+	CertificationDetails types.String   `tfsdk:"certification_details"`
+	JSONMetadata         normalizedJSON `tfsdk:"json_metadata"`
+	PositionJSON         normalizedJSON `tfsdk:"position_json"`
+	Layout               types.Object   `tfsdk:"layout"`
+}
+
+// Metadata returns the resource type name.
+func (r *dashboardResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard"
+}
+
+// Schema defines the schema for the resource.
+func (r *dashboardResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a dashboard in Superset through its attributes. Use superset_dashboard_import to manage dashboards from export directories instead.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the dashboard.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"uuid": schema.StringAttribute{
+				Description: "UUID of the dashboard.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				Description: "Path of the dashboard in the Superset UI, relative to the host.",
+				Computed:    true,
+			},
+			"dashboard_title": schema.StringAttribute{
+				Description: "Title of the dashboard.",
+				Required:    true,
+			},
+			"slug": schema.StringAttribute{
+				Description: "Unique slug used in the dashboard URL instead of its ID.",
+				Optional:    true,
+			},
+			"published": schema.BoolAttribute{
+				Description: "Whether the dashboard is published and listed to every user with access to it. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"owners": schema.SetAttribute{
+				Description: "IDs of the users owning the dashboard. Superset makes the creating user the owner when unset.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"roles": schema.SetAttribute{
+				Description: "IDs of the roles granted access to the dashboard when DASHBOARD_RBAC is enabled. Removing the attribute revokes every role.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
+			"css": schema.StringAttribute{
+				Description: "Custom CSS applied to the dashboard.",
+				Optional:    true,
+			},
+			"certified_by": schema.StringAttribute{
+				Description: "Person or group that certified the dashboard.",
+				Optional:    true,
+			},
+			"certification_details": schema.StringAttribute{
+				Description: "Details of the certification.",
+				Optional:    true,
+			},
+			"json_metadata": schema.StringAttribute{
+				Description: "Dashboard metadata as a JSON document, such as color_scheme, refresh_frequency or native_filter_configuration. " +
+					"Keys Superset adds when it saves the metadata are not reported as drift. Left to Superset when unset.",
+				CustomType: normalizedJSONType{},
+				Optional:   true,
+				Computed:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"position_json": schema.StringAttribute{
+				Description: "Layout of the dashboard as a JSON document. Differences in whitespace and key order are ignored. " +
+					"Generated when layout is set, and left to Superset when neither is set.",
+				CustomType: normalizedJSONType{},
+				Optional:   true,
+				Computed:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"layout": dashboardLayoutAttribute(),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *dashboardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dashboardResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dashboardReq, diags := plan.request(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	chartIDs, diags := r.applyLayout(ctx, &plan, newNormalizedJSONNull(), &dashboardReq)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating dashboard", map[string]interface{}{
+		"dashboard_title": dashboardReq.DashboardTitle,
+	})
+
+	id, err := r.client.CreateDashboard(ctx, dashboardReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating dashboard",
+			"Could not create dashboard: "+err.Error(),
+		)
+		return
+	}
+
+	// Save the ID right away so a failed follow-up call does not leak the dashboard.
+	plan.ID = types.Int64Value(id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+
+	if !plan.Roles.IsNull() {
+		resp.Diagnostics.Append(r.setRoles(ctx, id, plan.Roles)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !plan.Layout.IsNull() {
+		resp.Diagnostics.Append(r.syncLayoutCharts(ctx, id, chartIDs)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.refresh(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Created dashboard", map[string]interface{}{
+		"id":   id,
+		"uuid": plan.UUID.ValueString(),
+	})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *dashboardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dashboardResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dashboard, err := r.client.GetDashboard(ctx, state.ID.ValueInt64())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Dashboard ID %d not found, removing from state", state.ID.ValueInt64()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading dashboard",
+			"Could not read dashboard ID "+fmt.Sprintf("%d", state.ID.ValueInt64())+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &state, dashboard)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *dashboardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state dashboardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dashboardReq, diags := plan.request(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	chartIDs, diags := r.applyLayout(ctx, &plan, state.JSONMetadata, &dashboardReq)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.UpdateDashboard(ctx, plan.ID.ValueInt64(), dashboardReq); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating dashboard",
+			"Could not update dashboard ID "+fmt.Sprintf("%d", plan.ID.ValueInt64())+": "+err.Error(),
+		)
+		return
+	}
+
+	if !plan.Roles.Equal(state.Roles) {
+		resp.Diagnostics.Append(r.setRoles(ctx, plan.ID.ValueInt64(), plan.Roles)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !plan.Layout.IsNull() {
+		resp.Diagnostics.Append(r.syncLayoutCharts(ctx, plan.ID.ValueInt64(), chartIDs)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.refresh(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updated dashboard", map[string]interface{}{
+		"id": plan.ID.ValueInt64(),
+	})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *dashboardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dashboardResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteDashboard(ctx, state.ID.ValueInt64())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting dashboard",
+			"Could not delete dashboard, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted dashboard", map[string]interface{}{
+		"id": state.ID.ValueInt64(),
+	})
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *dashboardResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.SupersetAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks the layout at plan time, before any chart is looked up.
+func (r *dashboardResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config dashboardResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Layout.IsNull() {
+		return
+	}
+
+	if !config.PositionJSON.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("position_json"),
+			"Conflicting Dashboard Layout",
+			"position_json cannot be set together with layout, which generates it.",
+		)
+	}
+	if !config.JSONMetadata.IsNull() && !config.JSONMetadata.IsUnknown() {
+		var metadata map[string]any
+		if err := json.Unmarshal([]byte(config.JSONMetadata.ValueString()), &metadata); err == nil {
+			if _, ok := metadata["positions"]; ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("json_metadata"),
+					"Conflicting Dashboard Layout",
+					"json_metadata cannot hold positions when layout is set, which generates them.",
+				)
+			}
+		}
+	}
+	resp.Diagnostics.Append(validateDashboardLayout(ctx, config.Layout)...)
+}
+
+// ModifyPlan plans the position_json generated from the layout, so that the plan shows how a
+// layout change moves things around and a dashboard rearranged in the UI shows up as drift.
+func (r *dashboardResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config dashboardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || plan.Layout.IsNull() {
+		return
+	}
+
+	// Without a configured client, such as during validation, the layout cannot be resolved.
+	position := newNormalizedJSONUnknown()
+	if r.client != nil {
+		generated, _, known, diags := r.layoutPosition(ctx, &plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if known {
+			encoded, err := json.Marshal(generated)
+			if err != nil {
+				resp.Diagnostics.AddError("Error generating dashboard layout", err.Error())
+				return
+			}
+			position = newNormalizedJSONValue(string(encoded))
+		}
+	}
+
+	if !req.State.Raw.IsNull() && !position.IsUnknown() {
+		var state dashboardResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if equal, _ := state.PositionJSON.StringSemanticEquals(ctx, position); equal {
+			return
+		}
+	}
+
+	plan.PositionJSON = position
+	// The positions key of json_metadata changes along with position_json.
+	if config.JSONMetadata.IsNull() {
+		plan.JSONMetadata = newNormalizedJSONUnknown()
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// ImportState imports a dashboard by its numeric ID, its UUID or its slug.
+func (r *dashboardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		if uuidPattern.MatchString(req.ID) {
+			id, err = r.client.GetDashboardIDByUUID(ctx, req.ID)
+		} else {
+			id, err = r.client.GetDashboardIDBySlug(ctx, req.ID)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing dashboard",
+			fmt.Sprintf("Could not find dashboard '%s' by ID, UUID or slug: %s", req.ID, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// setRoles replaces the roles of a dashboard when they are known. A null set revokes every
+// role, like the slug handling clears the slug, so removing the attribute is not ignored.
+func (r *dashboardResource) setRoles(ctx context.Context, id int64, roles types.Set) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if roles.IsUnknown() {
+		return diags
+	}
+
+	roleIDs := []int64{}
+	if !roles.IsNull() {
+		diags.Append(roles.ElementsAs(ctx, &roleIDs, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	if err := r.client.SetDashboardRoles(ctx, id, roleIDs); err != nil {
+		diags.AddError(
+			"Error setting dashboard roles",
+			fmt.Sprintf("Could not set roles of dashboard ID %d: %s", id, err.Error()),
+		)
+	}
+	return diags
+}
+
+// layoutPosition generates the position_json document of the layout of m, looking up the charts
+// it references, and returns it with the IDs of those charts. known is false while the layout or
+// the dashboard title is not known yet.
+func (r *dashboardResource) layoutPosition(ctx context.Context, m *dashboardResourceModel) (map[string]any, []int64, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	value, err := m.Layout.ToTerraformValue(ctx)
+	if err != nil {
+		diags.AddError("Error generating dashboard layout", err.Error())
+		return nil, nil, false, diags
+	}
+	if !value.IsFullyKnown() || m.DashboardTitle.IsUnknown() {
+		return nil, nil, false, diags
+	}
+
+	var layout dashboardLayoutModel
+	diags.Append(m.Layout.As(ctx, &layout, basetypes.ObjectAsOptions{})...)
+	ids, uuids, d := layoutChartRefs(ctx, layout)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, nil, false, diags
+	}
+
+	charts := map[string]int64{}
+	if len(ids) > 0 {
+		found, err := r.client.GetChartUUIDsByIDs(ctx, ids)
+		if err != nil {
+			diags.AddError("Error generating dashboard layout", "Could not look up the charts of the layout: "+err.Error())
+			return nil, nil, false, diags
+		}
+		maps.Copy(charts, found)
+	}
+	if len(uuids) > 0 {
+		found, err := r.client.GetChartIDsByUUIDs(ctx, uuids)
+		if err != nil {
+			diags.AddError("Error generating dashboard layout", "Could not look up the charts of the layout: "+err.Error())
+			return nil, nil, false, diags
+		}
+		maps.Copy(charts, found)
+	}
+
+	position, d := buildDashboardPosition(ctx, m.DashboardTitle.ValueString(), layout, charts)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, nil, false, diags
+	}
+	chartIDs := slices.Compact(slices.Sorted(maps.Values(charts)))
+	return position, chartIDs, true, diags
+}
+
+// applyLayout sets the position_json and json_metadata of a create or update request from the
+// layout of m, and returns the IDs of the charts in the layout. The positions are merged into
+// the planned json_metadata, or into prior when the metadata is left to Superset.
+func (r *dashboardResource) applyLayout(ctx context.Context, m *dashboardResourceModel, prior normalizedJSON, dashboardReq *client.DashboardRequest) ([]int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if m.Layout.IsNull() {
+		return nil, diags
+	}
+
+	position, chartIDs, known, d := r.layoutPosition(ctx, m)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if !known {
+		diags.AddAttributeError(path.Root("layout"), "Error generating dashboard layout", "The layout is not known at apply time.")
+		return nil, diags
+	}
+
+	encoded, err := json.Marshal(position)
+	if err != nil {
+		diags.AddError("Error generating dashboard layout", err.Error())
+		return nil, diags
+	}
+	positionJSON := string(encoded)
+	dashboardReq.PositionJSON = &positionJSON
+
+	base := ""
+	switch {
+	case !m.JSONMetadata.IsNull() && !m.JSONMetadata.IsUnknown():
+		base = m.JSONMetadata.ValueString()
+	case !prior.IsNull() && !prior.IsUnknown():
+		base = prior.ValueString()
+	}
+	metadata, err := withPositions(base, position)
+	if err != nil {
+		diags.AddAttributeError(path.Root("json_metadata"), "Error generating dashboard layout", err.Error())
+		return nil, diags
+	}
+	dashboardReq.JSONMetadata = &metadata
+
+	return chartIDs, diags
+}
+
+// syncLayoutCharts links the charts of the layout to the dashboard and unlinks the charts that
+// are no longer in it, the way superset_dashboard_import clears a dashboard before importing it.
+func (r *dashboardResource) syncLayoutCharts(ctx context.Context, id int64, chartIDs []int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	linked, err := r.client.GetDashboardChartUUIDs(ctx, id)
+	if err != nil {
+		diags.AddError(
+			"Error linking dashboard charts",
+			fmt.Sprintf("Could not list the charts of dashboard ID %d: %s", id, err.Error()),
+		)
+		return diags
+	}
+
+	linkedIDs := slices.Sorted(maps.Values(linked))
+	var stale, missing []int64
+	for _, chartID := range linkedIDs {
+		if !slices.Contains(chartIDs, chartID) {
+			stale = append(stale, chartID)
+		}
+	}
+	for _, chartID := range chartIDs {
+		if !slices.Contains(linkedIDs, chartID) {
+			missing = append(missing, chartID)
+		}
+	}
+
+	if err := r.client.UnlinkChartsFromDashboard(ctx, stale, id); err != nil {
+		diags.AddError(
+			"Error linking dashboard charts",
+			fmt.Sprintf("Could not unlink charts %v from dashboard ID %d: %s", stale, id, err.Error()),
+		)
+		return diags
+	}
+	if err := r.client.LinkChartsToDashboard(ctx, missing, id); err != nil {
+		diags.AddError(
+			"Error linking dashboard charts",
+			fmt.Sprintf("Could not link charts %v to dashboard ID %d: %s", missing, id, err.Error()),
+		)
+	}
+	return diags
+}
+
+// refresh reads a dashboard back after a write, filling in the values Superset computed.
+func (r *dashboardResource) refresh(ctx context.Context, model *dashboardResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	dashboard, err := r.client.GetDashboard(ctx, model.ID.ValueInt64())
+	if err != nil {
+		diags.AddError(
+			"Error reading dashboard",
+			"Could not read dashboard ID "+fmt.Sprintf("%d", model.ID.ValueInt64())+" after saving it: "+err.Error(),
+		)
+		return diags
+	}
+	return r.apply(ctx, model, dashboard)
+}
+
+// apply copies a dashboard as Superset returned it into the model, so that changes made outside
+// Terraform show up as drift. The UUID is looked up separately when the response lacks it.
+func (r *dashboardResource) apply(ctx context.Context, m *dashboardResourceModel, dashboard *client.Dashboard) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	uuid := dashboard.UUID
+	if uuid == "" {
+		var err error
+		uuid, err = r.client.GetDashboardUUID(ctx, dashboard.ID)
+		if err != nil {
+			diags.AddError(
+				"Error reading dashboard",
+				fmt.Sprintf("Could not look up the UUID of dashboard ID %d: %s", dashboard.ID, err.Error()),
+			)
+			return diags
+		}
+	}
+
+	m.ID = types.Int64Value(dashboard.ID)
+	m.UUID = types.StringValue(uuid)
+	m.URL = types.StringValue(dashboard.URL)
+	m.DashboardTitle = types.StringValue(dashboard.DashboardTitle)
+	m.Published = types.BoolValue(dashboard.Published)
+	m.Slug = optionalString(dashboard.Slug, m.Slug)
+	m.CSS = optionalString(dashboard.CSS, m.CSS)
+	m.CertifiedBy = optionalString(dashboard.CertifiedBy, m.CertifiedBy)
+	m.CertificationDetails = optionalString(dashboard.CertificationDetails, m.CertificationDetails)
+
+	// Superset fills in default metadata keys when it saves a dashboard; keep the configured
+	// document as long as Superset still holds everything in it.
+	metadata := dashboard.JSONMetadata.String()
+	switch {
+	case metadata == "":
+		m.JSONMetadata = newNormalizedJSONNull()
+	case m.JSONMetadata.IsNull() || m.JSONMetadata.IsUnknown() || !jsonContains(metadata, m.JSONMetadata.ValueString()):
+		m.JSONMetadata = newNormalizedJSONValue(metadata)
+	}
+	if dashboard.PositionJSON != "" {
+		m.PositionJSON = newNormalizedJSONValue(dashboard.PositionJSON.String())
+	} else {
+		m.PositionJSON = newNormalizedJSONNull()
+	}
+
+	owners := make([]int64, 0, len(dashboard.Owners))
+	for _, owner := range dashboard.Owners {
+		owners = append(owners, owner.ID)
+	}
+	ownerSet, d := types.SetValueFrom(ctx, types.Int64Type, owners)
+	diags.Append(d...)
+	m.Owners = ownerSet
+
+	// An unset roles attribute stays null while the dashboard has no roles.
+	if len(dashboard.Roles) > 0 || !m.Roles.IsNull() {
+		roles := make([]int64, 0, len(dashboard.Roles))
+		for _, role := range dashboard.Roles {
+			roles = append(roles, role.ID)
+		}
+		roleSet, d := types.SetValueFrom(ctx, types.Int64Type, roles)
+		diags.Append(d...)
+		m.Roles = roleSet
+	}
+
+	return diags
+}
+
+// request builds the create or update payload from the model. Unset optional attributes are
+// sent empty so that removing them from the configuration clears them in Superset; computed
+// attributes are only sent when they are known.
+func (m *dashboardResourceModel) request(ctx context.Context) (client.DashboardRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	published := m.Published.ValueBool()
+	css := m.CSS.ValueString()
+	certifiedBy := m.CertifiedBy.ValueString()
+	certificationDetails := m.CertificationDetails.ValueString()
+	dashboardReq := client.DashboardRequest{
+		DashboardTitle:       m.DashboardTitle.ValueString(),
+		Slug:                 m.Slug.ValueStringPointer(),
+		Published:            &published,
+		CSS:                  &css,
+		CertifiedBy:          &certifiedBy,
+		CertificationDetails: &certificationDetails,
+	}
+
+	if !m.JSONMetadata.IsUnknown() && !m.JSONMetadata.IsNull() {
+		metadata := m.JSONMetadata.ValueString()
+		dashboardReq.JSONMetadata = &metadata
+	}
+	if !m.PositionJSON.IsUnknown() && !m.PositionJSON.IsNull() {
+		position := m.PositionJSON.ValueString()
+		dashboardReq.PositionJSON = &position
+	}
+	if !m.Owners.IsUnknown() && !m.Owners.IsNull() {
+		owners := []int64{}
+		diags.Append(m.Owners.ElementsAs(ctx, &owners, false)...)
+		dashboardReq.Owners = &owners
+	}
+
+	return dashboardReq, diags
+}
+
+// optionalString returns value for an optional string attribute. An empty value stays null
+// while the attribute is unset, so Superset returning "" or null for it is not drift.
+func optionalString(value string, prior types.String) types.String {
+	if value == "" && (prior.IsNull() || prior.IsUnknown()) {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
@@ -0,0 +1,377 @@
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-superset/internal/client"
+	"terraform-provider-superset/internal/testing/fakesuperset"
+)
+
+func TestAccDashboardResource_FakeSuperset(t *testing.T) {
+	fake := fakesuperset.New(t)
+	roleID := fake.AddRole("Sales")
+
+	config := func(title, slug string, published bool) string {
+		return fakeProviderConfig(fake) + fmt.Sprintf(`
+resource "superset_dashboard" "test" {
+  dashboard_title = %q
+  slug            = %q
+  published       = %t
+  roles           = [%d]
+  css             = ".header { display: none; }"
+  json_metadata   = jsonencode({ color_scheme = "bnbColors" })
+}
+`, title, slug, published, roleID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			_, err := fakeClient(t, fake).GetDashboard(t.Context(), 1)
+			if !client.IsNotFound(err) {
+				return fmt.Errorf("dashboard left after destroy: %v", err)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("Sales", "sales", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_dashboard.test", "id", "1"),
+					resource.TestCheckResourceAttr("superset_dashboard.test", "roles.#", "1"),
+					resource.TestCheckResourceAttrSet("superset_dashboard.test", "uuid"),
+				),
+			},
+			{
+				Config: config("Sales overview", "sales-overview", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_dashboard.test", "dashboard_title", "Sales overview"),
+					resource.TestCheckResourceAttr("superset_dashboard.test", "published", "true"),
+				),
+			},
+			{
+				ResourceName:      "superset_dashboard.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "superset_dashboard.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "sales-overview",
+			},
+		},
+	})
+}
+
+func TestAccDashboardResource_Layout(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	datasetID := addChartDataset(t, c)
+	chartID, err := c.CreateChart(t.Context(), client.ChartRequest{SliceName: "Orders", VizType: "table", DatasourceID: datasetID, DatasourceType: "table"})
+	require.NoError(t, err)
+
+	config := func(chartWidth int) string {
+		return fakeProviderConfig(fake) + fmt.Sprintf(`
+resource "superset_dashboard" "test" {
+  dashboard_title = "Sales"
+
+  layout = {
+    tabs = [
+      {
+        title = "Overview"
+        rows = [
+          { items = [{ chart_id = %d, width = %d }, { markdown = "# Orders", width = 4 }] },
+          { divider = true },
+        ]
+      },
+    ]
+  }
+}
+`, chartID, chartWidth)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(6),
+				ExpectError: regexp.MustCompile(`must add up to 12, got 10`),
+			},
+			{
+				Config: config(8),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("superset_dashboard.test", "position_json"),
+					func(*terraform.State) error {
+						count, err := c.GetChartDashboardCount(t.Context(), chartID)
+						if err != nil {
+							return err
+						}
+						if count != 1 {
+							return fmt.Errorf("chart is on %d dashboards, want 1", count)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config(8),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestDashboardResource_ReadDetectsDrift(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	roleID := fake.AddRole("Sales")
+	r := &dashboardResource{client: c}
+
+	createResp := &fwresource.CreateResponse{State: emptyState(t, r)}
+	r.Create(t.Context(), fwresource.CreateRequest{
+		Plan: planFor(t, r, dashboardResourceModel{
+			ID:                   types.Int64Unknown(),
+			UUID:                 types.StringUnknown(),
+			URL:                  types.StringUnknown(),
+			DashboardTitle:       types.StringValue("Sales"),
+			Slug:                 types.StringValue("sales"),
+			Published:            types.BoolValue(false),
+			Owners:               types.SetUnknown(types.Int64Type),
+			Roles:                types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(roleID)}),
+			CSS:                  types.StringNull(),
+			CertifiedBy:          types.StringNull(),
+			CertificationDetails: types.StringNull(),
+			JSONMetadata:         newNormalizedJSONValue(`{"color_scheme": "bnbColors"}`),
+			PositionJSON:         newNormalizedJSONUnknown(),
+			Layout:               types.ObjectNull(dashboardLayoutType.AttrTypes),
+		}),
+	}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+	var created dashboardResourceModel
+	require.False(t, createResp.State.Get(t.Context(), &created).HasError())
+	assert.NotEmpty(t, created.UUID.ValueString())
+	assert.Len(t, created.Roles.Elements(), 1)
+	assert.True(t, created.CSS.IsNull())
+
+	// Superset adds default keys to the metadata, and someone edits the dashboard in the UI.
+	metadata := `{"color_scheme": "bnbColors", "refresh_frequency": 0, "label_colors": {}}`
+	css := ".header { display: none; }"
+	require.NoError(t, c.UpdateDashboard(t.Context(), created.ID.ValueInt64(), client.DashboardRequest{
+		DashboardTitle: "Sales (edited)",
+		JSONMetadata:   &metadata,
+		CSS:            &css,
+	}))
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(t.Context(), fwresource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+
+	var refreshed dashboardResourceModel
+	require.False(t, readResp.State.Get(t.Context(), &refreshed).HasError())
+	assert.Equal(t, "Sales (edited)", refreshed.DashboardTitle.ValueString())
+	assert.Empty(t, refreshed.Slug.ValueString(), "the slug was cleared")
+	assert.Equal(t, css, refreshed.CSS.ValueString())
+	assert.JSONEq(t, `{"color_scheme": "bnbColors"}`, refreshed.JSONMetadata.ValueString(), "added metadata keys are not drift")
+
+	metadata = `{"color_scheme": "supersetColors"}`
+	require.NoError(t, c.UpdateDashboard(t.Context(), created.ID.ValueInt64(), client.DashboardRequest{DashboardTitle: "Sales", JSONMetadata: &metadata}))
+	r.Read(t.Context(), fwresource.ReadRequest{State: readResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	require.False(t, readResp.State.Get(t.Context(), &refreshed).HasError())
+	assert.JSONEq(t, metadata, refreshed.JSONMetadata.ValueString(), "a changed metadata key is drift")
+}
+
+func TestDashboardResource_RemovingRolesRevokesThem(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	roleID := fake.AddRole("Sales")
+	r := &dashboardResource{client: c}
+
+	model := dashboardResourceModel{
+		ID:                   types.Int64Unknown(),
+		UUID:                 types.StringUnknown(),
+		URL:                  types.StringUnknown(),
+		DashboardTitle:       types.StringValue("Sales"),
+		Slug:                 types.StringNull(),
+		Published:            types.BoolValue(false),
+		Owners:               types.SetUnknown(types.Int64Type),
+		Roles:                types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(roleID)}),
+		CSS:                  types.StringNull(),
+		CertifiedBy:          types.StringNull(),
+		CertificationDetails: types.StringNull(),
+		JSONMetadata:         newNormalizedJSONNull(),
+		PositionJSON:         newNormalizedJSONUnknown(),
+		Layout:               types.ObjectNull(dashboardLayoutType.AttrTypes),
+	}
+	createResp := &fwresource.CreateResponse{State: emptyState(t, r)}
+	r.Create(t.Context(), fwresource.CreateRequest{Plan: planFor(t, r, model)}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+	var created dashboardResourceModel
+	require.False(t, createResp.State.Get(t.Context(), &created).HasError())
+	require.Len(t, created.Roles.Elements(), 1)
+
+	created.Roles = types.SetNull(types.Int64Type)
+	updateResp := &fwresource.UpdateResponse{State: createResp.State}
+	r.Update(t.Context(), fwresource.UpdateRequest{Plan: planFor(t, r, created), State: createResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "%v", updateResp.Diagnostics)
+
+	var updated dashboardResourceModel
+	require.False(t, updateResp.State.Get(t.Context(), &updated).HasError())
+	assert.True(t, updated.Roles.IsNull(), "the removed attribute stays null")
+
+	dashboard, err := c.GetDashboard(t.Context(), created.ID.ValueInt64())
+	require.NoError(t, err)
+	assert.Empty(t, dashboard.Roles, "the roles were revoked")
+}
+
+func TestDashboardResource_ImportState(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	r := &dashboardResource{client: c}
+
+	slug := "sales"
+	id, err := c.CreateDashboard(t.Context(), client.DashboardRequest{DashboardTitle: "Sales", Slug: &slug})
+	require.NoError(t, err)
+	uuid, err := c.GetDashboardUUID(t.Context(), id)
+	require.NoError(t, err)
+
+	for _, importID := range []string{fmt.Sprint(id), uuid, slug} {
+		resp := &fwresource.ImportStateResponse{State: emptyState(t, r)}
+		r.ImportState(t.Context(), fwresource.ImportStateRequest{ID: importID}, resp)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+		var imported types.Int64
+		require.False(t, resp.State.GetAttribute(t.Context(), path.Root("id"), &imported).HasError())
+		assert.Equal(t, id, imported.ValueInt64(), "imported by %s", importID)
+	}
+
+	for _, importID := range []string{"00000000-0000-0000-0000-000000000000", "no-such-slug"} {
+		resp := &fwresource.ImportStateResponse{State: emptyState(t, r)}
+		r.ImportState(t.Context(), fwresource.ImportStateRequest{ID: importID}, resp)
+		require.True(t, resp.Diagnostics.HasError(), importID)
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "by ID, UUID or slug")
+	}
+}
+
+func TestDashboardResource_LayoutLinksCharts(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	r := &dashboardResource{client: c}
+
+	datasetID := addChartDataset(t, c)
+	var chartIDs []int64
+	for _, name := range []string{"Orders", "Revenue"} {
+		id, err := c.CreateChart(t.Context(), client.ChartRequest{SliceName: name, VizType: "table", DatasourceID: datasetID, DatasourceType: "table"})
+		require.NoError(t, err)
+		chartIDs = append(chartIDs, id)
+	}
+	uuids, err := c.GetChartUUIDsByIDs(t.Context(), chartIDs[1:])
+	require.NoError(t, err)
+	var revenueUUID string
+	for uuid := range uuids {
+		revenueUUID = uuid
+	}
+
+	model := func(layout types.Object) dashboardResourceModel {
+		return dashboardResourceModel{
+			ID:                   types.Int64Unknown(),
+			UUID:                 types.StringUnknown(),
+			URL:                  types.StringUnknown(),
+			DashboardTitle:       types.StringValue("Sales"),
+			Slug:                 types.StringNull(),
+			Published:            types.BoolValue(false),
+			Owners:               types.SetUnknown(types.Int64Type),
+			Roles:                types.SetNull(types.Int64Type),
+			CSS:                  types.StringNull(),
+			CertifiedBy:          types.StringNull(),
+			CertificationDetails: types.StringNull(),
+			JSONMetadata:         newNormalizedJSONValue(`{"color_scheme": "bnbColors"}`),
+			PositionJSON:         newNormalizedJSONUnknown(),
+			Layout:               layout,
+		}
+	}
+	config := func(m dashboardResourceModel) tfsdk.Config {
+		m.ID, m.UUID, m.URL = types.Int64Null(), types.StringNull(), types.StringNull()
+		m.Owners = types.SetNull(types.Int64Type)
+		m.PositionJSON = newNormalizedJSONNull()
+		plan := planFor(t, r, m)
+		return tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+	}
+	linkedCharts := func(dashboardID int64) []int64 {
+		linked, err := c.GetDashboardChartUUIDs(t.Context(), dashboardID)
+		require.NoError(t, err)
+		return slices.Sorted(maps.Values(linked))
+	}
+
+	// The plan holds the generated layout, so the first apply matches it.
+	both := model(gridLayout(t, layoutRow(t, chartItem(chartIDs[0], 6), chartUUIDItem(revenueUUID, 6)), layoutDivider(t), layoutRow(t, markdownItem("# Notes", 12, 20))))
+	planResp := &fwresource.ModifyPlanResponse{Plan: planFor(t, r, both)}
+	r.ModifyPlan(t.Context(), fwresource.ModifyPlanRequest{Plan: planFor(t, r, both), Config: config(both), State: emptyState(t, r)}, planResp)
+	require.False(t, planResp.Diagnostics.HasError(), "%v", planResp.Diagnostics)
+	var planned dashboardResourceModel
+	require.False(t, planResp.Plan.Get(t.Context(), &planned).HasError())
+	require.False(t, planned.PositionJSON.IsUnknown())
+	assert.Contains(t, planned.PositionJSON.ValueString(), revenueUUID)
+
+	createResp := &fwresource.CreateResponse{State: emptyState(t, r)}
+	r.Create(t.Context(), fwresource.CreateRequest{Plan: planResp.Plan}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+	var created dashboardResourceModel
+	require.False(t, createResp.State.Get(t.Context(), &created).HasError())
+	equal, _ := created.PositionJSON.StringSemanticEquals(t.Context(), planned.PositionJSON)
+	assert.True(t, equal, "the applied layout matches the plan")
+	assert.JSONEq(t, `{"color_scheme": "bnbColors"}`, created.JSONMetadata.ValueString(), "the generated positions are not drift")
+	assert.Equal(t, chartIDs, linkedCharts(created.ID.ValueInt64()))
+
+	dashboard, err := c.GetDashboard(t.Context(), created.ID.ValueInt64())
+	require.NoError(t, err)
+	var metadata struct {
+		Positions map[string]any `json:"positions"`
+	}
+	require.NoError(t, dashboard.JSONMetadata.Decode(&metadata))
+	assert.Contains(t, metadata.Positions, "MARKDOWN-1")
+
+	// Dropping a chart from the layout unlinks it from the dashboard.
+	one := model(gridLayout(t, layoutRow(t, chartUUIDItem(revenueUUID, 12))))
+	one.ID, one.UUID, one.URL, one.Owners, one.Roles = created.ID, created.UUID, created.URL, created.Owners, created.Roles
+	one.PositionJSON = created.PositionJSON
+	planResp = &fwresource.ModifyPlanResponse{Plan: planFor(t, r, one)}
+	r.ModifyPlan(t.Context(), fwresource.ModifyPlanRequest{Plan: planFor(t, r, one), Config: config(one), State: createResp.State}, planResp)
+	require.False(t, planResp.Diagnostics.HasError(), "%v", planResp.Diagnostics)
+	require.False(t, planResp.Plan.Get(t.Context(), &planned).HasError())
+	assert.NotContains(t, planned.PositionJSON.ValueString(), "MARKDOWN-1", "a layout change is planned")
+
+	updateResp := &fwresource.UpdateResponse{State: createResp.State}
+	r.Update(t.Context(), fwresource.UpdateRequest{Plan: planResp.Plan, State: createResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "%v", updateResp.Diagnostics)
+	assert.Equal(t, chartIDs[1:], linkedCharts(created.ID.ValueInt64()))
+
+	// An unchanged layout leaves the plan alone, metadata left to Superset included.
+	var current dashboardResourceModel
+	require.False(t, updateResp.State.Get(t.Context(), &current).HasError())
+	current.JSONMetadata = newNormalizedJSONValue(`{"color_scheme": "bnbColors", "positions": {}}`)
+	unchanged := planFor(t, r, current)
+	current.JSONMetadata = newNormalizedJSONNull()
+	planResp = &fwresource.ModifyPlanResponse{Plan: unchanged}
+	r.ModifyPlan(t.Context(), fwresource.ModifyPlanRequest{Plan: unchanged, Config: config(current), State: updateResp.State}, planResp)
+	require.False(t, planResp.Diagnostics.HasError(), "%v", planResp.Diagnostics)
+	assert.True(t, planResp.Plan.Raw.Equal(unchanged.Raw), "the plan was modified")
+}
@@ -24,7 +24,7 @@ func NewDatabasesDataSource() datasource.DataSource {
 
 // databasesDataSource is the data source implementation.
 type databasesDataSource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 // databasesDataSourceModel maps the data source schema data.
@@ -80,7 +80,7 @@ func (d *databasesDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 	var state databasesDataSourceModel
 
-	dbInfosRaw, err := d.client.GetAllDatabases()
+	databases, err := d.client.GetAllDatabases(ctx)
 	if err != nil {
 		tflog.Error(ctx, "Error fetching databases", map[string]interface{}{
 			"error": err.Error(),
@@ -92,43 +92,15 @@ func (d *databasesDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	for _, db := range dbInfosRaw {
+	for _, db := range databases {
 		tflog.Debug(ctx, "Processing database", map[string]interface{}{
-			"database": db,
+			"id":            db.ID,
+			"database_name": db.DatabaseName,
 		})
 
-		// Use type assertion to handle int64 type conversion
-		id, ok := db["id"].(int64)
-		if !ok {
-			if floatID, ok := db["id"].(float64); ok {
-				id = int64(floatID)
-			} else {
-				tflog.Error(ctx, "Type assertion error for database ID", map[string]interface{}{
-					"database_id_type": fmt.Sprintf("%T", db["id"]),
-				})
-				resp.Diagnostics.AddError(
-					"Type Assertion Error",
-					fmt.Sprintf("Expected int64 or float64 for database ID, got: %T", db["id"]),
-				)
-				return
-			}
-		}
-
-		name, ok := db["database_name"].(string)
-		if !ok {
-			tflog.Error(ctx, "Type assertion error for database name", map[string]interface{}{
-				"database_name_type": fmt.Sprintf("%T", db["database_name"]),
-			})
-			resp.Diagnostics.AddError(
-				"Type Assertion Error",
-				fmt.Sprintf("Expected string for database name, got: %T", db["database_name"]),
-			)
-			return
-		}
-
 		state.Databases = append(state.Databases, databaseModel{
-			ID:           types.Int64Value(id),
-			DatabaseName: types.StringValue(name),
+			ID:           types.Int64Value(db.ID),
+			DatabaseName: types.StringValue(db.DatabaseName),
 		})
 	}
 
@@ -146,15 +118,15 @@ func (d *databasesDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		tflog.Error(ctx, "Unexpected Data Source Configure Type", map[string]interface{}{
-			"expected": "*client.Client",
+			"expected": "client.SupersetAPI",
 			"got":      fmt.Sprintf("%T", req.ProviderData),
 		})
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
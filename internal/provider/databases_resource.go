@@ -7,6 +7,7 @@ import (
 
 	"terraform-provider-superset/internal/client"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -31,7 +32,7 @@ func NewDatabaseResource() resource.Resource {
 
 // databaseResource is the resource implementation.
 type databaseResource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 // sshTunnelModel maps the ssh_tunnel nested block.
@@ -230,7 +231,7 @@ func (r *databaseResource) Create(ctx context.Context, req resource.CreateReques
 	sqlalchemyURI := buildSQLAlchemyURI(plan)
 	payload := buildDatabasePayload(plan, sqlalchemyURI)
 
-	result, err := r.client.CreateDatabase(payload)
+	result, err := r.client.CreateDatabase(ctx, payload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Superset Database Connection",
@@ -239,67 +240,10 @@ func (r *databaseResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	// Type assertion with error handling
-	idFloat, ok := result["id"].(float64)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Invalid Response",
-			"The 'id' field in the response is not a float64",
-		)
+	plan.ID = types.Int64Value(result.ID)
+	if !applyDatabaseResponse(&plan, result, &resp.Diagnostics) {
 		return
 	}
-	plan.ID = types.Int64Value(int64(idFloat))
-
-	resultData, ok := result["result"].(map[string]interface{})
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Invalid Response",
-			"The response from the API does not contain the expected 'result' field",
-		)
-		return
-	}
-
-	// Handle type assertions with error handling
-	if val, ok := resultData["database_name"].(string); ok {
-		plan.ConnectionName = types.StringValue(val)
-	} else {
-		resp.Diagnostics.AddError(
-			"Invalid Response",
-			"The response from the API does not contain a valid 'database_name' field",
-		)
-		return
-	}
-	if val, ok := resultData["allow_ctas"].(bool); ok {
-		plan.AllowCTAS = types.BoolValue(val)
-	}
-	if val, ok := resultData["allow_cvas"].(bool); ok {
-		plan.AllowCVAS = types.BoolValue(val)
-	}
-	if val, ok := resultData["allow_dml"].(bool); ok {
-		plan.AllowDML = types.BoolValue(val)
-	}
-	if val, ok := resultData["allow_file_upload"].(bool); ok {
-		plan.AllowFileUpload = types.BoolValue(val)
-	}
-	if val, ok := resultData["allow_run_async"].(bool); ok {
-		plan.AllowRunAsync = types.BoolValue(val)
-	}
-	if val, ok := resultData["expose_in_sqllab"].(bool); ok {
-		plan.ExposeInSQLLab = types.BoolValue(val)
-	}
-	if val, ok := resultData["cache_timeout"].(float64); ok {
-		plan.CacheTimeout = types.Int64Value(int64(val))
-	} else if plan.CacheTimeout.IsUnknown() {
-		plan.CacheTimeout = types.Int64Value(0)
-	}
-	if val, ok := resultData["extra"].(string); ok {
-		plan.Extra = types.StringValue(val)
-	} else if plan.Extra.IsUnknown() {
-		plan.Extra = types.StringValue("")
-	}
-	if val, ok := resultData["impersonate_user"].(bool); ok {
-		plan.ImpersonateUser = types.BoolValue(val)
-	}
 
 	diags = resp.State.Set(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -326,8 +270,13 @@ func (r *databaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	db, err := r.client.GetDatabaseConnectionByID(state.ID.ValueInt64())
+	db, err := r.client.GetDatabaseConnectionByID(ctx, state.ID.ValueInt64())
 	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Database ID %d not found, removing from state", state.ID.ValueInt64()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading database connection",
 			fmt.Sprintf("Could not read database ID %d: %s", state.ID.ValueInt64(), err.Error()),
@@ -335,76 +284,28 @@ func (r *databaseResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	result, ok := db["result"].(map[string]interface{})
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Invalid Response",
-			"The response from the API does not contain the expected 'result' field",
-		)
-		return
-	}
-
-	if val, ok := result["database_name"].(string); ok {
-		state.ConnectionName = types.StringValue(val)
-	} else {
-		resp.Diagnostics.AddError(
-			"Invalid Response",
-			"The response from the API does not contain a valid 'database_name' field",
-		)
+	if !applyDatabaseResponse(&state, db, &resp.Diagnostics) {
 		return
 	}
-	if val, ok := result["allow_ctas"].(bool); ok {
-		state.AllowCTAS = types.BoolValue(val)
-	}
-	if val, ok := result["allow_cvas"].(bool); ok {
-		state.AllowCVAS = types.BoolValue(val)
-	}
-	if val, ok := result["allow_dml"].(bool); ok {
-		state.AllowDML = types.BoolValue(val)
-	}
-	if val, ok := result["allow_file_upload"].(bool); ok {
-		state.AllowFileUpload = types.BoolValue(val)
-	}
-	if val, ok := result["allow_run_async"].(bool); ok {
-		state.AllowRunAsync = types.BoolValue(val)
-	}
-	if val, ok := result["expose_in_sqllab"].(bool); ok {
-		state.ExposeInSQLLab = types.BoolValue(val)
-	}
-	if val, ok := result["cache_timeout"].(float64); ok {
-		state.CacheTimeout = types.Int64Value(int64(val))
-	} else if state.CacheTimeout.IsUnknown() {
-		state.CacheTimeout = types.Int64Value(0)
-	}
-	if val, ok := result["extra"].(string); ok {
-		state.Extra = types.StringValue(val)
-	} else if state.Extra.IsUnknown() {
-		state.Extra = types.StringValue("")
-	}
-	if val, ok := result["impersonate_user"].(bool); ok {
-		state.ImpersonateUser = types.BoolValue(val)
-	}
-	if val, ok := result["server_cert"].(string); ok {
-		state.ServerCert = types.StringValue(val)
+	if db.ServerCert != "" {
+		state.ServerCert = types.StringValue(db.ServerCert)
 	}
-	if val, ok := result["backend"].(string); ok {
-		state.DBEngine = types.StringValue(val)
+	if db.Backend != "" {
+		state.DBEngine = types.StringValue(db.Backend)
 	}
 	// Only populate individual fields if sqlalchemy_uri is not used
-	if state.SQLAlchemyURI.IsNull() || state.SQLAlchemyURI.ValueString() == "" {
-		if params, ok := result["parameters"].(map[string]interface{}); ok {
-			if val, ok := params["host"].(string); ok {
-				state.DBHost = types.StringValue(val)
-			}
-			if val, ok := params["username"].(string); ok {
-				state.DBUser = types.StringValue(val)
-			}
-			if val, ok := params["port"].(float64); ok {
-				state.DBPort = types.Int64Value(int64(val))
-			}
-			if val, ok := params["database"].(string); ok {
-				state.DBName = types.StringValue(val)
-			}
+	if (state.SQLAlchemyURI.IsNull() || state.SQLAlchemyURI.ValueString() == "") && db.Parameters != nil {
+		if db.Parameters.Host != "" {
+			state.DBHost = types.StringValue(db.Parameters.Host)
+		}
+		if db.Parameters.Username != "" {
+			state.DBUser = types.StringValue(db.Parameters.Username)
+		}
+		if db.Parameters.Port != 0 {
+			state.DBPort = types.Int64Value(db.Parameters.Port)
+		}
+		if db.Parameters.Database != "" {
+			state.DBName = types.StringValue(db.Parameters.Database)
 		}
 	}
 
@@ -445,7 +346,7 @@ func (r *databaseResource) Update(ctx context.Context, req resource.UpdateReques
 	sqlalchemyURI := buildSQLAlchemyURI(plan)
 	payload := buildDatabasePayload(plan, sqlalchemyURI)
 
-	result, err := r.client.UpdateDatabase(state.ID.ValueInt64(), payload)
+	result, err := r.client.UpdateDatabase(ctx, state.ID.ValueInt64(), payload)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Update Superset Database Connection",
@@ -454,56 +355,10 @@ func (r *databaseResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	resultData, ok := result["result"].(map[string]interface{})
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Invalid Response",
-			"The response from the API does not contain the expected 'result' field",
-		)
-		return
-	}
-
 	// Update state attributes with the values from the response
-	if val, ok := resultData["database_name"].(string); ok {
-		state.ConnectionName = types.StringValue(val)
-	} else {
-		resp.Diagnostics.AddError(
-			"Invalid Response",
-			"The response from the API does not contain a valid 'database_name' field",
-		)
+	if !applyDatabaseResponse(&state, result, &resp.Diagnostics) {
 		return
 	}
-	if val, ok := resultData["allow_ctas"].(bool); ok {
-		state.AllowCTAS = types.BoolValue(val)
-	}
-	if val, ok := resultData["allow_cvas"].(bool); ok {
-		state.AllowCVAS = types.BoolValue(val)
-	}
-	if val, ok := resultData["allow_dml"].(bool); ok {
-		state.AllowDML = types.BoolValue(val)
-	}
-	if val, ok := resultData["allow_file_upload"].(bool); ok {
-		state.AllowFileUpload = types.BoolValue(val)
-	}
-	if val, ok := resultData["allow_run_async"].(bool); ok {
-		state.AllowRunAsync = types.BoolValue(val)
-	}
-	if val, ok := resultData["expose_in_sqllab"].(bool); ok {
-		state.ExposeInSQLLab = types.BoolValue(val)
-	}
-	if val, ok := resultData["cache_timeout"].(float64); ok {
-		state.CacheTimeout = types.Int64Value(int64(val))
-	} else if state.CacheTimeout.IsUnknown() {
-		state.CacheTimeout = types.Int64Value(0)
-	}
-	if val, ok := resultData["extra"].(string); ok {
-		state.Extra = types.StringValue(val)
-	} else if state.Extra.IsUnknown() {
-		state.Extra = types.StringValue("")
-	}
-	if val, ok := resultData["impersonate_user"].(bool); ok {
-		state.ImpersonateUser = types.BoolValue(val)
-	}
 
 	state.DBEngine = plan.DBEngine
 	state.SQLAlchemyURI = plan.SQLAlchemyURI
@@ -542,7 +397,7 @@ func (r *databaseResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	err := r.client.DeleteDatabase(state.ID.ValueInt64())
+	err := r.client.DeleteDatabase(ctx, state.ID.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Delete Superset Database Connection",
@@ -585,6 +440,38 @@ func (r *databaseResource) ImportState(ctx context.Context, req resource.ImportS
 	})
 }
 
+// applyDatabaseResponse copies the connection settings Superset returned into model. It reports
+// false, with an error diagnostic, when the response carries no database name.
+func applyDatabaseResponse(model *databaseResourceModel, db *client.Database, diags *diag.Diagnostics) bool {
+	if db.DatabaseName == "" {
+		diags.AddError(
+			"Invalid Response",
+			"The response from the API does not contain a valid 'database_name' field",
+		)
+		return false
+	}
+
+	model.ConnectionName = types.StringValue(db.DatabaseName)
+	model.AllowCTAS = types.BoolValue(db.AllowCTAS)
+	model.AllowCVAS = types.BoolValue(db.AllowCVAS)
+	model.AllowDML = types.BoolValue(db.AllowDML)
+	model.AllowFileUpload = types.BoolValue(db.AllowFileUpload)
+	model.AllowRunAsync = types.BoolValue(db.AllowRunAsync)
+	model.ExposeInSQLLab = types.BoolValue(db.ExposeInSQLLab)
+	model.ImpersonateUser = types.BoolValue(db.ImpersonateUser)
+	if db.CacheTimeout != nil {
+		model.CacheTimeout = types.Int64Value(*db.CacheTimeout)
+	} else if model.CacheTimeout.IsUnknown() {
+		model.CacheTimeout = types.Int64Value(0)
+	}
+	if db.Extra != "" {
+		model.Extra = types.StringValue(db.Extra.String())
+	} else if model.Extra.IsUnknown() {
+		model.Extra = types.StringValue("")
+	}
+	return true
+}
+
 // buildSQLAlchemyURI returns the SQLAlchemy URI from either the explicit field or constructed from parts.
 func buildSQLAlchemyURI(plan databaseResourceModel) string {
 	if !plan.SQLAlchemyURI.IsNull() && plan.SQLAlchemyURI.ValueString() != "" {
@@ -657,11 +544,11 @@ func (r *databaseResource) Configure(_ context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
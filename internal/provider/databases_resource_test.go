@@ -4,8 +4,14 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-superset/internal/client"
 )
 
 // baseDBCreateResponse is the common API response shape for a freshly created database.
@@ -626,3 +632,28 @@ resource "superset_database" "ssh_pw_test" {
   }
 }
 `
+
+func TestApplyDatabaseResponse(t *testing.T) {
+	timeout := int64(300)
+	model := databaseResourceModel{CacheTimeout: types.Int64Unknown(), Extra: types.StringUnknown()}
+	var diags diag.Diagnostics
+
+	ok := applyDatabaseResponse(&model, &client.Database{
+		DatabaseName:   "warehouse",
+		AllowRunAsync:  true,
+		ExposeInSQLLab: true,
+		CacheTimeout:   &timeout,
+	}, &diags)
+
+	require.True(t, ok)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "warehouse", model.ConnectionName.ValueString())
+	assert.True(t, model.AllowRunAsync.ValueBool())
+	assert.False(t, model.AllowDML.ValueBool())
+	assert.Equal(t, int64(300), model.CacheTimeout.ValueInt64())
+	assert.Equal(t, "", model.Extra.ValueString(), "an unknown extra settles to empty")
+
+	ok = applyDatabaseResponse(&model, &client.Database{ID: 1}, &diags)
+	assert.False(t, ok)
+	assert.True(t, diags.HasError())
+}
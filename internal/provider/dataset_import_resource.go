@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"terraform-provider-superset/internal/client"
 
@@ -30,7 +31,7 @@ func NewDatasetImportResource() resource.Resource {
 }
 
 type datasetImportResource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 type datasetImportResourceModel struct {
@@ -108,10 +109,10 @@ func (r *datasetImportResource) Configure(_ context.Context, req resource.Config
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.Client)
+	c, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T.", req.ProviderData))
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T.", req.ProviderData))
 		return
 	}
 	r.client = c
@@ -222,17 +223,19 @@ func (r *datasetImportResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	ids, err := r.client.GetDatasetIDsByUUIDs(ctx, uuids)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Failed to look up dataset UUIDs: %s", err))
+		return
+	}
+
 	for _, uuid := range uuids {
-		id, err := r.client.GetDatasetIDByUUID(uuid)
-		if err != nil {
-			tflog.Warn(ctx, fmt.Sprintf("Failed to look up dataset UUID %s: %s", uuid, err))
-			continue
-		}
-		if id == 0 {
+		id, ok := ids[uuid]
+		if !ok {
 			continue
 		}
 		// Skip deletion if dataset is still used by charts
-		chartCount, err := r.client.GetDatasetChartCount(id)
+		chartCount, err := r.client.GetDatasetChartCount(ctx, id)
 		if err != nil {
 			tflog.Warn(ctx, fmt.Sprintf("Failed to check dataset %d references: %s", id, err))
 		} else if chartCount > 0 {
@@ -240,7 +243,7 @@ func (r *datasetImportResource) Delete(ctx context.Context, req resource.DeleteR
 			continue
 		}
 		tflog.Info(ctx, fmt.Sprintf("Deleting dataset %d (UUID %s)", id, uuid))
-		if err := r.client.DeleteDataset(id); err != nil {
+		if err := r.client.DeleteDataset(ctx, id); err != nil {
 			resp.Diagnostics.AddWarning("Failed to delete dataset",
 				fmt.Sprintf("Dataset %d (UUID %s): %s", id, uuid, err))
 		}
@@ -292,15 +295,17 @@ func (r *datasetImportResource) doImport(ctx context.Context, plan *datasetImpor
 		passwords = string(b)
 	}
 
-	zipData, err := zipDirectoryFiltered(sourceDir, overrides, datasetImportPrefixes, "SqlaTable", skipPatterns)
-	if err != nil {
-		return fmt.Errorf("creating ZIP: %w", err)
+	bundle := func(w io.Writer) error {
+		if err := zipDirectoryFiltered(w, sourceDir, overrides, datasetImportPrefixes, "SqlaTable", skipPatterns); err != nil {
+			return fmt.Errorf("creating ZIP: %w", err)
+		}
+		return nil
 	}
 
 	overwrite := plan.ForceOverwrite.ValueBool()
 	tflog.Info(ctx, fmt.Sprintf("Importing datasets from %s (overwrite=%v)", sourceDir, overwrite))
 
-	if err := r.client.ImportDataset(zipData, overwrite, passwords); err != nil {
+	if err := r.client.ImportDataset(ctx, bundle, overwrite, passwords); err != nil {
 		return err
 	}
 
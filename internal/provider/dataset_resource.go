@@ -29,7 +29,7 @@ func NewDatasetResource() resource.Resource {
 
 // datasetResource is the resource implementation.
 type datasetResource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 // datasetResourceModel maps the resource schema data.
@@ -94,7 +94,7 @@ func (r *datasetResource) Create(ctx context.Context, req resource.CreateRequest
 	})
 
 	// Get database ID by name
-	databaseID, err := r.client.GetDatabaseIDByName(plan.DatabaseName.ValueString())
+	databaseID, err := r.client.GetDatabaseIDByName(ctx, plan.DatabaseName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error finding database",
@@ -112,7 +112,7 @@ func (r *datasetResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// Create dataset
-	datasetResp, err := r.client.CreateDataset(datasetReq)
+	datasetResp, err := r.client.CreateDataset(ctx, datasetReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating dataset",
@@ -121,17 +121,7 @@ func (r *datasetResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	// Extract ID from response
-	var datasetID int64
-	if id, ok := (*datasetResp)["id"].(float64); ok {
-		datasetID = int64(id)
-	} else {
-		resp.Diagnostics.AddError(
-			"Error creating dataset",
-			"Could not extract ID from create response",
-		)
-		return
-	}
+	datasetID := datasetResp.ID
 
 	// Update the state
 	plan.ID = types.Int64Value(datasetID)
@@ -159,8 +149,13 @@ func (r *datasetResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	// Get dataset from API
-	dataset, err := r.client.GetDataset(state.ID.ValueInt64())
+	dataset, err := r.client.GetDataset(ctx, state.ID.ValueInt64())
 	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Dataset ID %d not found, removing from state", state.ID.ValueInt64()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading dataset",
 			"Could not read dataset ID "+fmt.Sprintf("%d", state.ID.ValueInt64())+": "+err.Error(),
@@ -169,31 +164,25 @@ func (r *datasetResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	// Update state from API response
-	if tableName, ok := (*dataset)["table_name"].(string); ok {
-		state.TableName = types.StringValue(tableName)
+	state.TableName = types.StringValue(dataset.TableName)
+	if dataset.Schema != "" {
+		state.Schema = types.StringValue(dataset.Schema)
 	}
-
-	if schema, ok := (*dataset)["schema"].(string); ok {
-		state.Schema = types.StringValue(schema)
-	}
-
-	if sql, ok := (*dataset)["sql"].(string); ok {
-		state.SQL = types.StringValue(sql)
+	if dataset.SQL != "" {
+		state.SQL = types.StringValue(dataset.SQL)
 	}
 
 	// Get database name by ID
-	if database, ok := (*dataset)["database"].(map[string]interface{}); ok {
-		if dbID, ok := database["id"].(float64); ok {
-			databaseName, err := r.client.GetDatabaseNameByID(int64(dbID))
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error reading dataset",
-					"Could not get database name: "+err.Error(),
-				)
-				return
-			}
-			state.DatabaseName = types.StringValue(databaseName)
+	if dataset.Database.ID != 0 {
+		databaseName, err := r.client.GetDatabaseNameByID(ctx, dataset.Database.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading dataset",
+				"Could not get database name: "+err.Error(),
+			)
+			return
 		}
+		state.DatabaseName = types.StringValue(databaseName)
 	}
 
 	// Set refreshed state
@@ -215,12 +204,11 @@ func (r *datasetResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	// Update dataset (database cannot be changed, so we don't validate it)
-	err := r.client.UpdateDataset(
-		plan.ID.ValueInt64(),
-		plan.TableName.ValueString(),
-		plan.Schema.ValueString(),
-		plan.SQL.ValueString(),
-	)
+	err := r.client.UpdateDataset(ctx, plan.ID.ValueInt64(), client.DatasetUpdateRequest{
+		TableName: plan.TableName.ValueString(),
+		Schema:    plan.Schema.ValueString(),
+		SQL:       plan.SQL.ValueString(),
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating dataset",
@@ -252,7 +240,7 @@ func (r *datasetResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 
 	// Delete existing dataset
-	err := r.client.DeleteDataset(state.ID.ValueInt64())
+	err := r.client.DeleteDataset(ctx, state.ID.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting dataset",
@@ -272,12 +260,12 @@ func (r *datasetResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -5,18 +5,21 @@ import (
 	"net/http"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"terraform-provider-superset/internal/client"
+	"terraform-provider-superset/internal/testing/fakesuperset"
 )
 
 func TestAccDatasetResource(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 
-	// Clear the global database cache to ensure our mocks are used
-	client.ClearGlobalDatabaseCache()
-
 	// Mock authentication response
 	mockLoginResponse := `{
 		"access_token": "fake-token",
@@ -109,7 +112,7 @@ func TestAccDatasetResource(t *testing.T) {
 	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
 		httpmock.NewStringResponder(200, mockLoginResponse))
 
-	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/database/?q=(page_size:5000)",
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/database/?q=(page:0,page_size:100)",
 		httpmock.NewStringResponder(200, mockDatabasesResponse))
 
 	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/dataset/",
@@ -171,9 +174,6 @@ func TestAccDatasetResourceWithSQL(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 
-	// Clear the global database cache to ensure our mocks are used
-	client.ClearGlobalDatabaseCache()
-
 	// Mock authentication response
 	mockLoginResponse := `{
 		"access_token": "fake-token",
@@ -249,7 +249,7 @@ func TestAccDatasetResourceWithSQL(t *testing.T) {
 	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
 		httpmock.NewStringResponder(200, mockLoginResponse))
 
-	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/database/?q=(page_size:5000)",
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/database/?q=(page:0,page_size:100)",
 		httpmock.NewStringResponder(200, mockDatabasesResponse))
 
 	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/dataset/",
@@ -297,3 +297,38 @@ resource "superset_dataset" "test" {
 }
 `, tableName, databaseName, sql)
 }
+
+func TestDatasetResource_KeepsServerCatalog(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	databaseID := addSavedQueryDatabase(t, c, "warehouse")
+	r := &datasetResource{client: c}
+
+	// Superset 4.1 fills in the default catalog of the database when a dataset is created.
+	dataset, err := c.CreateDataset(t.Context(), client.DatasetRequest{TableName: "orders", Database: databaseID, Catalog: "examples", Schema: "sales"})
+	require.NoError(t, err)
+
+	state := stateFor(t, r, datasetResourceModel{
+		ID:           types.Int64Value(dataset.ID),
+		TableName:    types.StringValue("orders"),
+		DatabaseName: types.StringValue("warehouse"),
+		Schema:       types.StringValue("sales"),
+		SQL:          types.StringNull(),
+	})
+	readResp := &fwresource.ReadResponse{State: state}
+	r.Read(t.Context(), fwresource.ReadRequest{State: state}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.Equal(state.Raw), "the server catalog is not drift")
+
+	var model datasetResourceModel
+	require.False(t, readResp.State.Get(t.Context(), &model).HasError())
+	model.TableName = types.StringValue("orders_v2")
+	updateResp := &fwresource.UpdateResponse{State: readResp.State}
+	r.Update(t.Context(), fwresource.UpdateRequest{Plan: planFor(t, r, model), State: readResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "%v", updateResp.Diagnostics)
+
+	updated, err := c.GetDataset(t.Context(), dataset.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "orders_v2", updated.TableName)
+	assert.Equal(t, "examples", updated.Catalog, "an update leaves the catalog alone")
+}
@@ -23,7 +23,7 @@ func NewDatasetsDataSource() datasource.DataSource {
 }
 
 type datasetsDataSource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 type datasetsDataSourceModel struct {
@@ -113,7 +113,7 @@ func (d *datasetsDataSource) Read(ctx context.Context, req datasource.ReadReques
 	tflog.Debug(ctx, "Starting Read method")
 
 	// Fetch datasets from the Superset instance
-	datasets, err := d.client.GetAllDatasets()
+	datasets, err := d.client.GetAllDatasets(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Superset Datasets",
@@ -126,133 +126,50 @@ func (d *datasetsDataSource) Read(ctx context.Context, req datasource.ReadReques
 	responseJSON, _ := json.Marshal(datasets)
 	tflog.Debug(ctx, fmt.Sprintf("API Response: %s", responseJSON))
 
+	ownerType := types.ObjectType{AttrTypes: map[string]attr.Type{"id": types.Int64Type, "first_name": types.StringType, "last_name": types.StringType}}
+
 	var datasetsModel []dataset
 	for _, ds := range datasets {
-		tflog.Debug(ctx, fmt.Sprintf("Processing dataset: %v", ds))
+		tflog.Debug(ctx, fmt.Sprintf("Processing dataset: %d %s", ds.ID, ds.TableName))
 
-		id, ok := ds["id"].(float64)
-		if !ok {
+		if ds.Database.ID == 0 {
 			resp.Diagnostics.AddError(
 				"Invalid Response",
-				"Missing or invalid 'id' field in the API response",
+				fmt.Sprintf("Dataset %d has no 'database' in the API response", ds.ID),
 			)
 			return
 		}
-		tflog.Debug(ctx, fmt.Sprintf("Dataset ID: %f", id))
 
-		tableName, ok := ds["table_name"].(string)
-		if !ok {
-			resp.Diagnostics.AddError(
-				"Invalid Response",
-				"Missing or invalid 'table_name' field in the API response",
-			)
-			return
-		}
-		tflog.Debug(ctx, fmt.Sprintf("Table Name: %s", tableName))
-
-		database, ok := ds["database"].(map[string]interface{})
-		if !ok {
-			resp.Diagnostics.AddError(
-				"Invalid Response",
-				"Missing or invalid 'database' field in the API response",
-			)
-			return
-		}
-		tflog.Debug(ctx, fmt.Sprintf("Database Field: %v", database))
-
-		databaseID, ok := database["id"].(float64)
-		if !ok {
-			resp.Diagnostics.AddError(
-				"Invalid Response",
-				"Missing or invalid 'database.id' field in the API response",
-			)
-			return
-		}
-		tflog.Debug(ctx, fmt.Sprintf("Database ID: %f", databaseID))
-
-		databaseName, ok := database["database_name"].(string)
-		if !ok {
-			resp.Diagnostics.AddError(
-				"Invalid Response",
-				"Missing or invalid 'database.database_name' field in the API response",
-			)
-			return
-		}
-		tflog.Debug(ctx, fmt.Sprintf("Database Name: %s", databaseName))
-
-		schema, ok := ds["schema"].(string)
-		if !ok {
-			schema = ""
-		}
-		tflog.Debug(ctx, fmt.Sprintf("Schema: %s", schema))
-
-		sql, ok := ds["sql"].(string)
-		if !ok {
-			sql = ""
-		}
-		tflog.Debug(ctx, fmt.Sprintf("SQL: %s", sql))
-
-		kind, ok := ds["kind"].(string)
-		if !ok {
-			kind = ""
-		}
-		tflog.Debug(ctx, fmt.Sprintf("Kind: %s", kind))
-
-		ownersList := []attr.Value{}
-		owners, ok := ds["owners"].([]interface{})
-		if ok {
-			for _, owner := range owners {
-				ownerMap, ok := owner.(map[string]interface{})
-				if ok {
-					ownerID, ok := ownerMap["id"].(float64)
-					if !ok {
-						ownerID = 0
-					}
-					firstName, ok := ownerMap["first_name"].(string)
-					if !ok {
-						firstName = ""
-					}
-					lastName, ok := ownerMap["last_name"].(string)
-					if !ok {
-						lastName = ""
-					}
-
-					ownerObject := map[string]attr.Value{
-						"id":         types.Int64Value(int64(ownerID)),
-						"first_name": types.StringValue(firstName),
-						"last_name":  types.StringValue(lastName),
-					}
-					ownerVal, diags := types.ObjectValue(map[string]attr.Type{
-						"id":         types.Int64Type,
-						"first_name": types.StringType,
-						"last_name":  types.StringType,
-					}, ownerObject)
-					resp.Diagnostics.Append(diags...)
-					if resp.Diagnostics.HasError() {
-						return
-					}
-					ownersList = append(ownersList, ownerVal)
-				}
+		ownersList := make([]attr.Value, 0, len(ds.Owners))
+		for _, owner := range ds.Owners {
+			ownerVal, diags := types.ObjectValue(ownerType.AttrTypes, map[string]attr.Value{
+				"id":         types.Int64Value(owner.ID),
+				"first_name": types.StringValue(owner.FirstName),
+				"last_name":  types.StringValue(owner.LastName),
+			})
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
 			}
+			ownersList = append(ownersList, ownerVal)
 		}
 
-		ownersAttr, diags := types.ListValue(types.ObjectType{AttrTypes: map[string]attr.Type{"id": types.Int64Type, "first_name": types.StringType, "last_name": types.StringType}}, ownersList)
+		ownersAttr, diags := types.ListValue(ownerType, ownersList)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		dataset := dataset{
-			ID:           types.Int64Value(int64(id)),
-			TableName:    types.StringValue(tableName),
-			DatabaseID:   types.Int64Value(int64(databaseID)),
-			DatabaseName: types.StringValue(databaseName),
-			Schema:       types.StringValue(schema),
-			SQL:          types.StringValue(sql),
-			Kind:         types.StringValue(kind),
+		datasetsModel = append(datasetsModel, dataset{
+			ID:           types.Int64Value(ds.ID),
+			TableName:    types.StringValue(ds.TableName),
+			DatabaseID:   types.Int64Value(ds.Database.ID),
+			DatabaseName: types.StringValue(ds.Database.DatabaseName),
+			Schema:       types.StringValue(ds.Schema),
+			SQL:          types.StringValue(ds.SQL),
+			Kind:         types.StringValue(ds.Kind),
 			Owners:       ownersAttr,
-		}
-		datasetsModel = append(datasetsModel, dataset)
+		})
 	}
 
 	state := datasetsDataSourceModel{
@@ -280,11 +197,11 @@ func (d *datasetsDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-superset/internal/client"
+)
+
+// fakeSuperset is an in-memory client.SupersetAPI for unit tests of resource logic. Only the
+// methods a test exercises are implemented; calling any other method panics on the nil
+// embedded interface, which points straight at the missing fake.
+type fakeSuperset struct {
+	client.SupersetAPI
+
+	capabilities client.Capabilities
+	roles        map[int64]string
+	nextID       int64
+	// err, when set, is returned by every fake method.
+	err error
+}
+
+func newFakeSuperset() *fakeSuperset {
+	return &fakeSuperset{roles: map[int64]string{}, nextID: 1}
+}
+
+func (f *fakeSuperset) Capabilities() client.Capabilities {
+	return f.capabilities
+}
+
+func (f *fakeSuperset) CreateRole(_ context.Context, name string) (int64, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	id := f.nextID
+	f.nextID++
+	f.roles[id] = name
+	return id, nil
+}
+
+func (f *fakeSuperset) GetRole(_ context.Context, id int64) (*client.Role, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	name, ok := f.roles[id]
+	if !ok {
+		return nil, fmt.Errorf("role %d %w", id, client.ErrNotFound)
+	}
+	return &client.Role{ID: id, Name: name}, nil
+}
+
+func (f *fakeSuperset) UpdateRole(_ context.Context, id int64, name string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if _, ok := f.roles[id]; !ok {
+		return fmt.Errorf("role %d %w", id, client.ErrNotFound)
+	}
+	f.roles[id] = name
+	return nil
+}
+
+func (f *fakeSuperset) DeleteRole(_ context.Context, id int64) error {
+	if f.err != nil {
+		return f.err
+	}
+	if _, ok := f.roles[id]; !ok {
+		return fmt.Errorf("role %d %w", id, client.ErrNotFound)
+	}
+	delete(f.roles, id)
+	return nil
+}
+
+// nullPlan returns a null plan with the schema r declares.
+func nullPlan(t *testing.T, r resource.Resource) tfsdk.Plan {
+	t.Helper()
+	var resp resource.SchemaResponse
+	r.Schema(t.Context(), resource.SchemaRequest{}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	return tfsdk.Plan{Schema: resp.Schema, Raw: tftypes.NewValue(resp.Schema.Type().TerraformType(t.Context()), nil)}
+}
+
+// unknownPlan returns a plan for r whose values are all still unknown.
+func unknownPlan(t *testing.T, r resource.Resource) tfsdk.Plan {
+	t.Helper()
+	plan := nullPlan(t, r)
+	plan.Raw = tftypes.NewValue(plan.Raw.Type(), tftypes.UnknownValue)
+	return plan
+}
+
+// planFor builds a plan for r holding model.
+func planFor(t *testing.T, r resource.Resource, model any) tfsdk.Plan {
+	t.Helper()
+	plan := nullPlan(t, r)
+	diags := plan.Set(t.Context(), model)
+	require.False(t, diags.HasError(), "%v", diags)
+	return plan
+}
+
+// stateFor builds a state for r holding model.
+func stateFor(t *testing.T, r resource.Resource, model any) tfsdk.State {
+	t.Helper()
+	plan := planFor(t, r, model)
+	return tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}
+}
+
+// emptyState returns a null state for r, as the framework passes to Create.
+func emptyState(t *testing.T, r resource.Resource) tfsdk.State {
+	t.Helper()
+	plan := nullPlan(t, r)
+	return tfsdk.State{Schema: plan.Schema, Raw: plan.Raw}
+}
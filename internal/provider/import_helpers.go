@@ -2,9 +2,9 @@ package provider
 
 import (
 	"archive/zip"
-	"bytes"
 	"crypto/sha256"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -39,18 +39,17 @@ func compileSkipPatterns(patterns []string) []*regexp.Regexp {
 	return compiled
 }
 
-// zipDirectoryFiltered creates a ZIP of sourceDir including only the specified subdirectory prefixes.
+// zipDirectoryFiltered writes a ZIP of sourceDir to out, including only the specified subdirectory prefixes.
 // It generates a metadata.yaml with the given type and current timestamp.
 // Database overrides are applied to databases/*.yaml files.
 // Files matching skipPatterns are excluded from the ZIP.
-func zipDirectoryFiltered(sourceDir string, overrides map[string]map[string]interface{}, includePrefixes []string, metadataType string, skipPatterns []*regexp.Regexp) ([]byte, error) {
-	var buf bytes.Buffer
-	w := zip.NewWriter(&buf)
+func zipDirectoryFiltered(out io.Writer, sourceDir string, overrides map[string]map[string]interface{}, includePrefixes []string, metadataType string, skipPatterns []*regexp.Regexp) error {
+	w := zip.NewWriter(out)
 	base := filepath.Base(sourceDir)
 
 	// Create root dir entry
 	if _, err := w.Create(base + "/"); err != nil {
-		return nil, err
+		return err
 	}
 
 	err := filepath.WalkDir(sourceDir, func(p string, d fs.DirEntry, err error) error {
@@ -109,27 +108,24 @@ func zipDirectoryFiltered(sourceDir string, overrides map[string]map[string]inte
 		return err
 	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Generate metadata.yaml with overridden type and current timestamp
 	metaContent, err := buildMetadataFromDir(sourceDir, metadataType)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	metaPath := filepath.ToSlash(filepath.Join(base, "metadata.yaml"))
 	f, err := w.Create(metaPath)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if _, err := f.Write(metaContent); err != nil {
-		return nil, err
+		return err
 	}
 
-	if err := w.Close(); err != nil {
-		return nil, err
-	}
-	return buf.Bytes(), nil
+	return w.Close()
 }
 
 // computeFilteredFileHashes computes SHA256 hashes for files in sourceDir matching the given prefixes.
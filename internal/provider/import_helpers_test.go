@@ -89,11 +89,12 @@ func TestComputeFilteredFileHashes_DatabaseOverrides(t *testing.T) {
 func TestZipDirectoryFiltered_Datasets(t *testing.T) {
 	root := setupTestExportDir(t)
 
-	zipData, err := zipDirectoryFiltered(root, nil, []string{"datasets/", "databases/"}, "SqlaTable", nil)
+	var buf bytes.Buffer
+	err := zipDirectoryFiltered(&buf, root, nil, []string{"datasets/", "databases/"}, "SqlaTable", nil)
 	require.NoError(t, err)
-	require.NotEmpty(t, zipData)
+	require.NotEmpty(t, buf.Bytes())
 
-	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
 	require.NoError(t, err)
 
 	// Collect paths relative to the ZIP root dir
@@ -117,10 +118,11 @@ func TestZipDirectoryFiltered_Datasets(t *testing.T) {
 func TestZipDirectoryFiltered_Charts(t *testing.T) {
 	root := setupTestExportDir(t)
 
-	zipData, err := zipDirectoryFiltered(root, nil, []string{"charts/", "datasets/", "databases/"}, "Slice", nil)
+	var buf bytes.Buffer
+	err := zipDirectoryFiltered(&buf, root, nil, []string{"charts/", "datasets/", "databases/"}, "Slice", nil)
 	require.NoError(t, err)
 
-	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
 	require.NoError(t, err)
 
 	var relPaths []string
@@ -142,10 +144,11 @@ func TestZipDirectoryFiltered_Charts(t *testing.T) {
 func TestZipDirectoryFiltered_MetadataType(t *testing.T) {
 	root := setupTestExportDir(t)
 
-	zipData, err := zipDirectoryFiltered(root, nil, []string{"datasets/", "databases/"}, "SqlaTable", nil)
+	var buf bytes.Buffer
+	err := zipDirectoryFiltered(&buf, root, nil, []string{"datasets/", "databases/"}, "SqlaTable", nil)
 	require.NoError(t, err)
 
-	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
 	require.NoError(t, err)
 
 	for _, f := range reader.File {
@@ -171,10 +174,11 @@ func TestZipDirectoryFiltered_DatabaseOverrides(t *testing.T) {
 		"db-uuid-1": {"sqlalchemy_uri": "starrocks://overridden:9030"},
 	}
 
-	zipData, err := zipDirectoryFiltered(root, overrides, []string{"databases/"}, "SqlaTable", nil)
+	var buf bytes.Buffer
+	err := zipDirectoryFiltered(&buf, root, overrides, []string{"databases/"}, "SqlaTable", nil)
 	require.NoError(t, err)
 
-	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
 	require.NoError(t, err)
 
 	for _, f := range reader.File {
@@ -251,10 +255,11 @@ func TestZipDirectoryFiltered_SkipFiles(t *testing.T) {
 	root := setupTestExportDirWithExtraFiles(t)
 
 	skip := compileSkipPatterns([]string{`\.terraform\.lock\.hcl`})
-	zipData, err := zipDirectoryFiltered(root, nil, []string{"datasets/", "databases/"}, "SqlaTable", skip)
+	var buf bytes.Buffer
+	err := zipDirectoryFiltered(&buf, root, nil, []string{"datasets/", "databases/"}, "SqlaTable", skip)
 	require.NoError(t, err)
 
-	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
 	require.NoError(t, err)
 
 	var relPaths []string
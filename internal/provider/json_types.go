@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = normalizedJSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = normalizedJSON{}
+	_ xattr.ValidateableAttribute                = normalizedJSON{}
+)
+
+// normalizedJSONType is a string attribute type holding a JSON document, such as a chart's
+// params. Two documents that differ only in whitespace or key order are semantically equal, so
+// Superset re-serializing a document does not show up as drift.
+type normalizedJSONType struct {
+	basetypes.StringType
+}
+
+// Equal returns true if o is also a normalizedJSONType.
+func (t normalizedJSONType) Equal(o attr.Type) bool {
+	other, ok := o.(normalizedJSONType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// String returns a human readable name of the type.
+func (t normalizedJSONType) String() string {
+	return "normalizedJSONType"
+}
+
+// ValueFromString wraps a string value in a normalizedJSON.
+func (t normalizedJSONType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return normalizedJSON{StringValue: in}, nil
+}
+
+// ValueFromTerraform converts a Terraform value into a normalizedJSON.
+func (t normalizedJSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return normalizedJSON{StringValue: stringValue}, nil
+}
+
+// ValueType returns the value type of this type.
+func (t normalizedJSONType) ValueType(_ context.Context) attr.Value {
+	return normalizedJSON{}
+}
+
+// normalizedJSON is a value of normalizedJSONType.
+type normalizedJSON struct {
+	basetypes.StringValue
+}
+
+// newNormalizedJSONValue returns a known normalizedJSON holding s.
+func newNormalizedJSONValue(s string) normalizedJSON {
+	return normalizedJSON{StringValue: basetypes.NewStringValue(s)}
+}
+
+// newNormalizedJSONNull returns a null normalizedJSON.
+func newNormalizedJSONNull() normalizedJSON {
+	return normalizedJSON{StringValue: basetypes.NewStringNull()}
+}
+
+// newNormalizedJSONUnknown returns an unknown normalizedJSON.
+func newNormalizedJSONUnknown() normalizedJSON {
+	return normalizedJSON{StringValue: basetypes.NewStringUnknown()}
+}
+
+// Type returns a normalizedJSONType.
+func (v normalizedJSON) Type(_ context.Context) attr.Type {
+	return normalizedJSONType{}
+}
+
+// Equal returns true if o is a normalizedJSON holding the same string. Semantic equality is
+// checked separately by StringSemanticEquals.
+func (v normalizedJSON) Equal(o attr.Value) bool {
+	other, ok := o.(normalizedJSON)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both values decode to the same JSON document.
+func (v normalizedJSON) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(normalizedJSON)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	var prior, next any
+	if err := json.Unmarshal([]byte(v.ValueString()), &prior); err != nil {
+		return false, diags
+	}
+	if err := json.Unmarshal([]byte(newValue.ValueString()), &next); err != nil {
+		return false, diags
+	}
+	return reflect.DeepEqual(prior, next), diags
+}
+
+// ValidateAttribute rejects a configured value that is not valid JSON.
+func (v normalizedJSON) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+	if !json.Valid([]byte(v.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON String Value",
+			fmt.Sprintf("A string value was provided that is not valid JSON: %q.", v.ValueString()),
+		)
+	}
+}
+
+// jsonContains reports whether the JSON document doc holds every member of the JSON document
+// subset with an equal value, recursing into nested objects. Arrays and scalars must be equal.
+// Superset adds default keys to some documents, such as a dashboard's json_metadata, when it
+// saves them; comparing with jsonContains keeps those additions from showing up as drift.
+func jsonContains(doc, subset string) bool {
+	var d, s any
+	if err := json.Unmarshal([]byte(doc), &d); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(subset), &s); err != nil {
+		return false
+	}
+	return valueContains(d, s)
+}
+
+func valueContains(doc, subset any) bool {
+	subsetObject, ok := subset.(map[string]any)
+	if !ok {
+		return reflect.DeepEqual(doc, subset)
+	}
+	docObject, ok := doc.(map[string]any)
+	if !ok {
+		return false
+	}
+	for key, value := range subsetObject {
+		docValue, ok := docObject[key]
+		if !ok || !valueContains(docValue, value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizedJSON_SemanticEquals(t *testing.T) {
+	tests := []struct {
+		name  string
+		prior string
+		next  string
+		equal bool
+	}{
+		{name: "identical", prior: `{"a": 1}`, next: `{"a": 1}`, equal: true},
+		{name: "whitespace and key order", prior: `{"a": 1, "b": [1, 2]}`, next: "{\n  \"b\": [1,2],\n  \"a\": 1\n}", equal: true},
+		{name: "different value", prior: `{"a": 1}`, next: `{"a": 2}`, equal: false},
+		{name: "array order matters", prior: `[1, 2]`, next: `[2, 1]`, equal: false},
+		{name: "invalid JSON", prior: `{"a": 1}`, next: `{"a": 1`, equal: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, diags := newNormalizedJSONValue(tt.prior).StringSemanticEquals(t.Context(), newNormalizedJSONValue(tt.next))
+			require.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, tt.equal, equal)
+		})
+	}
+}
+
+func TestNormalizedJSON_ValidateAttribute(t *testing.T) {
+	for value, wantErr := range map[string]bool{`{"row_limit": 100}`: false, `{"row_limit": }`: true} {
+		resp := &xattr.ValidateAttributeResponse{}
+		newNormalizedJSONValue(value).ValidateAttribute(t.Context(), xattr.ValidateAttributeRequest{Path: path.Root("params")}, resp)
+		assert.Equal(t, wantErr, resp.Diagnostics.HasError(), value)
+	}
+
+	resp := &xattr.ValidateAttributeResponse{}
+	newNormalizedJSONNull().ValidateAttribute(t.Context(), xattr.ValidateAttributeRequest{Path: path.Root("params")}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), "null values are not validated")
+}
+
+func TestJSONContains(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		subset   string
+		contains bool
+	}{
+		{name: "added keys", doc: `{"color_scheme": "bnbColors", "refresh_frequency": 0, "label_colors": {}}`, subset: `{"color_scheme": "bnbColors"}`, contains: true},
+		{name: "nested added keys", doc: `{"a": {"b": 1, "c": 2}}`, subset: `{"a": {"b": 1}}`, contains: true},
+		{name: "changed value", doc: `{"color_scheme": "supersetColors"}`, subset: `{"color_scheme": "bnbColors"}`, contains: false},
+		{name: "missing key", doc: `{}`, subset: `{"color_scheme": "bnbColors"}`, contains: false},
+		{name: "arrays must be equal", doc: `{"ids": [1, 2, 3]}`, subset: `{"ids": [1, 2]}`, contains: false},
+		{name: "empty subset", doc: `{"a": 1}`, subset: `{}`, contains: true},
+		{name: "invalid document", doc: ``, subset: `{}`, contains: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.contains, jsonContains(tt.doc, tt.subset))
+		})
+	}
+}
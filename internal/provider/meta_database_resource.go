@@ -32,7 +32,7 @@ func NewMetaDatabaseResource() resource.Resource {
 
 // metaDatabaseResource is the resource implementation.
 type metaDatabaseResource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 // metaDatabaseResourceModel maps the resource schema data.
@@ -145,11 +145,11 @@ func (r *metaDatabaseResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -176,7 +176,7 @@ func (r *metaDatabaseResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	// Check if meta database already exists
-	existingDB, err := r.client.FindMetaDatabaseByName(plan.DatabaseName.ValueString())
+	existingDB, err := r.client.FindMetaDatabaseByName(ctx, plan.DatabaseName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Search for Existing Meta Database",
@@ -237,7 +237,7 @@ func (r *metaDatabaseResource) Create(ctx context.Context, req resource.CreateRe
 			metaDB.IsManagedExternally = false
 		}
 
-		err = r.client.UpdateMetaDatabase(existingDB.ID, metaDB)
+		err = r.client.UpdateMetaDatabase(ctx, existingDB.ID, metaDB)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to Update Superset Meta Database",
@@ -287,7 +287,7 @@ func (r *metaDatabaseResource) Create(ctx context.Context, req resource.CreateRe
 			metaDB.IsManagedExternally = false
 		}
 
-		id, err = r.client.CreateMetaDatabase(metaDB)
+		id, err = r.client.CreateMetaDatabase(ctx, metaDB)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to Create Superset Meta Database",
@@ -332,14 +332,21 @@ func (r *metaDatabaseResource) Read(ctx context.Context, req resource.ReadReques
 	})
 
 	// First try to get by ID from state
-	metaDB, err := r.client.GetMetaDatabase(state.ID.ValueInt64())
+	metaDB, err := r.client.GetMetaDatabase(ctx, state.ID.ValueInt64())
+	if err != nil && !client.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to Read Meta Database",
+			fmt.Sprintf("GetMetaDatabase failed: %s", err.Error()),
+		)
+		return
+	}
 	if err != nil {
 		tflog.Warn(ctx, "Failed to get meta database by ID, trying by name", map[string]interface{}{
 			"error": err.Error(),
 			"id":    state.ID.ValueInt64(),
 		})
 		// Fallback to search by name
-		metaDB, err = r.client.FindMetaDatabaseByName(state.DatabaseName.ValueString())
+		metaDB, err = r.client.FindMetaDatabaseByName(ctx, state.DatabaseName.ValueString())
 		if err != nil {
 			tflog.Error(ctx, "Failed to search for meta database by name", map[string]interface{}{
 				"error": err.Error(),
@@ -457,7 +464,7 @@ func (r *metaDatabaseResource) Update(ctx context.Context, req resource.UpdateRe
 		AllowedDBs:          allowedDBs,
 	}
 
-	err := r.client.UpdateMetaDatabase(plan.ID.ValueInt64(), metaDB)
+	err := r.client.UpdateMetaDatabase(ctx, plan.ID.ValueInt64(), metaDB)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Update Superset Meta Database",
@@ -479,7 +486,7 @@ func (r *metaDatabaseResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	err := r.client.DeleteMetaDatabase(state.ID.ValueInt64())
+	err := r.client.DeleteMetaDatabase(ctx, state.ID.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Delete Superset Meta Database",
@@ -507,7 +514,7 @@ func (r *metaDatabaseResource) ImportState(ctx context.Context, req resource.Imp
 	}
 
 	// Fetch the full resource data from Superset
-	metaDB, err := r.client.GetMetaDatabase(id)
+	metaDB, err := r.client.GetMetaDatabase(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Meta Database During Import",
@@ -2,7 +2,10 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"terraform-provider-superset/internal/client"
 
@@ -40,6 +43,46 @@ type supersetProviderModel struct {
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
 	Provider types.String `tfsdk:"provider"`
+
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait types.String `tfsdk:"retry_max_wait"`
+
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	SerializeImports      types.Bool    `tfsdk:"serialize_imports"`
+	MaxImportBundleMB     types.Int64   `tfsdk:"max_import_bundle_mb"`
+
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	ExtraHeaders       types.Map    `tfsdk:"extra_headers"`
+
+	Auth *supersetProviderAuthModel `tfsdk:"auth"`
+}
+
+// supersetProviderAuthModel maps the auth block. At most one mode may be configured.
+type supersetProviderAuthModel struct {
+	AccessToken types.String                     `tfsdk:"access_token"`
+	OAuth2      *supersetProviderOAuth2Model     `tfsdk:"oauth2"`
+	RemoteUser  *supersetProviderRemoteUserModel `tfsdk:"remote_user"`
+}
+
+// supersetProviderOAuth2Model maps the auth.oauth2 block.
+type supersetProviderOAuth2Model struct {
+	TokenURL     types.String `tfsdk:"token_url"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Scopes       types.List   `tfsdk:"scopes"`
+}
+
+// supersetProviderRemoteUserModel maps the auth.remote_user block.
+type supersetProviderRemoteUserModel struct {
+	Header   types.String `tfsdk:"header"`
+	Username types.String `tfsdk:"username"`
 }
 
 // Metadata returns the provider type name.
@@ -70,6 +113,114 @@ func (p *supersetProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Description: "The authentication provider to use. Valid values are 'db' (database) or 'ldap'. Defaults to 'db'.",
 				Optional:    true,
 			},
+			"max_retries": schema.Int64Attribute{
+				Description: "How many times a request is retried after a transient failure (HTTP 429, 502, 503, 504 or a reset connection). Set to 0 to disable retries. Defaults to 3. Can also be set with the SUPERSET_MAX_RETRIES environment variable.",
+				Optional:    true,
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Description: "The longest single wait between retries, as a Go duration string such as '30s' or '1m'. Backoff grows exponentially with jitter up to this value, and a Retry-After header from Superset is honoured up to this value as well. Defaults to '30s'. Can also be set with the SUPERSET_RETRY_MAX_WAIT environment variable.",
+				Optional:    true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "The most requests the provider has open against Superset at the same time, across all resources, counting a request until its response has been read. Set to 0 for no limit. Defaults to 0. Can also be set with the SUPERSET_MAX_CONCURRENT_REQUESTS environment variable.",
+				Optional:    true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "The most requests per second the provider sends to Superset, with bursts of up to one second's worth. Fractional values such as 0.5 are allowed. Set to 0 for no limit. Defaults to 0. Can also be set with the SUPERSET_REQUESTS_PER_SECOND environment variable.",
+				Optional:    true,
+			},
+			"serialize_imports": schema.BoolAttribute{
+				Description: "Run dashboard, chart and dataset bundle imports one at a time, because Superset's import endpoints are not safe to call concurrently. Defaults to true.",
+				Optional:    true,
+			},
+			"max_import_bundle_mb": schema.Int64Attribute{
+				Description: "The largest dashboard, chart or dataset import bundle the provider uploads, in megabytes. Bundles are zipped while they are streamed to Superset, and an import fails as soon as its ZIP grows past this size. The upload itself is not bound by request_timeout, which only limits the wait for Superset to answer once the bundle has been sent, so large bundles can take as long as the link needs. Defaults to 512. Can also be set with the SUPERSET_MAX_IMPORT_BUNDLE_MB environment variable.",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "The timeout for a single HTTP request to Superset, as a Go duration string such as '120s' or '5m'. For bundle imports it only covers the wait for the response after the upload. Set to '0s' to disable. Defaults to '120s'. Can also be set with the SUPERSET_REQUEST_TIMEOUT environment variable.",
+				Optional:    true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM-encoded certificate authority bundle trusted in addition to the system roots, for Superset instances behind an internal CA. Conflicts with 'ca_cert_file'.",
+				Optional:    true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM-encoded certificate authority bundle trusted in addition to the system roots. Conflicts with 'ca_cert_pem'. Can also be set with the SUPERSET_CA_CERT_FILE environment variable.",
+				Optional:    true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "PEM-encoded client certificate presented to Superset for mutual TLS. Requires 'client_key'.",
+				Optional:    true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM-encoded private key for 'client_cert'.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Skip TLS certificate verification. Only use this against test instances. Defaults to false.",
+				Optional:    true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of an HTTP(S) proxy used for every request to Superset. When unset, the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables apply.",
+				Optional:    true,
+			},
+			"extra_headers": schema.MapAttribute{
+				Description: "Additional HTTP headers sent with every request to Superset, including login and CSRF calls. They are not sent to the OAuth2 token URL.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"auth": schema.SingleNestedBlock{
+				Description: "Alternative authentication for Superset instances that do not allow password logins. Configure exactly one of 'access_token', 'oauth2' or 'remote_user'. When this block is present, 'username', 'password' and 'provider' are ignored.",
+				Attributes: map[string]schema.Attribute{
+					"access_token": schema.StringAttribute{
+						Description: "A pre-issued Superset access token sent as a bearer token. Can also be set with the SUPERSET_ACCESS_TOKEN environment variable, which is also used when neither this block nor a username and password are configured.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"oauth2": schema.SingleNestedBlock{
+						Description: "Obtain an access token with the OAuth2 client-credentials grant.",
+						Attributes: map[string]schema.Attribute{
+							"token_url": schema.StringAttribute{
+								Description: "The token endpoint of the identity provider, for example 'https://keycloak.example.com/realms/bi/protocol/openid-connect/token'.",
+								Optional:    true,
+							},
+							"client_id": schema.StringAttribute{
+								Description: "The OAuth2 client ID.",
+								Optional:    true,
+							},
+							"client_secret": schema.StringAttribute{
+								Description: "The OAuth2 client secret.",
+								Optional:    true,
+								Sensitive:   true,
+							},
+							"scopes": schema.ListAttribute{
+								Description: "Scopes requested with the token.",
+								ElementType: types.StringType,
+								Optional:    true,
+							},
+						},
+					},
+					"remote_user": schema.SingleNestedBlock{
+						Description: "Authenticate through a trusted gateway that sets Superset's AUTH_REMOTE_USER from a request header.",
+						Attributes: map[string]schema.Attribute{
+							"header": schema.StringAttribute{
+								Description: "The header the gateway trusts. Defaults to 'X-Remote-User'.",
+								Optional:    true,
+							},
+							"username": schema.StringAttribute{
+								Description: "The Superset user name sent in the header.",
+								Optional:    true,
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -141,6 +292,174 @@ func (p *supersetProvider) Configure(ctx context.Context, req provider.Configure
 		providerType = "db"
 	}
 
+	maxRetries := int64(client.DefaultMaxRetries)
+	if v := os.Getenv("SUPERSET_MAX_RETRIES"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Superset Max Retries",
+				"The SUPERSET_MAX_RETRIES environment variable must be an integer: "+err.Error(),
+			)
+		}
+		maxRetries = parsed
+	}
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+	if maxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Superset Max Retries",
+			"The max_retries value must be zero or greater.",
+		)
+	}
+
+	retryMaxWait := client.DefaultRetryMaxWait
+	retryMaxWaitRaw := os.Getenv("SUPERSET_RETRY_MAX_WAIT")
+	if !config.RetryMaxWait.IsNull() && !config.RetryMaxWait.IsUnknown() {
+		retryMaxWaitRaw = config.RetryMaxWait.ValueString()
+	}
+	if retryMaxWaitRaw != "" {
+		parsed, err := time.ParseDuration(retryMaxWaitRaw)
+		if err != nil || parsed <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_wait"),
+				"Invalid Superset Retry Max Wait",
+				fmt.Sprintf("The retry_max_wait value %q must be a positive duration such as '30s' or '1m'.", retryMaxWaitRaw),
+			)
+		}
+		retryMaxWait = parsed
+	}
+
+	var maxConcurrentRequests int64
+	if v := os.Getenv("SUPERSET_MAX_CONCURRENT_REQUESTS"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid Superset Max Concurrent Requests",
+				"The SUPERSET_MAX_CONCURRENT_REQUESTS environment variable must be an integer: "+err.Error(),
+			)
+		}
+		maxConcurrentRequests = parsed
+	}
+	if !config.MaxConcurrentRequests.IsNull() && !config.MaxConcurrentRequests.IsUnknown() {
+		maxConcurrentRequests = config.MaxConcurrentRequests.ValueInt64()
+	}
+	if maxConcurrentRequests < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Superset Max Concurrent Requests",
+			"The max_concurrent_requests value must be zero or greater.",
+		)
+	}
+
+	var requestsPerSecond float64
+	if v := os.Getenv("SUPERSET_REQUESTS_PER_SECOND"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("requests_per_second"),
+				"Invalid Superset Requests Per Second",
+				"The SUPERSET_REQUESTS_PER_SECOND environment variable must be a number: "+err.Error(),
+			)
+		}
+		requestsPerSecond = parsed
+	}
+	if !config.RequestsPerSecond.IsNull() && !config.RequestsPerSecond.IsUnknown() {
+		requestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+	if requestsPerSecond < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid Superset Requests Per Second",
+			"The requests_per_second value must be zero or greater.",
+		)
+	}
+
+	serializeImports := true
+	if !config.SerializeImports.IsNull() && !config.SerializeImports.IsUnknown() {
+		serializeImports = config.SerializeImports.ValueBool()
+	}
+
+	maxImportBundleMB := client.DefaultMaxBundleSize >> 20
+	if v := os.Getenv("SUPERSET_MAX_IMPORT_BUNDLE_MB"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_import_bundle_mb"),
+				"Invalid Superset Max Import Bundle Size",
+				"The SUPERSET_MAX_IMPORT_BUNDLE_MB environment variable must be an integer: "+err.Error(),
+			)
+		}
+		maxImportBundleMB = parsed
+	}
+	if !config.MaxImportBundleMB.IsNull() && !config.MaxImportBundleMB.IsUnknown() {
+		maxImportBundleMB = config.MaxImportBundleMB.ValueInt64()
+	}
+	if maxImportBundleMB <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_import_bundle_mb"),
+			"Invalid Superset Max Import Bundle Size",
+			"The max_import_bundle_mb value must be greater than zero.",
+		)
+	}
+
+	requestTimeout := client.DefaultRequestTimeout
+	requestTimeoutRaw := os.Getenv("SUPERSET_REQUEST_TIMEOUT")
+	if !config.RequestTimeout.IsNull() && !config.RequestTimeout.IsUnknown() {
+		requestTimeoutRaw = config.RequestTimeout.ValueString()
+	}
+	if requestTimeoutRaw != "" {
+		parsed, err := time.ParseDuration(requestTimeoutRaw)
+		if err != nil || parsed < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Superset Request Timeout",
+				fmt.Sprintf("The request_timeout value %q must be a duration such as '120s' or '5m'.", requestTimeoutRaw),
+			)
+		}
+		requestTimeout = parsed
+	}
+
+	caCertPEM := config.CACertPEM.ValueString()
+	caCertFile := os.Getenv("SUPERSET_CA_CERT_FILE")
+	if !config.CACertFile.IsNull() {
+		caCertFile = config.CACertFile.ValueString()
+	}
+	if caCertPEM != "" && caCertFile != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Conflicting Superset CA Certificate Settings",
+			"Only one of ca_cert_pem and ca_cert_file may be set.",
+		)
+	}
+	if caCertFile != "" {
+		data, err := os.ReadFile(caCertFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unable to Read Superset CA Certificate",
+				fmt.Sprintf("The CA bundle at %q could not be read: %s", caCertFile, err),
+			)
+		}
+		caCertPEM = string(data)
+	}
+
+	if config.ClientCert.IsNull() != config.ClientKey.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_cert"),
+			"Incomplete Superset Client Certificate",
+			"Both client_cert and client_key must be set to use mutual TLS.",
+		)
+	}
+
+	extraHeaders := map[string]string{}
+	if !config.ExtraHeaders.IsNull() && !config.ExtraHeaders.IsUnknown() {
+		resp.Diagnostics.Append(config.ExtraHeaders.ElementsAs(ctx, &extraHeaders, false)...)
+	}
+
 	// If any of the expected configurations are missing, return errors with provider-specific guidance.
 	if host == "" {
 		resp.Diagnostics.AddAttributeError(
@@ -152,24 +471,27 @@ func (p *supersetProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
-	if username == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("username"),
-			"Missing Superset API Username",
-			"The provider cannot create the Superset API client as there is a missing or empty value for the Superset API username. "+
-				"Set the username value in the configuration or use the SUPERSET_USERNAME environment variable. "+
-				"If either is already set, ensure the value is not empty.",
-		)
-	}
+	authenticator := p.configureAuthenticator(ctx, config, username, password, resp)
+	if authenticator == nil && config.Auth == nil {
+		if username == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("username"),
+				"Missing Superset API Username",
+				"The provider cannot create the Superset API client as there is a missing or empty value for the Superset API username. "+
+					"Set the username value in the configuration or use the SUPERSET_USERNAME environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
 
-	if password == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("password"),
-			"Missing Superset API Password",
-			"The provider cannot create the Superset API client as there is a missing or empty value for the Superset API password. "+
-				"Set the password value in the configuration or use the SUPERSET_PASSWORD environment variable. "+
-				"If either is already set, ensure the value is not empty.",
-		)
+		if password == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password"),
+				"Missing Superset API Password",
+				"The provider cannot create the Superset API client as there is a missing or empty value for the Superset API password. "+
+					"Set the password value in the configuration or use the SUPERSET_PASSWORD environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -186,7 +508,28 @@ func (p *supersetProvider) Configure(ctx context.Context, req provider.Configure
 	tflog.Debug(ctx, "Creating Superset client")
 
 	// Create a new Superset client using the configuration values
-	client, err := client.NewClient(host, username, password, providerType)
+	client, err := client.NewClient(ctx, client.Config{
+		Host:                  host,
+		Username:              username,
+		Password:              password,
+		Provider:              providerType,
+		MaxRetries:            int(maxRetries),
+		RetryMaxWait:          retryMaxWait,
+		MaxConcurrentRequests: int(maxConcurrentRequests),
+		RequestsPerSecond:     requestsPerSecond,
+		SerializeImports:      serializeImports,
+		MaxBundleSize:         maxImportBundleMB << 20,
+		Transport: client.TransportConfig{
+			RequestTimeout:     requestTimeout,
+			CACertPEM:          caCertPEM,
+			ClientCertPEM:      config.ClientCert.ValueString(),
+			ClientKeyPEM:       config.ClientKey.ValueString(),
+			InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+			ProxyURL:           config.ProxyURL.ValueString(),
+		},
+		ExtraHeaders: extraHeaders,
+		Auth:         authenticator,
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Superset API Client",
@@ -201,7 +544,101 @@ func (p *supersetProvider) Configure(ctx context.Context, req provider.Configure
 	resp.DataSourceData = client
 	resp.ResourceData = client
 
-	tflog.Info(ctx, "Configured Superset client", map[string]any{"success": true})
+	tflog.Info(ctx, "Configured Superset client", map[string]any{
+		"success":          true,
+		"superset_version": client.Capabilities().Version.String(),
+	})
+}
+
+// configureAuthenticator returns the Authenticator selected by the auth block or the
+// SUPERSET_ACCESS_TOKEN environment variable, or nil for the default username/password login.
+func (p *supersetProvider) configureAuthenticator(ctx context.Context, config supersetProviderModel, username, password string, resp *provider.ConfigureResponse) client.Authenticator {
+	envToken := os.Getenv("SUPERSET_ACCESS_TOKEN")
+
+	if config.Auth == nil {
+		if username == "" && password == "" && envToken != "" {
+			return &client.AccessTokenAuthenticator{Token: envToken}
+		}
+		return nil
+	}
+
+	auth := config.Auth
+	modes := 0
+	if !auth.AccessToken.IsNull() {
+		modes++
+	}
+	if auth.OAuth2 != nil {
+		modes++
+	}
+	if auth.RemoteUser != nil {
+		modes++
+	}
+	if modes > 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth"),
+			"Conflicting Superset Authentication Modes",
+			"Configure only one of access_token, oauth2 or remote_user in the auth block.",
+		)
+		return nil
+	}
+
+	switch {
+	case auth.OAuth2 != nil:
+		oauth := auth.OAuth2
+		required := []struct {
+			name  string
+			value types.String
+		}{
+			{"token_url", oauth.TokenURL},
+			{"client_id", oauth.ClientID},
+			{"client_secret", oauth.ClientSecret},
+		}
+		for _, field := range required {
+			if field.value.ValueString() == "" {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("auth").AtName("oauth2").AtName(field.name),
+					"Missing Superset OAuth2 Setting",
+					fmt.Sprintf("The %s value is required for OAuth2 client-credentials authentication.", field.name),
+				)
+			}
+		}
+		var scopes []string
+		if !oauth.Scopes.IsNull() && !oauth.Scopes.IsUnknown() {
+			resp.Diagnostics.Append(oauth.Scopes.ElementsAs(ctx, &scopes, false)...)
+		}
+		return &client.OAuth2ClientCredentialsAuthenticator{
+			TokenURL:     oauth.TokenURL.ValueString(),
+			ClientID:     oauth.ClientID.ValueString(),
+			ClientSecret: oauth.ClientSecret.ValueString(),
+			Scopes:       scopes,
+		}
+	case auth.RemoteUser != nil:
+		if auth.RemoteUser.Username.ValueString() == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("auth").AtName("remote_user").AtName("username"),
+				"Missing Superset Remote User",
+				"The username value is required for remote-user authentication.",
+			)
+		}
+		return &client.RemoteUserAuthenticator{
+			Header:   auth.RemoteUser.Header.ValueString(),
+			Username: auth.RemoteUser.Username.ValueString(),
+		}
+	default:
+		token := envToken
+		if !auth.AccessToken.IsNull() {
+			token = auth.AccessToken.ValueString()
+		}
+		if token == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("auth").AtName("access_token"),
+				"Missing Superset Access Token",
+				"The auth block needs one of access_token, oauth2 or remote_user. "+
+					"Set access_token in the configuration or use the SUPERSET_ACCESS_TOKEN environment variable.",
+			)
+		}
+		return &client.AccessTokenAuthenticator{Token: token}
+	}
 }
 
 // DataSources defines the data sources implemented in the provider.
@@ -214,6 +651,7 @@ func (p *supersetProvider) DataSources(_ context.Context) []func() datasource.Da
 		NewDatasetsDataSource,        // New datasets data source
 		NewUsersDataSource,           // New users data source
 		NewCSSTemplateDataSource,     // CSS template data source
+		NewSavedQueriesDataSource,    // Saved queries data source
 	}
 }
 
@@ -232,5 +670,9 @@ func (p *supersetProvider) Resources(_ context.Context) []func() resource.Resour
 		NewDatasetImportResource,      // Dataset import resource
 		NewChartImportResource,        // Chart import resource
 		NewCSSTemplateResource,        // CSS template resource
+		NewChartResource,              // Chart resource
+		NewDashboardResource,          // Dashboard resource
+		NewSavedQueryResource,         // Saved query resource
+		NewReportScheduleResource,     // Report schedule resource
 	}
 }
@@ -1,10 +1,16 @@
 package provider
 
 import (
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
-	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"fmt"
 	"os"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-superset/internal/client"
+	"terraform-provider-superset/internal/testing/fakesuperset"
 )
 
 const providerConfig = `
@@ -15,6 +21,26 @@ provider "superset" {
 }
 `
 
+// fakeProviderConfig points the provider at a fakesuperset server, for acceptance tests that
+// run a whole lifecycle against real in-memory state instead of per-URL mocks.
+func fakeProviderConfig(s *fakesuperset.Server) string {
+	return fmt.Sprintf(`
+provider "superset" {
+  host     = %q
+  username = "admin"
+  password = "admin"
+}
+`, s.URL())
+}
+
+// fakeClient logs a client in to s, for CheckDestroy functions that inspect the fake's state.
+func fakeClient(t *testing.T, s *fakesuperset.Server) *client.Client {
+	t.Helper()
+	c, err := client.NewClient(t.Context(), client.Config{Host: s.URL(), Username: "admin", Password: "admin"})
+	require.NoError(t, err)
+	return c
+}
+
 var (
 	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 		"superset": providerserver.NewProtocol6WithError(New("test")()),
@@ -0,0 +1,600 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &reportScheduleResource{}
+	_ resource.ResourceWithConfigure      = &reportScheduleResource{}
+	_ resource.ResourceWithImportState    = &reportScheduleResource{}
+	_ resource.ResourceWithValidateConfig = &reportScheduleResource{}
+)
+
+// NewReportScheduleResource is a helper function to simplify the provider implementation.
+func NewReportScheduleResource() resource.Resource {
+	return &reportScheduleResource{}
+}
+
+// reportScheduleResource is the resource implementation.
+type reportScheduleResource struct {
+	client client.SupersetAPI
+}
+
+// reportScheduleResourceModel maps the resource schema data.
+type reportScheduleResourceModel struct {
+	ID                  types.Int64    `tfsdk:"id"`
+	Type                types.String   `tfsdk:"type"`
+	Name                types.String   `tfsdk:"name"`
+	Description         types.String   `tfsdk:"description"`
+	Active              types.Bool     `tfsdk:"active"`
+	Crontab             types.String   `tfsdk:"crontab"`
+	Timezone            types.String   `tfsdk:"timezone"`
+	DashboardID         types.Int64    `tfsdk:"dashboard_id"`
+	ChartID             types.Int64    `tfsdk:"chart_id"`
+	DatabaseName        types.String   `tfsdk:"database_name"`
+	SQL                 types.String   `tfsdk:"sql"`
+	ValidatorType       types.String   `tfsdk:"validator_type"`
+	ValidatorConfigJSON normalizedJSON `tfsdk:"validator_config_json"`
+	Recipients          types.Set      `tfsdk:"recipients"`
+	WorkingTimeout      types.Int64    `tfsdk:"working_timeout"`
+	LogRetention        types.Int64    `tfsdk:"log_retention"`
+	GracePeriod         types.Int64    `tfsdk:"grace_period"`
+	ReportFormat        types.String   `tfsdk:"report_format"`
+}
+
+// reportRecipientModel maps a recipients block.
+type reportRecipientModel struct {
+	Type   types.String `tfsdk:"type"`
+	Target types.String `tfsdk:"target"`
+}
+
+var reportRecipientType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"type":   types.StringType,
+	"target": types.StringType,
+}}
+
+// stringOneOfValidator validates that a string is one of a fixed set of values.
+type stringOneOfValidator struct {
+	values []string
+}
+
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be one of: '%s'", strings.Join(v.values, "', '"))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(v.values, req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Value",
+			fmt.Sprintf("Value must be one of '%s', got: %q.", strings.Join(v.values, "', '"), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// Metadata returns the resource type name.
+func (r *reportScheduleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_report_schedule"
+}
+
+// Schema defines the schema for the resource.
+func (r *reportScheduleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an alert or a scheduled report in Superset. Reports send a dashboard or chart on a schedule; alerts run a SQL query on a schedule and send the dashboard or chart when its result passes the validator.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the report schedule.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Kind of schedule: 'Alert' or 'Report'.",
+				Required:    true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{"Alert", "Report"}},
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the schedule, unique among schedules of the same type.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the schedule.",
+				Optional:    true,
+			},
+			"active": schema.BoolAttribute{
+				Description: "Whether the schedule runs. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"crontab": schema.StringAttribute{
+				Description: "When the schedule runs, as a five-field crontab expression such as '0 9 * * mon-fri', or a macro such as '@daily'. The syntax is checked at plan time.",
+				Required:    true,
+				Validators: []validator.String{
+					crontabValidator{},
+				},
+			},
+			"timezone": schema.StringAttribute{
+				Description: "Time zone the crontab is evaluated in, for example 'Europe/Berlin'. Defaults to 'UTC'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("UTC"),
+			},
+			"dashboard_id": schema.Int64Attribute{
+				Description: "ID of the dashboard the schedule sends. Set exactly one of dashboard_id and chart_id.",
+				Optional:    true,
+			},
+			"chart_id": schema.Int64Attribute{
+				Description: "ID of the chart the schedule sends. Set exactly one of dashboard_id and chart_id.",
+				Optional:    true,
+			},
+			"database_name": schema.StringAttribute{
+				Description: "Name of the database the alert query runs against. Required for alerts, not allowed for reports.",
+				Optional:    true,
+			},
+			"sql": schema.StringAttribute{
+				Description: "SQL query the alert evaluates. Required for alerts, not allowed for reports.",
+				Optional:    true,
+			},
+			"validator_type": schema.StringAttribute{
+				Description: "How the alert query result is checked: 'not null' triggers when the query returns a non-null, non-zero value; 'operator' compares it against validator_config_json. Required for alerts, not allowed for reports.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{"not null", "operator"}},
+				},
+			},
+			"validator_config_json": schema.StringAttribute{
+				Description: "Condition of an 'operator' alert as a JSON object, for example '{\"op\": \">\", \"threshold\": 10}'. Differences in whitespace and key order are ignored.",
+				CustomType:  normalizedJSONType{},
+				Optional:    true,
+			},
+			"working_timeout": schema.Int64Attribute{
+				Description: "Seconds a run may take before it is stopped. Defaults to 3600.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(3600),
+			},
+			"log_retention": schema.Int64Attribute{
+				Description: "Days the execution log is kept. Defaults to 90.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(90),
+			},
+			"grace_period": schema.Int64Attribute{
+				Description: "Seconds after an alert triggers during which it does not notify again. Defaults to 14400.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(14400),
+			},
+			"report_format": schema.StringAttribute{
+				Description: "Format of the content sent: 'PNG', 'PDF', 'CSV' or 'TEXT'. CSV and TEXT need a chart. Defaults to 'PNG'.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("PNG"),
+				Validators: []validator.String{
+					stringOneOfValidator{values: []string{"PNG", "PDF", "CSV", "TEXT"}},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"recipients": schema.SetNestedBlock{
+				Description: "Where the schedule sends its notifications. Repeat the block for each recipient.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "Notification method: 'Email', 'Slack' or 'SlackV2'.",
+							Required:    true,
+							Validators: []validator.String{
+								stringOneOfValidator{values: []string{"Email", "Slack", "SlackV2"}},
+							},
+						},
+						"target": schema.StringAttribute{
+							Description: "Comma-separated email addresses for 'Email', or channel names for Slack.",
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the rules Superset enforces on the combination of attributes, so that
+// they fail at plan time instead of during apply.
+func (r *reportScheduleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config reportScheduleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.DashboardID.IsUnknown() && !config.ChartID.IsUnknown() && config.DashboardID.IsNull() == config.ChartID.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dashboard_id"),
+			"Invalid Report Schedule Target",
+			"Set exactly one of dashboard_id and chart_id.",
+		)
+	}
+	if !config.DashboardID.IsNull() && (config.ReportFormat.ValueString() == "CSV" || config.ReportFormat.ValueString() == "TEXT") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("report_format"),
+			"Invalid Report Format",
+			fmt.Sprintf("report_format %q is only available for charts; dashboards are sent as PNG or PDF.", config.ReportFormat.ValueString()),
+		)
+	}
+
+	alertOnly := map[string]attr.Value{
+		"database_name":         config.DatabaseName,
+		"sql":                   config.SQL,
+		"validator_type":        config.ValidatorType,
+		"validator_config_json": config.ValidatorConfigJSON,
+	}
+	switch config.Type.ValueString() {
+	case "Alert":
+		for _, name := range []string{"database_name", "sql", "validator_type"} {
+			if alertOnly[name].IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"Missing Alert Attribute",
+					name+" is required for alerts.",
+				)
+			}
+		}
+		if config.ValidatorType.ValueString() == "operator" && config.ValidatorConfigJSON.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("validator_config_json"),
+				"Missing Alert Attribute",
+				"validator_config_json is required when validator_type is 'operator'.",
+			)
+		}
+	case "Report":
+		for name, value := range alertOnly {
+			if !value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"Invalid Report Attribute",
+					name+" only applies to alerts.",
+				)
+			}
+		}
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *reportScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan reportScheduleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reportReq, diags := r.request(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating report schedule", map[string]interface{}{
+		"type": reportReq.Type,
+		"name": reportReq.Name,
+	})
+
+	id, err := r.client.CreateReportSchedule(ctx, reportReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating report schedule",
+			"Could not create report schedule: "+err.Error(),
+		)
+		return
+	}
+
+	// Save the ID right away so a failed read-back does not leak the report schedule.
+	plan.ID = types.Int64Value(id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+
+	report, err := r.client.GetReportSchedule(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading report schedule",
+			"Could not read report schedule ID "+fmt.Sprintf("%d", id)+" after creating it: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(plan.apply(ctx, report)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Created report schedule", map[string]interface{}{
+		"id": id,
+	})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *reportScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state reportScheduleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	report, err := r.client.GetReportSchedule(ctx, state.ID.ValueInt64())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Report schedule ID %d not found, removing from state", state.ID.ValueInt64()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading report schedule",
+			"Could not read report schedule ID "+fmt.Sprintf("%d", state.ID.ValueInt64())+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.apply(ctx, report)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *reportScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan reportScheduleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reportReq, diags := r.request(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.UpdateReportSchedule(ctx, plan.ID.ValueInt64(), reportReq); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating report schedule",
+			"Could not update report schedule ID "+fmt.Sprintf("%d", plan.ID.ValueInt64())+": "+err.Error(),
+		)
+		return
+	}
+
+	report, err := r.client.GetReportSchedule(ctx, plan.ID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading report schedule",
+			"Could not read report schedule ID "+fmt.Sprintf("%d", plan.ID.ValueInt64())+" after updating it: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(plan.apply(ctx, report)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updated report schedule", map[string]interface{}{
+		"id": plan.ID.ValueInt64(),
+	})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *reportScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state reportScheduleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteReportSchedule(ctx, state.ID.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting report schedule",
+			"Could not delete report schedule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted report schedule", map[string]interface{}{
+		"id": state.ID.ValueInt64(),
+	})
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *reportScheduleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.SupersetAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ImportState imports a report schedule by its numeric ID.
+func (r *reportScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing report schedule",
+			fmt.Sprintf("Import ID '%s' is not a numeric report schedule ID.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// request resolves the alert database and builds the create or update payload from the model.
+// The whole schedule is sent, so removing an attribute from the configuration clears it.
+func (r *reportScheduleResource) request(ctx context.Context, m *reportScheduleResourceModel) (client.ReportScheduleRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	reportReq := client.ReportScheduleRequest{
+		Type:           m.Type.ValueString(),
+		Name:           m.Name.ValueString(),
+		Description:    m.Description.ValueString(),
+		Active:         m.Active.ValueBool(),
+		Crontab:        m.Crontab.ValueString(),
+		Timezone:       m.Timezone.ValueString(),
+		Dashboard:      m.DashboardID.ValueInt64Pointer(),
+		Chart:          m.ChartID.ValueInt64Pointer(),
+		SQL:            m.SQL.ValueStringPointer(),
+		ValidatorType:  m.ValidatorType.ValueStringPointer(),
+		WorkingTimeout: m.WorkingTimeout.ValueInt64(),
+		LogRetention:   m.LogRetention.ValueInt64(),
+		GracePeriod:    m.GracePeriod.ValueInt64(),
+		ReportFormat:   m.ReportFormat.ValueString(),
+	}
+	// Superset keeps the previous condition when none is sent, so an unset one is sent empty.
+	reportReq.ValidatorConfigJSON = json.RawMessage(`{}`)
+	if !m.ValidatorConfigJSON.IsNull() {
+		reportReq.ValidatorConfigJSON = json.RawMessage(m.ValidatorConfigJSON.ValueString())
+	}
+
+	if !m.DatabaseName.IsNull() {
+		databaseID, err := r.client.GetDatabaseIDByName(ctx, m.DatabaseName.ValueString())
+		if err != nil {
+			diags.AddError(
+				"Error finding database",
+				fmt.Sprintf("Could not find database '%s': %s", m.DatabaseName.ValueString(), err.Error()),
+			)
+			return reportReq, diags
+		}
+		reportReq.Database = &databaseID
+	}
+
+	var recipients []reportRecipientModel
+	diags.Append(m.Recipients.ElementsAs(ctx, &recipients, false)...)
+	reportReq.Recipients = make([]client.ReportRecipientRequest, 0, len(recipients))
+	for _, recipient := range recipients {
+		reportReq.Recipients = append(reportReq.Recipients, client.ReportRecipientRequest{
+			Type:                recipient.Type.ValueString(),
+			RecipientConfigJSON: client.ReportRecipientConfig{Target: recipient.Target.ValueString()},
+		})
+	}
+
+	return reportReq, diags
+}
+
+// apply copies a report schedule as Superset returned it into the model, so that changes made
+// outside Terraform show up as drift.
+func (m *reportScheduleResourceModel) apply(ctx context.Context, report *client.ReportSchedule) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.ID = types.Int64Value(report.ID)
+	m.Type = types.StringValue(report.Type)
+	m.Name = types.StringValue(report.Name)
+	m.Active = types.BoolValue(report.Active)
+	m.Crontab = types.StringValue(report.Crontab)
+	m.Timezone = types.StringValue(report.Timezone)
+	m.WorkingTimeout = types.Int64Value(report.WorkingTimeout)
+	m.LogRetention = types.Int64Value(report.LogRetention)
+	m.GracePeriod = types.Int64Value(report.GracePeriod)
+	m.ReportFormat = types.StringValue(report.ReportFormat)
+
+	m.Description = optionalString(report.Description, m.Description)
+	m.SQL = optionalString(report.SQL, m.SQL)
+	m.ValidatorType = optionalString(report.ValidatorType, m.ValidatorType)
+
+	m.DashboardID = types.Int64Null()
+	if report.Dashboard != nil {
+		m.DashboardID = types.Int64Value(report.Dashboard.ID)
+	}
+	m.ChartID = types.Int64Null()
+	if report.Chart != nil {
+		m.ChartID = types.Int64Value(report.Chart.ID)
+	}
+	m.DatabaseName = types.StringNull()
+	if report.Database != nil {
+		m.DatabaseName = types.StringValue(report.Database.DatabaseName)
+	}
+
+	// Superset stores "{}" as the validator configuration of reports and "not null" alerts.
+	var validatorConfig map[string]any
+	if err := report.ValidatorConfigJSON.Decode(&validatorConfig); err != nil {
+		diags.AddError(
+			"Invalid Report Schedule",
+			fmt.Sprintf("Report schedule ID %d has a validator_config_json that is not a JSON object: %s", report.ID, err),
+		)
+		return diags
+	}
+	if len(validatorConfig) == 0 && m.ValidatorConfigJSON.IsNull() {
+		m.ValidatorConfigJSON = newNormalizedJSONNull()
+	} else {
+		m.ValidatorConfigJSON = newNormalizedJSONValue(report.ValidatorConfigJSON.String())
+	}
+
+	recipients := make([]reportRecipientModel, 0, len(report.Recipients))
+	for _, recipient := range report.Recipients {
+		var config client.ReportRecipientConfig
+		if err := recipient.RecipientConfigJSON.Decode(&config); err != nil {
+			diags.AddError(
+				"Invalid Report Schedule",
+				fmt.Sprintf("Report schedule ID %d has a %s recipient whose configuration is not a JSON object: %s", report.ID, recipient.Type, err),
+			)
+			return diags
+		}
+		recipients = append(recipients, reportRecipientModel{
+			Type:   types.StringValue(recipient.Type),
+			Target: types.StringValue(config.Target),
+		})
+	}
+	recipientSet, d := types.SetValueFrom(ctx, reportRecipientType, recipients)
+	diags.Append(d...)
+	m.Recipients = recipientSet
+
+	return diags
+}
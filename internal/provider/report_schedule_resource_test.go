@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-superset/internal/client"
+	"terraform-provider-superset/internal/testing/fakesuperset"
+)
+
+// addReportTargets creates the "warehouse" database with a chart and a dashboard on the fake
+// server for schedules to send.
+func addReportTargets(t *testing.T, c *client.Client) (chartID, dashboardID int64) {
+	t.Helper()
+	datasetID := addChartDataset(t, c)
+	chartID, err := c.CreateChart(t.Context(), client.ChartRequest{SliceName: "Orders", VizType: "table", DatasourceID: datasetID, DatasourceType: "table"})
+	require.NoError(t, err)
+	dashboardID, err = c.CreateDashboard(t.Context(), client.DashboardRequest{DashboardTitle: "Sales"})
+	require.NoError(t, err)
+	return chartID, dashboardID
+}
+
+func reportRecipients(t *testing.T, recipients ...reportRecipientModel) types.Set {
+	t.Helper()
+	set, diags := types.SetValueFrom(t.Context(), reportRecipientType, recipients)
+	require.False(t, diags.HasError(), "%v", diags)
+	return set
+}
+
+// alertModel returns an operator alert on a chart, with the defaults Terraform would plan.
+func alertModel(t *testing.T, chartID int64) reportScheduleResourceModel {
+	return reportScheduleResourceModel{
+		ID:                  types.Int64Unknown(),
+		Type:                types.StringValue("Alert"),
+		Name:                types.StringValue("Too many late orders"),
+		Description:         types.StringNull(),
+		Active:              types.BoolValue(true),
+		Crontab:             types.StringValue("*/15 * * * *"),
+		Timezone:            types.StringValue("UTC"),
+		DashboardID:         types.Int64Null(),
+		ChartID:             types.Int64Value(chartID),
+		DatabaseName:        types.StringValue("warehouse"),
+		SQL:                 types.StringValue("SELECT count(*) FROM orders WHERE late"),
+		ValidatorType:       types.StringValue("operator"),
+		ValidatorConfigJSON: newNormalizedJSONValue(`{"op": ">", "threshold": 10}`),
+		Recipients:          reportRecipients(t, reportRecipientModel{Type: types.StringValue("Email"), Target: types.StringValue("ops@example.com")}),
+		WorkingTimeout:      types.Int64Value(3600),
+		LogRetention:        types.Int64Value(90),
+		GracePeriod:         types.Int64Value(14400),
+		ReportFormat:        types.StringValue("PNG"),
+	}
+}
+
+func TestAccReportScheduleResource_FakeSuperset(t *testing.T) {
+	fake := fakesuperset.New(t)
+	chartID, dashboardID := addReportTargets(t, fakeClient(t, fake))
+
+	alert := func(crontab string) string {
+		return fakeProviderConfig(fake) + fmt.Sprintf(`
+resource "superset_report_schedule" "test" {
+  type                  = "Alert"
+  name                  = "Too many late orders"
+  crontab               = %q
+  chart_id              = %d
+  database_name         = "warehouse"
+  sql                   = "SELECT count(*) FROM orders WHERE late"
+  validator_type        = "operator"
+  validator_config_json = jsonencode({ op = ">", threshold = 10 })
+
+  recipients {
+    type   = "Email"
+    target = "ops@example.com"
+  }
+}
+`, crontab, chartID)
+	}
+	report := fakeProviderConfig(fake) + fmt.Sprintf(`
+resource "superset_report_schedule" "test" {
+  type          = "Report"
+  name          = "Weekly sales"
+  crontab       = "0 9 * * mon"
+  timezone      = "Europe/Berlin"
+  dashboard_id  = %d
+  report_format = "PDF"
+  log_retention = 30
+
+  recipients {
+    type   = "Email"
+    target = "sales@example.com"
+  }
+
+  recipients {
+    type   = "Slack"
+    target = "#sales"
+  }
+}
+`, dashboardID)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			_, err := fakeClient(t, fake).GetReportSchedule(t.Context(), 1)
+			if !client.IsNotFound(err) {
+				return fmt.Errorf("report schedule left after destroy: %v", err)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      alert("*/15 * * *"),
+				ExpectError: regexp.MustCompile(`expected 5 fields`),
+			},
+			{
+				Config: alert("*/15 * * * *"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_report_schedule.test", "id", "1"),
+					resource.TestCheckResourceAttr("superset_report_schedule.test", "active", "true"),
+					resource.TestCheckResourceAttr("superset_report_schedule.test", "timezone", "UTC"),
+					resource.TestCheckResourceAttr("superset_report_schedule.test", "recipients.#", "1"),
+				),
+			},
+			{
+				Config: report,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_report_schedule.test", "type", "Report"),
+					resource.TestCheckNoResourceAttr("superset_report_schedule.test", "chart_id"),
+					resource.TestCheckNoResourceAttr("superset_report_schedule.test", "validator_config_json"),
+					resource.TestCheckResourceAttr("superset_report_schedule.test", "recipients.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "superset_report_schedule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestReportScheduleResource_ValidateConfig(t *testing.T) {
+	r := &reportScheduleResource{}
+	tests := []struct {
+		name    string
+		modify  func(m *reportScheduleResourceModel)
+		wantErr string
+	}{
+		{
+			name:   "valid alert",
+			modify: func(*reportScheduleResourceModel) {},
+		},
+		{
+			name: "valid report",
+			modify: func(m *reportScheduleResourceModel) {
+				m.Type = types.StringValue("Report")
+				m.DatabaseName = types.StringNull()
+				m.SQL = types.StringNull()
+				m.ValidatorType = types.StringNull()
+				m.ValidatorConfigJSON = newNormalizedJSONNull()
+			},
+		},
+		{
+			name: "unknown target",
+			modify: func(m *reportScheduleResourceModel) {
+				m.ChartID = types.Int64Unknown()
+			},
+		},
+		{
+			name: "no target",
+			modify: func(m *reportScheduleResourceModel) {
+				m.ChartID = types.Int64Null()
+			},
+			wantErr: "Set exactly one of dashboard_id and chart_id",
+		},
+		{
+			name: "two targets",
+			modify: func(m *reportScheduleResourceModel) {
+				m.DashboardID = types.Int64Value(2)
+			},
+			wantErr: "Set exactly one of dashboard_id and chart_id",
+		},
+		{
+			name: "alert without SQL",
+			modify: func(m *reportScheduleResourceModel) {
+				m.SQL = types.StringNull()
+			},
+			wantErr: "sql is required for alerts",
+		},
+		{
+			name: "operator alert without condition",
+			modify: func(m *reportScheduleResourceModel) {
+				m.ValidatorConfigJSON = newNormalizedJSONNull()
+			},
+			wantErr: "validator_config_json is required when validator_type is 'operator'",
+		},
+		{
+			name: "report with SQL",
+			modify: func(m *reportScheduleResourceModel) {
+				m.Type = types.StringValue("Report")
+				m.DatabaseName = types.StringNull()
+				m.ValidatorType = types.StringNull()
+				m.ValidatorConfigJSON = newNormalizedJSONNull()
+			},
+			wantErr: "sql only applies to alerts",
+		},
+		{
+			name: "CSV of a dashboard",
+			modify: func(m *reportScheduleResourceModel) {
+				m.ChartID = types.Int64Null()
+				m.DashboardID = types.Int64Value(2)
+				m.ReportFormat = types.StringValue("CSV")
+			},
+			wantErr: `report_format "CSV" is only available for charts`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := alertModel(t, 1)
+			tt.modify(&m)
+			state := stateFor(t, r, m)
+			resp := &fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(t.Context(), fwresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}, resp)
+			if tt.wantErr == "" {
+				assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+				return
+			}
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), tt.wantErr)
+		})
+	}
+}
+
+func TestReportScheduleResource_ReadDetectsDrift(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	chartID, dashboardID := addReportTargets(t, c)
+	r := &reportScheduleResource{client: c}
+
+	createResp := &fwresource.CreateResponse{State: emptyState(t, r)}
+	r.Create(t.Context(), fwresource.CreateRequest{Plan: planFor(t, r, alertModel(t, chartID))}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+	var created reportScheduleResourceModel
+	require.False(t, createResp.State.Get(t.Context(), &created).HasError())
+	assert.True(t, created.Description.IsNull())
+	assert.True(t, created.DashboardID.IsNull())
+	assert.Equal(t, "warehouse", created.DatabaseName.ValueString())
+	assert.Len(t, created.Recipients.Elements(), 1)
+
+	// Someone turns the alert into a report in the Superset UI.
+	require.NoError(t, c.UpdateReportSchedule(t.Context(), created.ID.ValueInt64(), client.ReportScheduleRequest{
+		Type:                "Report",
+		Name:                "Sales digest",
+		Description:         "Edited by hand",
+		Crontab:             "0 8 * * *",
+		Timezone:            "UTC",
+		Dashboard:           &dashboardID,
+		ValidatorConfigJSON: json.RawMessage(`{}`),
+		Recipients:          []client.ReportRecipientRequest{{Type: "Slack", RecipientConfigJSON: client.ReportRecipientConfig{Target: "#sales"}}},
+		WorkingTimeout:      3600,
+		LogRetention:        90,
+		GracePeriod:         14400,
+		ReportFormat:        "PDF",
+	}))
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(t.Context(), fwresource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+
+	var refreshed reportScheduleResourceModel
+	require.False(t, readResp.State.Get(t.Context(), &refreshed).HasError())
+	assert.Equal(t, "Report", refreshed.Type.ValueString())
+	assert.Equal(t, "Edited by hand", refreshed.Description.ValueString())
+	assert.False(t, refreshed.Active.ValueBool())
+	assert.Equal(t, "0 8 * * *", refreshed.Crontab.ValueString())
+	assert.True(t, refreshed.ChartID.IsNull())
+	assert.Equal(t, dashboardID, refreshed.DashboardID.ValueInt64())
+	assert.True(t, refreshed.DatabaseName.IsNull())
+	assert.Equal(t, "PDF", refreshed.ReportFormat.ValueString())
+	assert.JSONEq(t, `{}`, refreshed.ValidatorConfigJSON.ValueString(),
+		"the cleared condition shows as drift while the configuration still sets one")
+	assert.Equal(t, []attr.Value{types.ObjectValueMust(reportRecipientType.AttrTypes, map[string]attr.Value{
+		"type":   types.StringValue("Slack"),
+		"target": types.StringValue("#sales"),
+	})}, refreshed.Recipients.Elements())
+
+	require.NoError(t, c.DeleteReportSchedule(t.Context(), created.ID.ValueInt64()))
+	readResp = &fwresource.ReadResponse{State: readResp.State}
+	r.Read(t.Context(), fwresource.ReadRequest{State: readResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull())
+}
+
+func TestReportScheduleResource_ImportState(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	_, dashboardID := addReportTargets(t, c)
+	r := &reportScheduleResource{client: c}
+
+	id, err := c.CreateReportSchedule(t.Context(), client.ReportScheduleRequest{
+		Type: "Report", Name: "Weekly sales", Active: true, Crontab: "0 9 * * 1", Timezone: "UTC", Dashboard: &dashboardID,
+		WorkingTimeout: 3600, LogRetention: 90, GracePeriod: 14400, ReportFormat: "PNG",
+	})
+	require.NoError(t, err)
+
+	resp := &fwresource.ImportStateResponse{State: emptyState(t, r)}
+	r.ImportState(t.Context(), fwresource.ImportStateRequest{ID: fmt.Sprint(id)}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var imported types.Int64
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("id"), &imported).HasError())
+	assert.Equal(t, id, imported.ValueInt64())
+
+	// A report read for the first time has no validator condition, although Superset stores "{}".
+	readResp := &fwresource.ReadResponse{State: resp.State}
+	r.Read(t.Context(), fwresource.ReadRequest{State: resp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	var read reportScheduleResourceModel
+	require.False(t, readResp.State.Get(t.Context(), &read).HasError())
+	assert.True(t, read.ValidatorConfigJSON.IsNull())
+	assert.Equal(t, "Weekly sales", read.Name.ValueString())
+
+	resp = &fwresource.ImportStateResponse{State: emptyState(t, r)}
+	r.ImportState(t.Context(), fwresource.ImportStateRequest{ID: "Weekly sales"}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "not a numeric report schedule ID")
+}
+
+func TestReportScheduleResource_UpdateAlertToReport(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	chartID, dashboardID := addReportTargets(t, c)
+	r := &reportScheduleResource{client: c}
+
+	createResp := &fwresource.CreateResponse{State: emptyState(t, r)}
+	r.Create(t.Context(), fwresource.CreateRequest{Plan: planFor(t, r, alertModel(t, chartID))}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+	var created reportScheduleResourceModel
+	require.False(t, createResp.State.Get(t.Context(), &created).HasError())
+	plan := created
+	plan.Type = types.StringValue("Report")
+	plan.ChartID = types.Int64Null()
+	plan.DashboardID = types.Int64Value(dashboardID)
+	plan.DatabaseName = types.StringNull()
+	plan.SQL = types.StringNull()
+	plan.ValidatorType = types.StringNull()
+	plan.ValidatorConfigJSON = newNormalizedJSONNull()
+
+	updateResp := &fwresource.UpdateResponse{State: createResp.State}
+	r.Update(t.Context(), fwresource.UpdateRequest{Plan: planFor(t, r, plan), State: createResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "%v", updateResp.Diagnostics)
+
+	// The alert settings Superset would otherwise keep are cleared, so the next plan is empty.
+	var updated reportScheduleResourceModel
+	require.False(t, updateResp.State.Get(t.Context(), &updated).HasError())
+	assert.Equal(t, plan, updated)
+
+	report, err := c.GetReportSchedule(t.Context(), created.ID.ValueInt64())
+	require.NoError(t, err)
+	assert.Empty(t, report.SQL)
+	assert.Nil(t, report.Database)
+	assert.JSONEq(t, `{}`, report.ValidatorConfigJSON.String())
+}
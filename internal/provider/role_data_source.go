@@ -20,7 +20,7 @@ func NewRoleDataSource() datasource.DataSource {
 }
 
 type roleDataSource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 type roleDataSourceModel struct {
@@ -57,7 +57,7 @@ func (d *roleDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	roleID, err := d.client.GetRoleIDByName(data.Name.ValueString())
+	roleID, err := d.client.GetRoleIDByName(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Superset Role",
@@ -77,11 +77,11 @@ func (d *roleDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -14,7 +14,7 @@ func TestAccRoleDataSource(t *testing.T) {
 	httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
 		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
 
-	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page:0,page_size:100)",
 		httpmock.NewStringResponder(200, `{
 			"result": [
 				{"id": 1, "name": "Admin"},
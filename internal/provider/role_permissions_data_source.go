@@ -23,7 +23,7 @@ func NewRolePermissionsDataSource() datasource.DataSource {
 
 // rolePermissionsDataSource is the data source implementation.
 type rolePermissionsDataSource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 // rolePermissionsDataSourceModel maps the data source schema data.
@@ -87,7 +87,7 @@ func (d *rolePermissionsDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
-	roleID, err := d.client.GetRoleIDByName(state.RoleName.ValueString())
+	roleID, err := d.client.GetRoleIDByName(ctx, state.RoleName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Find Role",
@@ -96,7 +96,7 @@ func (d *rolePermissionsDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
-	permissions, err := d.client.GetRolePermissions(roleID)
+	permissions, err := d.client.GetRolePermissions(ctx, roleID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Superset Role Permissions",
@@ -123,11 +123,11 @@ func (d *rolePermissionsDataSource) Configure(_ context.Context, req datasource.
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
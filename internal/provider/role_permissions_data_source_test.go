@@ -16,7 +16,7 @@ func TestAccRolePermissionsDataSource(t *testing.T) {
 		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
 
 	// Mock the Superset API response for getting role ID by name
-	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page:0,page_size:100)",
 		httpmock.NewStringResponder(200, `{"result": [{"id": 1, "name": "DWH-DB-Connect"}]}`))
 
 	// Mock the Superset API response for getting role permissions
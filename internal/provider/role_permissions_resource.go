@@ -31,7 +31,7 @@ func NewRolePermissionsResource() resource.Resource {
 
 // rolePermissionsResource is the resource implementation.
 type rolePermissionsResource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 // rolePermissionsResourceModel maps the resource schema data.
@@ -116,7 +116,7 @@ func (r *rolePermissionsResource) Create(ctx context.Context, req resource.Creat
 	})
 
 	// Get the role ID based on role name
-	roleID, err := r.client.GetRoleIDByName(plan.RoleName.ValueString())
+	roleID, err := r.client.GetRoleIDByName(ctx, plan.RoleName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error finding role",
@@ -129,18 +129,24 @@ func (r *rolePermissionsResource) Create(ctx context.Context, req resource.Creat
 		"roleID": roleID,
 	})
 
-	// Prepare permission IDs from plan using a map to ensure unique IDs
+	// Resolve all permission IDs at once; the map ensures unique IDs
+	pairs := make([]client.PermissionPair, 0, len(plan.ResourcePermissions))
+	for _, perm := range plan.ResourcePermissions {
+		pairs = append(pairs, client.PermissionPair{Permission: perm.Permission.ValueString(), ViewMenu: perm.ViewMenu.ValueString()})
+	}
+	resolved, err := r.client.ResolvePermissions(ctx, pairs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error finding permission ID",
+			fmt.Sprintf("Could not resolve the permissions of role '%s': %s", plan.RoleName.ValueString(), err),
+		)
+		return
+	}
+
 	var resourcePermissions []resourcePermissionModel
 	permissionIDs := map[int64]bool{}
-	for _, perm := range plan.ResourcePermissions {
-		permID, err := r.client.GetPermissionIDByNameAndView(perm.Permission.ValueString(), perm.ViewMenu.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error finding permission ID",
-				fmt.Sprintf("Could not find permission ID for '%s' and view '%s': %s", perm.Permission.ValueString(), perm.ViewMenu.ValueString(), err),
-			)
-			return
-		}
+	for i, perm := range plan.ResourcePermissions {
+		permID := resolved[pairs[i]]
 		permissionIDs[permID] = true
 		resourcePermissions = append(resourcePermissions, resourcePermissionModel{
 			ID:         types.Int64Value(permID),
@@ -164,7 +170,7 @@ func (r *rolePermissionsResource) Create(ctx context.Context, req resource.Creat
 	})
 
 	// Update role permissions using the client
-	if err := r.client.UpdateRolePermissions(roleID, permIDList); err != nil {
+	if err := r.client.UpdateRolePermissions(ctx, roleID, permIDList); err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating role permissions",
 			"Failed to update role permissions: "+err.Error(),
@@ -209,8 +215,13 @@ func (r *rolePermissionsResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	// Get role ID
-	roleID, err := r.client.GetRoleIDByName(state.RoleName.ValueString())
+	roleID, err := r.client.GetRoleIDByName(ctx, state.RoleName.ValueString())
 	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Role '%s' not found, removing its permissions from state", state.RoleName.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error finding role",
 			fmt.Sprintf("Could not find role '%s': %s", state.RoleName.ValueString(), err),
@@ -219,7 +230,7 @@ func (r *rolePermissionsResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	// Get all permissions from Superset
-	permissions, err := r.client.GetRolePermissions(roleID)
+	permissions, err := r.client.GetRolePermissions(ctx, roleID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading role permissions",
@@ -274,7 +285,7 @@ func (r *rolePermissionsResource) Update(ctx context.Context, req resource.Updat
 	})
 
 	// Get the role ID based on role name
-	roleID, err := r.client.GetRoleIDByName(plan.RoleName.ValueString())
+	roleID, err := r.client.GetRoleIDByName(ctx, plan.RoleName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error finding role",
@@ -287,18 +298,24 @@ func (r *rolePermissionsResource) Update(ctx context.Context, req resource.Updat
 		"roleID": roleID,
 	})
 
-	// Prepare permission IDs from plan using a map to ensure unique IDs
+	// Resolve all permission IDs at once; the map ensures unique IDs
+	pairs := make([]client.PermissionPair, 0, len(plan.ResourcePermissions))
+	for _, perm := range plan.ResourcePermissions {
+		pairs = append(pairs, client.PermissionPair{Permission: perm.Permission.ValueString(), ViewMenu: perm.ViewMenu.ValueString()})
+	}
+	resolved, err := r.client.ResolvePermissions(ctx, pairs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error finding permission ID",
+			fmt.Sprintf("Could not resolve the permissions of role '%s': %s", plan.RoleName.ValueString(), err),
+		)
+		return
+	}
+
 	var resourcePermissions []resourcePermissionModel
 	permissionIDs := map[int64]bool{}
-	for _, perm := range plan.ResourcePermissions {
-		permID, err := r.client.GetPermissionIDByNameAndView(perm.Permission.ValueString(), perm.ViewMenu.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error finding permission ID",
-				fmt.Sprintf("Could not find permission ID for '%s' and view '%s': %s", perm.Permission.ValueString(), perm.ViewMenu.ValueString(), err),
-			)
-			return
-		}
+	for i, perm := range plan.ResourcePermissions {
+		permID := resolved[pairs[i]]
 		permissionIDs[permID] = true
 		resourcePermissions = append(resourcePermissions, resourcePermissionModel{
 			ID:         types.Int64Value(permID),
@@ -322,7 +339,7 @@ func (r *rolePermissionsResource) Update(ctx context.Context, req resource.Updat
 	})
 
 	// Update role permissions using the client
-	if err := r.client.UpdateRolePermissions(roleID, permIDList); err != nil {
+	if err := r.client.UpdateRolePermissions(ctx, roleID, permIDList); err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating role permissions",
 			"Failed to update role permissions: "+err.Error(),
@@ -373,8 +390,13 @@ func (r *rolePermissionsResource) Delete(ctx context.Context, req resource.Delet
 		"roleName": state.RoleName.ValueString(),
 	})
 
-	roleID, err := r.client.GetRoleIDByName(state.RoleName.ValueString())
+	roleID, err := r.client.GetRoleIDByName(ctx, state.RoleName.ValueString())
 	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Role '%s' not found, removing its permissions from state", state.RoleName.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error finding role",
 			fmt.Sprintf("Could not find role '%s': %s", state.RoleName.ValueString(), err),
@@ -386,7 +408,7 @@ func (r *rolePermissionsResource) Delete(ctx context.Context, req resource.Delet
 		"roleID": roleID,
 	})
 
-	err = r.client.ClearRolePermissions(roleID)
+	err = r.client.ClearRolePermissions(ctx, roleID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error clearing role permissions",
@@ -407,11 +429,11 @@ func (r *rolePermissionsResource) Configure(_ context.Context, req resource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -435,7 +457,7 @@ func (r *rolePermissionsResource) ImportState(ctx context.Context, req resource.
 		return
 	}
 
-	role, err := r.client.GetRole(roleID)
+	role, err := r.client.GetRole(ctx, roleID)
 	if err != nil {
 		resp.Diagnostics.AddError("Error fetching role", fmt.Sprintf("Could not fetch role with ID '%d': %s", roleID, err))
 		return
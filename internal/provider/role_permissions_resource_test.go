@@ -19,7 +19,7 @@ func TestAccRolePermissionsResource(t *testing.T) {
 		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles/129",
 			httpmock.NewStringResponder(200, `{"result": {"id": 129, "name": "DWH-DB-Connect"}}`))
 
-		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page:0,page_size:100)",
 			httpmock.NewStringResponder(200, `{
 				"result": [
 					{"id": 129, "name": "DWH-DB-Connect"}
@@ -103,7 +103,7 @@ resource "superset_role_permissions" "team" {
 		httpmock.RegisterResponder("POST", "http://superset-host/api/v1/security/login",
 			httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
 
-		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+		httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page:0,page_size:100)",
 			httpmock.NewStringResponder(200, `{
 				"result": [
 					{"id": 129, "name": "DWH-DB-Connect"}
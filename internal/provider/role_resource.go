@@ -31,7 +31,7 @@ func NewRoleResource() resource.Resource {
 
 // roleResource is the resource implementation.
 type roleResource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 // roleResourceModel maps the resource schema data.
@@ -86,7 +86,7 @@ func (r *roleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	id, err := r.client.CreateRole(plan.Name.ValueString())
+	id, err := r.client.CreateRole(ctx, plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Superset Role",
@@ -123,8 +123,13 @@ func (r *roleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	role, err := r.client.GetRole(state.ID.ValueInt64())
+	role, err := r.client.GetRole(ctx, state.ID.ValueInt64())
 	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Role ID %d not found, removing from state", state.ID.ValueInt64()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading role",
 			fmt.Sprintf("Could not read role ID %d: %s", state.ID.ValueInt64(), err.Error()),
@@ -169,7 +174,7 @@ func (r *roleResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	if plan.Name != state.Name {
 		// Only update if there is a real change
-		err := r.client.UpdateRole(state.ID.ValueInt64(), plan.Name.ValueString())
+		err := r.client.UpdateRole(ctx, state.ID.ValueInt64(), plan.Name.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to update role", "Error: "+err.Error())
 			return
@@ -195,9 +200,9 @@ func (r *roleResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.client.DeleteRole(state.ID.ValueInt64())
+	err := r.client.DeleteRole(ctx, state.ID.ValueInt64())
 	if err != nil {
-		if err.Error() == "failed to delete role, status code: 404" {
+		if client.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			tflog.Debug(ctx, fmt.Sprintf("Role ID %d not found, removing from state", state.ID.ValueInt64()))
 			return
@@ -243,11 +248,11 @@ func (r *roleResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
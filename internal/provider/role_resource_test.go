@@ -1,10 +1,19 @@
 package provider
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-superset/internal/testing/fakesuperset"
 )
 
 func TestAccRoleResource(t *testing.T) {
@@ -17,7 +26,7 @@ func TestAccRoleResource(t *testing.T) {
 		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
 
 	// Mock the Superset API response for checking if role exists (for GetRoleIDByName)
-	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page:0,page_size:100)",
 		httpmock.NewStringResponder(200, `{"result": [{"id": 1, "name": "Antifraud"}]}`))
 
 	// Mock the Superset API response for creating roles
@@ -61,3 +70,104 @@ resource "superset_role" "team_antifraud" {
   name = "Antifraud"
 }
 `
+
+func TestAccRoleResource_FakeSuperset(t *testing.T) {
+	fake := fakesuperset.New(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			roles, err := fakeClient(t, fake).FetchRoles(t.Context())
+			if err != nil {
+				return err
+			}
+			if len(roles) > 0 {
+				return fmt.Errorf("roles left after destroy: %v", roles)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig(fake) + testAccRoleResourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_role.team_antifraud", "name", "Antifraud"),
+					resource.TestCheckResourceAttr("superset_role.team_antifraud", "id", "1"),
+				),
+			},
+			{
+				Config: fakeProviderConfig(fake) + `
+resource "superset_role" "team_antifraud" {
+  name = "Antifraud Team"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_role.team_antifraud", "name", "Antifraud Team"),
+					resource.TestCheckResourceAttr("superset_role.team_antifraud", "id", "1"),
+				),
+			},
+			{
+				ResourceName:            "superset_role.team_antifraud",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+		},
+	})
+}
+
+func TestRoleResource_Lifecycle(t *testing.T) {
+	fake := newFakeSuperset()
+	r := &roleResource{client: fake}
+
+	createResp := &fwresource.CreateResponse{State: emptyState(t, r)}
+	r.Create(t.Context(), fwresource.CreateRequest{
+		Plan: planFor(t, r, roleResourceModel{ID: types.Int64Unknown(), Name: types.StringValue("Analyst"), LastUpdated: types.StringUnknown()}),
+	}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+	var created roleResourceModel
+	require.False(t, createResp.State.Get(t.Context(), &created).HasError())
+	assert.Equal(t, int64(1), created.ID.ValueInt64())
+	assert.Equal(t, "Analyst", fake.roles[1])
+
+	updated := created
+	updated.Name = types.StringValue("Senior Analyst")
+	updateResp := &fwresource.UpdateResponse{State: createResp.State}
+	r.Update(t.Context(), fwresource.UpdateRequest{
+		Plan:  planFor(t, r, updated),
+		State: createResp.State,
+	}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "%v", updateResp.Diagnostics)
+	assert.Equal(t, "Senior Analyst", fake.roles[1])
+
+	deleteResp := &fwresource.DeleteResponse{State: updateResp.State}
+	r.Delete(t.Context(), fwresource.DeleteRequest{State: updateResp.State}, deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "%v", deleteResp.Diagnostics)
+	assert.Empty(t, fake.roles)
+	assert.True(t, deleteResp.State.Raw.IsNull())
+}
+
+func TestRoleResource_ReadRemovesDeletedRole(t *testing.T) {
+	r := &roleResource{client: newFakeSuperset()}
+	state := stateFor(t, r, roleResourceModel{ID: types.Int64Value(42), Name: types.StringValue("Gone"), LastUpdated: types.StringNull()})
+
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(t.Context(), fwresource.ReadRequest{State: state}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull())
+}
+
+func TestRoleResource_ReadReportsOtherErrors(t *testing.T) {
+	fake := newFakeSuperset()
+	fake.err = errors.New("connection refused")
+	r := &roleResource{client: fake}
+	state := stateFor(t, r, roleResourceModel{ID: types.Int64Value(1), Name: types.StringValue("Analyst"), LastUpdated: types.StringNull()})
+
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(t.Context(), fwresource.ReadRequest{State: state}, resp)
+
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "connection refused")
+	assert.False(t, resp.State.Raw.IsNull())
+}
@@ -23,7 +23,7 @@ func NewRolesDataSource() datasource.DataSource {
 
 // rolesDataSource is the data source implementation.
 type rolesDataSource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 // rolesDataSourceModel maps the data source schema data.
@@ -71,7 +71,7 @@ func (d *rolesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 func (d *rolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state rolesDataSourceModel
 
-	roles, err := d.client.FetchRoles()
+	roles, err := d.client.FetchRoles(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Superset Roles",
@@ -97,11 +97,11 @@ func (d *rolesDataSource) Configure(_ context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -17,7 +17,7 @@ func TestAccRolesDataSource(t *testing.T) {
 		httpmock.NewStringResponder(200, `{"access_token": "fake-token"}`))
 
 	// Mock the Superset API response for fetching roles
-	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page_size:5000)",
+	httpmock.RegisterResponder("GET", "http://superset-host/api/v1/security/roles?q=(page:0,page_size:100)",
 		httpmock.NewStringResponder(200, `{
 			"result": [
 				{"id": 1, "name": "Admin"},
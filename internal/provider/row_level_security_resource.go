@@ -19,6 +19,7 @@ var (
 	_ resource.Resource                = &rowLevelSecurityResource{}
 	_ resource.ResourceWithConfigure   = &rowLevelSecurityResource{}
 	_ resource.ResourceWithImportState = &rowLevelSecurityResource{}
+	_ resource.ResourceWithModifyPlan  = &rowLevelSecurityResource{}
 )
 
 func NewRowLevelSecurityResource() resource.Resource {
@@ -26,7 +27,7 @@ func NewRowLevelSecurityResource() resource.Resource {
 }
 
 type rowLevelSecurityResource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 type rowLevelSecurityResourceModel struct {
@@ -123,7 +124,7 @@ func (r *rowLevelSecurityResource) Create(ctx context.Context, req resource.Crea
 		filterType = plan.FilterType.ValueString()
 	}
 
-	rlsID, err := r.client.CreateRowLevelSecurity(
+	rlsID, err := r.client.CreateRowLevelSecurity(ctx,
 		plan.Name.ValueString(),
 		tables,
 		plan.Clause.ValueString(),
@@ -161,8 +162,13 @@ func (r *rowLevelSecurityResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	rls, err := r.client.GetRowLevelSecurity(state.ID.ValueInt64())
+	rls, err := r.client.GetRowLevelSecurity(ctx, state.ID.ValueInt64())
 	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("RLS rule ID %d not found, removing from state", state.ID.ValueInt64()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading RLS rule",
 			"Could not read RLS rule ID "+fmt.Sprintf("%d", state.ID.ValueInt64())+": "+err.Error(),
@@ -194,6 +200,14 @@ func (r *rowLevelSecurityResource) Read(ctx context.Context, req resource.ReadRe
 	resp.Diagnostics.Append(diags...)
 }
 
+// ModifyPlan fails the plan early when the server predates the row level security API.
+func (r *rowLevelSecurityResource) ModifyPlan(_ context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	requireFeature(r.client, client.FeatureRowLevelSecurityAPI, &resp.Diagnostics)
+}
+
 func (r *rowLevelSecurityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan rowLevelSecurityResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -223,7 +237,7 @@ func (r *rowLevelSecurityResource) Update(ctx context.Context, req resource.Upda
 		filterType = plan.FilterType.ValueString()
 	}
 
-	err := r.client.UpdateRowLevelSecurity(
+	err := r.client.UpdateRowLevelSecurity(ctx,
 		plan.ID.ValueInt64(),
 		plan.Name.ValueString(),
 		tables,
@@ -257,7 +271,7 @@ func (r *rowLevelSecurityResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
-	err := r.client.DeleteRowLevelSecurity(state.ID.ValueInt64())
+	err := r.client.DeleteRowLevelSecurity(ctx, state.ID.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting RLS rule",
@@ -276,11 +290,11 @@ func (r *rowLevelSecurityResource) Configure(_ context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
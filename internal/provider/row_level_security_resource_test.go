@@ -3,8 +3,13 @@ package provider
 import (
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-superset/internal/client"
 )
 
 func TestAccRowLevelSecurityResource(t *testing.T) {
@@ -78,3 +83,35 @@ resource "superset_row_level_security" "test" {
 		},
 	})
 }
+
+func TestRowLevelSecurityResource_ModifyPlanChecksVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version client.Version
+		wantErr bool
+	}{
+		{name: "supported", version: client.Version{Major: 4, Minor: 1, Raw: "4.1.0"}},
+		{name: "unknown version", version: client.Version{}},
+		{name: "first release with the API", version: client.Version{Major: 2, Minor: 1, Raw: "2.1.0"}},
+		{name: "too old", version: client.Version{Major: 2, Minor: 0, Patch: 1, Raw: "2.0.1"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeSuperset()
+			fake.capabilities = client.Capabilities{Version: tt.version}
+			r := &rowLevelSecurityResource{client: fake}
+
+			resp := &fwresource.ModifyPlanResponse{Plan: unknownPlan(t, r)}
+			r.ModifyPlan(t.Context(), fwresource.ModifyPlanRequest{Plan: resp.Plan}, resp)
+
+			if !tt.wantErr {
+				assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+				return
+			}
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, "Unsupported Superset version", resp.Diagnostics.Errors()[0].Summary())
+			assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "requires Superset >= 2.1")
+		})
+	}
+}
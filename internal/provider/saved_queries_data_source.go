@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &savedQueriesDataSource{}
+	_ datasource.DataSourceWithConfigure = &savedQueriesDataSource{}
+)
+
+// NewSavedQueriesDataSource is a helper function to simplify the provider implementation.
+func NewSavedQueriesDataSource() datasource.DataSource {
+	return &savedQueriesDataSource{}
+}
+
+// savedQueriesDataSource is the data source implementation.
+type savedQueriesDataSource struct {
+	client client.SupersetAPI
+}
+
+// savedQueriesDataSourceModel maps the data source schema data.
+type savedQueriesDataSourceModel struct {
+	LabelContains types.String      `tfsdk:"label_contains"`
+	DatabaseName  types.String      `tfsdk:"database_name"`
+	Schema        types.String      `tfsdk:"schema"`
+	SavedQueries  []savedQueryModel `tfsdk:"saved_queries"`
+}
+
+// savedQueryModel maps the saved query schema data.
+type savedQueryModel struct {
+	ID                 types.Int64  `tfsdk:"id"`
+	Label              types.String `tfsdk:"label"`
+	Description        types.String `tfsdk:"description"`
+	DatabaseID         types.Int64  `tfsdk:"database_id"`
+	DatabaseName       types.String `tfsdk:"database_name"`
+	Catalog            types.String `tfsdk:"catalog"`
+	Schema             types.String `tfsdk:"schema"`
+	SQL                types.String `tfsdk:"sql"`
+	TemplateParameters types.String `tfsdk:"template_parameters"`
+}
+
+// Metadata returns the data source type name.
+func (d *savedQueriesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_saved_queries"
+}
+
+// Schema defines the schema for the data source.
+func (d *savedQueriesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches SQL Lab saved queries from Superset, optionally filtered by label, database and schema.",
+		Attributes: map[string]schema.Attribute{
+			"label_contains": schema.StringAttribute{
+				Description: "Only return saved queries whose label contains this text (case-insensitive).",
+				Optional:    true,
+			},
+			"database_name": schema.StringAttribute{
+				Description: "Only return saved queries running against the database with this name.",
+				Optional:    true,
+			},
+			"schema": schema.StringAttribute{
+				Description: "Only return saved queries running in this schema.",
+				Optional:    true,
+			},
+			"saved_queries": schema.ListNestedAttribute{
+				Description: "List of saved queries, ordered by ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "Numeric identifier of the saved query.",
+							Computed:    true,
+						},
+						"label": schema.StringAttribute{
+							Description: "Name of the saved query.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the saved query.",
+							Computed:    true,
+						},
+						"database_id": schema.Int64Attribute{
+							Description: "ID of the database the query runs against.",
+							Computed:    true,
+						},
+						"database_name": schema.StringAttribute{
+							Description: "Name of the database the query runs against.",
+							Computed:    true,
+						},
+						"catalog": schema.StringAttribute{
+							Description: "Catalog the query runs in.",
+							Computed:    true,
+						},
+						"schema": schema.StringAttribute{
+							Description: "Schema the query runs in.",
+							Computed:    true,
+						},
+						"sql": schema.StringAttribute{
+							Description: "SQL text of the query.",
+							Computed:    true,
+						},
+						"template_parameters": schema.StringAttribute{
+							Description: "Jinja template parameters of the query as a JSON object.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *savedQueriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data savedQueriesDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filters []client.Filter
+	if !data.LabelContains.IsNull() {
+		filters = append(filters, client.Filter{Col: "label", Opr: "ct", Value: data.LabelContains.ValueString()})
+	}
+	if !data.DatabaseName.IsNull() {
+		databaseID, err := d.client.GetDatabaseIDByName(ctx, data.DatabaseName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error finding database",
+				fmt.Sprintf("Could not find database '%s': %s", data.DatabaseName.ValueString(), err.Error()),
+			)
+			return
+		}
+		filters = append(filters, client.Filter{Col: "database", Opr: "rel_o_m", Value: databaseID})
+	}
+	if !data.Schema.IsNull() {
+		filters = append(filters, client.Filter{Col: "schema", Opr: "eq", Value: data.Schema.ValueString()})
+	}
+
+	queries, err := d.client.ListSavedQueries(ctx, filters...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Superset Saved Queries",
+			fmt.Sprintf("ListSavedQueries failed: %s", err.Error()),
+		)
+		return
+	}
+
+	data.SavedQueries = make([]savedQueryModel, 0, len(queries))
+	for _, q := range queries {
+		data.SavedQueries = append(data.SavedQueries, savedQueryModel{
+			ID:                 types.Int64Value(q.ID),
+			Label:              types.StringValue(q.Label),
+			Description:        types.StringValue(q.Description),
+			DatabaseID:         types.Int64Value(q.Database.ID),
+			DatabaseName:       types.StringValue(q.Database.DatabaseName),
+			Catalog:            types.StringValue(q.Catalog),
+			Schema:             types.StringValue(q.Schema),
+			SQL:                types.StringValue(q.SQL),
+			TemplateParameters: types.StringValue(q.TemplateParameters),
+		})
+	}
+
+	tflog.Debug(ctx, "Fetched saved queries", map[string]interface{}{
+		"saved_queries_count": len(data.SavedQueries),
+		"filters":             len(filters),
+	})
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *savedQueriesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.SupersetAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
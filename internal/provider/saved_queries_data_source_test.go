@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-superset/internal/client"
+	"terraform-provider-superset/internal/testing/fakesuperset"
+)
+
+// addSavedQueries creates two databases and three saved queries on the fake server.
+func addSavedQueries(t *testing.T, c *client.Client) {
+	t.Helper()
+	warehouse := addSavedQueryDatabase(t, c, "warehouse")
+	analytics := addSavedQueryDatabase(t, c, "analytics")
+	sales, reporting := "sales", "reporting"
+	for _, q := range []client.SavedQueryRequest{
+		{DatabaseID: warehouse, Label: "Orders by day", Schema: &sales, SQL: "SELECT 1"},
+		{DatabaseID: warehouse, Label: "Refunds", Schema: &sales, SQL: "SELECT 2"},
+		{DatabaseID: analytics, Label: "Orders by region", Schema: &reporting, SQL: "SELECT 3"},
+	} {
+		_, err := c.CreateSavedQuery(t.Context(), q)
+		require.NoError(t, err)
+	}
+}
+
+func TestSavedQueriesDataSource_Filters(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	addSavedQueries(t, c)
+	d := &savedQueriesDataSource{client: c}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(t.Context(), datasource.SchemaRequest{}, &schemaResp)
+
+	read := func(filters savedQueriesDataSourceModel) []string {
+		t.Helper()
+		// Config has no setter, so the configuration is built as a state and copied over.
+		configured := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(t.Context()), nil)}
+		require.False(t, configured.Set(t.Context(), &filters).HasError())
+		config := tfsdk.Config{Schema: schemaResp.Schema, Raw: configured.Raw}
+		resp := &datasource.ReadResponse{State: configured}
+		d.Read(t.Context(), datasource.ReadRequest{Config: config}, resp)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+		var state savedQueriesDataSourceModel
+		require.False(t, resp.State.Get(t.Context(), &state).HasError())
+		labels := make([]string, 0, len(state.SavedQueries))
+		for _, q := range state.SavedQueries {
+			labels = append(labels, q.Label.ValueString())
+		}
+		return labels
+	}
+	filters := func(label, database, schema *string) savedQueriesDataSourceModel {
+		return savedQueriesDataSourceModel{
+			LabelContains: types.StringPointerValue(label),
+			DatabaseName:  types.StringPointerValue(database),
+			Schema:        types.StringPointerValue(schema),
+		}
+	}
+	str := func(s string) *string { return &s }
+
+	assert.Equal(t, []string{"Orders by day", "Refunds", "Orders by region"}, read(filters(nil, nil, nil)))
+	assert.Equal(t, []string{"Orders by day", "Orders by region"}, read(filters(str("orders"), nil, nil)))
+	assert.Equal(t, []string{"Orders by day"}, read(filters(str("orders"), str("warehouse"), nil)))
+	assert.Equal(t, []string{"Orders by region"}, read(filters(nil, nil, str("reporting"))))
+	assert.Empty(t, read(filters(str("orders"), str("warehouse"), str("reporting"))))
+}
+
+func TestAccSavedQueriesDataSource_FakeSuperset(t *testing.T) {
+	fake := fakesuperset.New(t)
+	addSavedQueries(t, fakeClient(t, fake))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig(fake) + `
+data "superset_saved_queries" "test" {
+  label_contains = "orders"
+  database_name  = "analytics"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.superset_saved_queries.test", "saved_queries.#", "1"),
+					resource.TestCheckResourceAttr("data.superset_saved_queries.test", "saved_queries.0.label", "Orders by region"),
+					resource.TestCheckResourceAttr("data.superset_saved_queries.test", "saved_queries.0.database_name", "analytics"),
+					resource.TestCheckResourceAttr("data.superset_saved_queries.test", "saved_queries.0.schema", "reporting"),
+					resource.TestCheckResourceAttr("data.superset_saved_queries.test", "saved_queries.0.sql", "SELECT 3"),
+				),
+			},
+			{
+				Config: fakeProviderConfig(fake) + `
+data "superset_saved_queries" "test" {
+  database_name = "missing"
+}
+`,
+				ExpectError: regexp.MustCompile(`Could not find database 'missing'`),
+			},
+		},
+	})
+}
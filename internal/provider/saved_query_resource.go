@@ -0,0 +1,334 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-superset/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &savedQueryResource{}
+	_ resource.ResourceWithConfigure   = &savedQueryResource{}
+	_ resource.ResourceWithImportState = &savedQueryResource{}
+	_ resource.ResourceWithModifyPlan  = &savedQueryResource{}
+)
+
+// NewSavedQueryResource is a helper function to simplify the provider implementation.
+func NewSavedQueryResource() resource.Resource {
+	return &savedQueryResource{}
+}
+
+// savedQueryResource is the resource implementation.
+type savedQueryResource struct {
+	client client.SupersetAPI
+}
+
+// savedQueryResourceModel maps the resource schema data.
+type savedQueryResourceModel struct {
+	ID                 types.Int64    `tfsdk:"id"`
+	Label              types.String   `tfsdk:"label"`
+	Description        types.String   `tfsdk:"description"`
+	DatabaseName       types.String   `tfsdk:"database_name"`
+	Catalog            types.String   `tfsdk:"catalog"`
+	Schema             types.String   `tfsdk:"schema"`
+	SQL                types.String   `tfsdk:"sql"`
+	TemplateParameters normalizedJSON `tfsdk:"template_parameters"`
+}
+
+// Metadata returns the resource type name.
+func (r *savedQueryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_saved_query"
+}
+
+// Schema defines the schema for the resource.
+func (r *savedQueryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a SQL Lab saved query in Superset.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Numeric identifier of the saved query.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"label": schema.StringAttribute{
+				Description: "Name of the saved query.",
+				Required:    true,
+			},
+			"description": schema.StringAttribute{
+				Description: "Description of the saved query.",
+				Optional:    true,
+			},
+			"database_name": schema.StringAttribute{
+				Description: "Name of the database the query runs against.",
+				Required:    true,
+			},
+			"catalog": schema.StringAttribute{
+				Description: "Catalog the query runs in, for databases that support multiple catalogs. Requires Superset >= 4.1.",
+				Optional:    true,
+			},
+			"schema": schema.StringAttribute{
+				Description: "Schema the query runs in.",
+				Optional:    true,
+			},
+			"sql": schema.StringAttribute{
+				Description: "SQL text of the query.",
+				Required:    true,
+			},
+			"template_parameters": schema.StringAttribute{
+				Description: "Jinja template parameters of the query as a JSON object. Differences in whitespace and key order are ignored.",
+				CustomType:  normalizedJSONType{},
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *savedQueryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan savedQueryResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	queryReq, err := r.request(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error finding database",
+			fmt.Sprintf("Could not find database '%s': %s", plan.DatabaseName.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Creating saved query", map[string]interface{}{
+		"label":       queryReq.Label,
+		"database_id": queryReq.DatabaseID,
+	})
+
+	id, err := r.client.CreateSavedQuery(ctx, queryReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating saved query",
+			"Could not create saved query: "+err.Error(),
+		)
+		return
+	}
+
+	// Save the ID right away so a failed read-back does not leak the saved query.
+	plan.ID = types.Int64Value(id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+
+	query, err := r.client.GetSavedQuery(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading saved query",
+			"Could not read saved query ID "+fmt.Sprintf("%d", id)+" after creating it: "+err.Error(),
+		)
+		return
+	}
+	plan.apply(query)
+
+	tflog.Debug(ctx, "Created saved query", map[string]interface{}{
+		"id": id,
+	})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *savedQueryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state savedQueryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query, err := r.client.GetSavedQuery(ctx, state.ID.ValueInt64())
+	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Saved query ID %d not found, removing from state", state.ID.ValueInt64()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading saved query",
+			"Could not read saved query ID "+fmt.Sprintf("%d", state.ID.ValueInt64())+": "+err.Error(),
+		)
+		return
+	}
+
+	state.apply(query)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ModifyPlan fails the plan early when a catalog is set and the server predates catalogs.
+func (r *savedQueryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var catalog types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("catalog"), &catalog)...)
+	if resp.Diagnostics.HasError() || catalog.IsNull() || catalog.IsUnknown() {
+		return
+	}
+	requireFeature(r.client, client.FeatureCatalog, &resp.Diagnostics)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *savedQueryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state savedQueryResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	queryReq, err := r.request(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error finding database",
+			fmt.Sprintf("Could not find database '%s': %s", plan.DatabaseName.ValueString(), err.Error()),
+		)
+		return
+	}
+	// A removed catalog is cleared with an empty one; null would be left out of the payload.
+	if plan.Catalog.IsNull() && !state.Catalog.IsNull() {
+		cleared := ""
+		queryReq.Catalog = &cleared
+	}
+
+	if err := r.client.UpdateSavedQuery(ctx, plan.ID.ValueInt64(), queryReq); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating saved query",
+			"Could not update saved query ID "+fmt.Sprintf("%d", plan.ID.ValueInt64())+": "+err.Error(),
+		)
+		return
+	}
+
+	query, err := r.client.GetSavedQuery(ctx, plan.ID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading saved query",
+			"Could not read saved query ID "+fmt.Sprintf("%d", plan.ID.ValueInt64())+" after updating it: "+err.Error(),
+		)
+		return
+	}
+	plan.apply(query)
+
+	tflog.Debug(ctx, "Updated saved query", map[string]interface{}{
+		"id": plan.ID.ValueInt64(),
+	})
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *savedQueryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state savedQueryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteSavedQuery(ctx, state.ID.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting saved query",
+			"Could not delete saved query, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Deleted saved query", map[string]interface{}{
+		"id": state.ID.ValueInt64(),
+	})
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *savedQueryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(client.SupersetAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ImportState imports a saved query by its numeric ID.
+func (r *savedQueryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing saved query",
+			fmt.Sprintf("Import ID '%s' is not a numeric saved query ID.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// request resolves the database and builds the create or update payload from the model. Unset
+// schema and template parameters are sent as null so that removing them from the configuration
+// clears them in Superset; an unset catalog is left out for servers that predate catalogs.
+func (r *savedQueryResource) request(ctx context.Context, m *savedQueryResourceModel) (client.SavedQueryRequest, error) {
+	databaseID, err := r.client.GetDatabaseIDByName(ctx, m.DatabaseName.ValueString())
+	if err != nil {
+		return client.SavedQueryRequest{}, err
+	}
+
+	return client.SavedQueryRequest{
+		DatabaseID:         databaseID,
+		Label:              m.Label.ValueString(),
+		Description:        m.Description.ValueString(),
+		Catalog:            m.Catalog.ValueStringPointer(),
+		Schema:             m.Schema.ValueStringPointer(),
+		SQL:                m.SQL.ValueString(),
+		TemplateParameters: m.TemplateParameters.ValueStringPointer(),
+	}, nil
+}
+
+// apply copies a saved query as Superset returned it into the model, so that changes made
+// outside Terraform show up as drift.
+func (m *savedQueryResourceModel) apply(query *client.SavedQuery) {
+	m.ID = types.Int64Value(query.ID)
+	m.Label = types.StringValue(query.Label)
+	m.DatabaseName = types.StringValue(query.Database.DatabaseName)
+	m.SQL = types.StringValue(query.SQL)
+
+	m.Description = optionalString(query.Description, m.Description)
+	m.Catalog = optionalString(query.Catalog, m.Catalog)
+	m.Schema = optionalString(query.Schema, m.Schema)
+	if query.TemplateParameters != "" {
+		m.TemplateParameters = newNormalizedJSONValue(query.TemplateParameters)
+	} else {
+		m.TemplateParameters = newNormalizedJSONNull()
+	}
+}
@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-superset/internal/client"
+	"terraform-provider-superset/internal/testing/fakesuperset"
+)
+
+// addSavedQueryDatabase creates a database on the fake server for saved queries to run against.
+func addSavedQueryDatabase(t *testing.T, c *client.Client, name string) int64 {
+	t.Helper()
+	db, err := c.CreateDatabase(t.Context(), map[string]interface{}{"database_name": name, "sqlalchemy_uri": "sqlite://"})
+	require.NoError(t, err)
+	return db.ID
+}
+
+func TestAccSavedQueryResource_FakeSuperset(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	addSavedQueryDatabase(t, c, "warehouse")
+	addSavedQueryDatabase(t, c, "analytics")
+
+	config := func(database, schema, params string) string {
+		return fakeProviderConfig(fake) + fmt.Sprintf(`
+resource "superset_saved_query" "test" {
+  label               = "Orders by region"
+  database_name       = %q
+  schema              = %q
+  sql                 = "SELECT region, count(*) FROM orders WHERE region = '{{ region }}' GROUP BY 1"
+  template_parameters = %q
+}
+`, database, schema, params)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			_, err := fakeClient(t, fake).GetSavedQuery(t.Context(), 1)
+			if !client.IsNotFound(err) {
+				return fmt.Errorf("saved query left after destroy: %v", err)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("warehouse", "sales", `{"region": "EU"}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_saved_query.test", "id", "1"),
+					resource.TestCheckResourceAttr("superset_saved_query.test", "database_name", "warehouse"),
+					resource.TestCheckNoResourceAttr("superset_saved_query.test", "catalog"),
+				),
+			},
+			{
+				// Reformatting the template parameters is not a change.
+				Config:   config("warehouse", "sales", `{"region":"EU"}`),
+				PlanOnly: true,
+			},
+			{
+				Config: config("analytics", "reporting", `{"region": "US"}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("superset_saved_query.test", "database_name", "analytics"),
+					resource.TestCheckResourceAttr("superset_saved_query.test", "schema", "reporting"),
+				),
+			},
+			{
+				ResourceName:      "superset_saved_query.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestSavedQueryResource_ReadDetectsDrift(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	databaseID := addSavedQueryDatabase(t, c, "warehouse")
+	otherID := addSavedQueryDatabase(t, c, "analytics")
+	r := &savedQueryResource{client: c}
+
+	createResp := &fwresource.CreateResponse{State: emptyState(t, r)}
+	r.Create(t.Context(), fwresource.CreateRequest{
+		Plan: planFor(t, r, savedQueryResourceModel{
+			ID:                 types.Int64Unknown(),
+			Label:              types.StringValue("Orders"),
+			Description:        types.StringNull(),
+			DatabaseName:       types.StringValue("warehouse"),
+			Catalog:            types.StringNull(),
+			Schema:             types.StringValue("sales"),
+			SQL:                types.StringValue("SELECT * FROM orders"),
+			TemplateParameters: newNormalizedJSONValue(`{"region": "EU"}`),
+		}),
+	}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+	var created savedQueryResourceModel
+	require.False(t, createResp.State.Get(t.Context(), &created).HasError())
+	assert.True(t, created.Description.IsNull())
+	assert.True(t, created.Catalog.IsNull())
+
+	query, err := c.GetSavedQuery(t.Context(), created.ID.ValueInt64())
+	require.NoError(t, err)
+	assert.Equal(t, databaseID, query.Database.ID)
+
+	// Someone edits the query in SQL Lab.
+	require.NoError(t, c.UpdateSavedQuery(t.Context(), created.ID.ValueInt64(), client.SavedQueryRequest{
+		DatabaseID:  otherID,
+		Label:       "Orders (copy)",
+		Description: "Edited by hand",
+		SQL:         "SELECT 1",
+	}))
+
+	readResp := &fwresource.ReadResponse{State: createResp.State}
+	r.Read(t.Context(), fwresource.ReadRequest{State: createResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+
+	var refreshed savedQueryResourceModel
+	require.False(t, readResp.State.Get(t.Context(), &refreshed).HasError())
+	assert.Equal(t, "Orders (copy)", refreshed.Label.ValueString())
+	assert.Equal(t, "Edited by hand", refreshed.Description.ValueString())
+	assert.Equal(t, "analytics", refreshed.DatabaseName.ValueString())
+	assert.Equal(t, "SELECT 1", refreshed.SQL.ValueString())
+	assert.Empty(t, refreshed.Schema.ValueString(), "the schema was cleared")
+	assert.True(t, refreshed.TemplateParameters.IsNull())
+
+	require.NoError(t, c.DeleteSavedQuery(t.Context(), created.ID.ValueInt64()))
+	readResp = &fwresource.ReadResponse{State: readResp.State}
+	r.Read(t.Context(), fwresource.ReadRequest{State: readResp.State}, readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull())
+}
+
+func TestSavedQueryResource_CatalogOnOlderServer(t *testing.T) {
+	fake := fakesuperset.New(t)
+	fake.SetVersion("4.0.2")
+	c := fakeClient(t, fake)
+	addSavedQueryDatabase(t, c, "warehouse")
+	r := &savedQueryResource{client: c}
+
+	model := savedQueryResourceModel{
+		ID:                 types.Int64Unknown(),
+		Label:              types.StringValue("Orders"),
+		Description:        types.StringNull(),
+		DatabaseName:       types.StringValue("warehouse"),
+		Catalog:            types.StringNull(),
+		Schema:             types.StringNull(),
+		SQL:                types.StringValue("SELECT 1"),
+		TemplateParameters: newNormalizedJSONNull(),
+	}
+	createResp := &fwresource.CreateResponse{State: emptyState(t, r)}
+	r.Create(t.Context(), fwresource.CreateRequest{Plan: planFor(t, r, model)}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "a query without a catalog works on 4.0: %v", createResp.Diagnostics)
+
+	model.Catalog = types.StringValue("main")
+	resp := &fwresource.ModifyPlanResponse{Plan: planFor(t, r, model)}
+	r.ModifyPlan(t.Context(), fwresource.ModifyPlanRequest{Plan: resp.Plan}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Unsupported Superset version", resp.Diagnostics.Errors()[0].Summary())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "catalog support requires Superset >= 4.1")
+}
+
+func TestSavedQueryResource_RemovingCatalogClearsIt(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	addSavedQueryDatabase(t, c, "warehouse")
+	r := &savedQueryResource{client: c}
+
+	createResp := &fwresource.CreateResponse{State: emptyState(t, r)}
+	r.Create(t.Context(), fwresource.CreateRequest{
+		Plan: planFor(t, r, savedQueryResourceModel{
+			ID:                 types.Int64Unknown(),
+			Label:              types.StringValue("Orders"),
+			Description:        types.StringNull(),
+			DatabaseName:       types.StringValue("warehouse"),
+			Catalog:            types.StringValue("main"),
+			Schema:             types.StringNull(),
+			SQL:                types.StringValue("SELECT 1"),
+			TemplateParameters: newNormalizedJSONNull(),
+		}),
+	}, createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+	var created savedQueryResourceModel
+	require.False(t, createResp.State.Get(t.Context(), &created).HasError())
+	assert.Equal(t, "main", created.Catalog.ValueString())
+
+	created.Catalog = types.StringNull()
+	updateResp := &fwresource.UpdateResponse{State: createResp.State}
+	r.Update(t.Context(), fwresource.UpdateRequest{Plan: planFor(t, r, created), State: createResp.State}, updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "%v", updateResp.Diagnostics)
+
+	var updated savedQueryResourceModel
+	require.False(t, updateResp.State.Get(t.Context(), &updated).HasError())
+	assert.True(t, updated.Catalog.IsNull())
+
+	query, err := c.GetSavedQuery(t.Context(), created.ID.ValueInt64())
+	require.NoError(t, err)
+	assert.Empty(t, query.Catalog, "the catalog was cleared")
+}
+
+func TestSavedQueryResource_UnknownDatabase(t *testing.T) {
+	fake := fakesuperset.New(t)
+	r := &savedQueryResource{client: fakeClient(t, fake)}
+
+	createResp := &fwresource.CreateResponse{State: emptyState(t, r)}
+	r.Create(t.Context(), fwresource.CreateRequest{
+		Plan: planFor(t, r, savedQueryResourceModel{
+			ID:                 types.Int64Unknown(),
+			Label:              types.StringValue("Orders"),
+			Description:        types.StringNull(),
+			DatabaseName:       types.StringValue("missing"),
+			Catalog:            types.StringNull(),
+			Schema:             types.StringNull(),
+			SQL:                types.StringValue("SELECT 1"),
+			TemplateParameters: newNormalizedJSONNull(),
+		}),
+	}, createResp)
+	require.True(t, createResp.Diagnostics.HasError())
+	assert.Contains(t, createResp.Diagnostics.Errors()[0].Detail(), "Could not find database 'missing'")
+}
+
+func TestSavedQueryResource_ImportState(t *testing.T) {
+	fake := fakesuperset.New(t)
+	c := fakeClient(t, fake)
+	databaseID := addSavedQueryDatabase(t, c, "warehouse")
+	r := &savedQueryResource{client: c}
+
+	id, err := c.CreateSavedQuery(t.Context(), client.SavedQueryRequest{DatabaseID: databaseID, Label: "Orders", SQL: "SELECT 1"})
+	require.NoError(t, err)
+
+	resp := &fwresource.ImportStateResponse{State: emptyState(t, r)}
+	r.ImportState(t.Context(), fwresource.ImportStateRequest{ID: fmt.Sprint(id)}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var imported types.Int64
+	require.False(t, resp.State.GetAttribute(t.Context(), path.Root("id"), &imported).HasError())
+	assert.Equal(t, id, imported.ValueInt64())
+
+	resp = &fwresource.ImportStateResponse{State: emptyState(t, r)}
+	r.ImportState(t.Context(), fwresource.ImportStateRequest{ID: "Orders"}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "not a numeric saved query ID")
+}
@@ -33,7 +33,7 @@ func NewUserResource() resource.Resource {
 
 // userResource is the resource implementation.
 type userResource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 // userResourceModel maps the resource schema data.
@@ -142,7 +142,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	id, err := r.client.CreateUser(
+	id, err := r.client.CreateUser(ctx,
 		plan.Username.ValueString(),
 		plan.FirstName.ValueString(),
 		plan.LastName.ValueString(),
@@ -187,8 +187,13 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	user, err := r.client.GetUser(state.ID.ValueInt64())
+	user, err := r.client.GetUser(ctx, state.ID.ValueInt64())
 	if err != nil {
+		if client.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("User ID %d not found, removing from state", state.ID.ValueInt64()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Error reading user",
 			fmt.Sprintf("Could not read user ID %d: %s", state.ID.ValueInt64(), err.Error()),
@@ -244,7 +249,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	err := r.client.UpdateUser(
+	err := r.client.UpdateUser(ctx,
 		state.ID.ValueInt64(),
 		plan.Username.ValueString(),
 		plan.FirstName.ValueString(),
@@ -279,9 +284,9 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.client.DeleteUser(state.ID.ValueInt64())
+	err := r.client.DeleteUser(ctx, state.ID.ValueInt64())
 	if err != nil {
-		if err.Error() == "failed to delete user, status code: 404" {
+		if client.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			tflog.Debug(ctx, fmt.Sprintf("User ID %d not found, removing from state", state.ID.ValueInt64()))
 			return
@@ -327,11 +332,11 @@ func (r *userResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -23,7 +23,7 @@ func NewUsersDataSource() datasource.DataSource {
 
 // usersDataSource is the data source implementation.
 type usersDataSource struct {
-	client *client.Client
+	client client.SupersetAPI
 }
 
 // usersDataSourceModel maps the data source schema data.
@@ -108,7 +108,7 @@ func (d *usersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state usersDataSourceModel
 
-	users, err := d.client.FetchUsers()
+	users, err := d.client.FetchUsers(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Superset Users",
@@ -147,11 +147,11 @@ func (d *usersDataSource) Configure(_ context.Context, req datasource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(client.SupersetAPI)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected client.SupersetAPI, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
@@ -0,0 +1,83 @@
+// Package cassette records HTTP interactions with a Superset server to YAML files and replays
+// them in tests, so client behaviour can be checked against several Superset releases offline.
+//
+// A Recorder wraps the real transport, forwards every request and keeps a scrubbed copy of the
+// exchange: credentials, tokens, cookies and CSRF values never reach the cassette. A Player serves
+// the recorded responses back in order. Both plug into client.TransportConfig.WrapTransport.
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RecordEnv is the environment variable that switches cassette-driven tests from replaying to
+// recording against a live server.
+const RecordEnv = "SUPERSET_CASSETTE_RECORD"
+
+// Cassette is an ordered list of recorded interactions.
+type Cassette struct {
+	// SupersetVersion is the version the server reported while recording.
+	SupersetVersion string        `yaml:"superset_version,omitempty"`
+	Interactions    []Interaction `yaml:"interactions"`
+}
+
+// Interaction is one request and the response the server gave to it.
+type Interaction struct {
+	Request  Request  `yaml:"request"`
+	Response Response `yaml:"response"`
+}
+
+// Request is the recorded part of an outgoing request. URI holds the path and query only, so a
+// cassette replays against any host.
+type Request struct {
+	Method string `yaml:"method"`
+	URI    string `yaml:"uri"`
+	Body   string `yaml:"body,omitempty"`
+}
+
+// Response is the recorded part of a server response.
+type Response struct {
+	Status      int    `yaml:"status"`
+	ContentType string `yaml:"content_type,omitempty"`
+	Body        string `yaml:"body,omitempty"`
+}
+
+// Recording reports whether tests should record new cassettes instead of replaying them.
+func Recording() bool {
+	return os.Getenv(RecordEnv) != ""
+}
+
+// Load reads a cassette from path.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Cassette
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to path, creating its directory if needed.
+func (c *Cassette) Save(path string) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
@@ -0,0 +1,116 @@
+package cassette
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_ScrubsSecrets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret-session")
+		switch r.URL.Path {
+		case "/api/v1/security/login":
+			_, _ = io.WriteString(w, `{"access_token":"eyJhbGciOi","refresh_token":"eyJyZWZyZXNo"}`)
+		case "/api/v1/security/csrf_token/":
+			_, _ = io.WriteString(w, `{"result":"IjM0ZTc0"}`)
+		default:
+			_, _ = io.WriteString(w, `{"result":{"sqlalchemy_uri":"postgresql://superset:s3cret@db:5432/superset","id":3}}`)
+		}
+	}))
+	defer srv.Close()
+
+	rec := NewRecorder(nil)
+	httpClient := &http.Client{Transport: rec}
+
+	resp, err := httpClient.Post(srv.URL+"/api/v1/security/login", "application/json",
+		strings.NewReader(`{"username":"admin","password":"admin","provider":"db"}`))
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Contains(t, string(body), "eyJhbGciOi", "the caller still sees the real response")
+
+	for _, path := range []string{"/api/v1/security/csrf_token/", "/api/v1/database/3?q=(keys:!(none))"} {
+		resp, err = httpClient.Get(srv.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	c := rec.Cassette()
+	require.Len(t, c.Interactions, 3)
+
+	login := c.Interactions[0]
+	assert.Equal(t, "POST", login.Request.Method)
+	assert.Equal(t, "/api/v1/security/login", login.Request.URI)
+	assert.Contains(t, login.Request.Body, `"username": "admin"`)
+	assert.Contains(t, login.Request.Body, `"password": "REDACTED"`)
+	assert.NotContains(t, login.Response.Body, "eyJ")
+	assert.Equal(t, 200, login.Response.Status)
+	assert.Equal(t, "application/json", login.Response.ContentType)
+
+	assert.Contains(t, c.Interactions[1].Response.Body, `"result": "REDACTED"`)
+
+	database := c.Interactions[2]
+	assert.Equal(t, "/api/v1/database/3?q=(keys:!(none))", database.Request.URI)
+	assert.Contains(t, database.Response.Body, "postgresql://superset:REDACTED@db:5432/superset")
+	assert.Contains(t, database.Response.Body, `"id": 3`)
+}
+
+func TestScrubBody(t *testing.T) {
+	assert.Equal(t, "[multipart body omitted]", scrubBody("multipart/form-data; boundary=x", []byte("--x\r\n")))
+	assert.Equal(t, "client_id=superset&client_secret=REDACTED",
+		scrubBody("application/x-www-form-urlencoded", []byte("client_id=superset&client_secret=hunter2")))
+	assert.Equal(t, "", scrubBody("application/json", nil))
+	assert.Equal(t, "{\n  \"passwords\": \"REDACTED\"\n}\n",
+		scrubBody("application/json", []byte(`{"passwords":{"databases/examples.yaml":"pw"}}`)))
+}
+
+func TestPlayer_ReplaysInOrder(t *testing.T) {
+	p := NewPlayer(&Cassette{Interactions: []Interaction{
+		{Request: Request{Method: "GET", URI: "/api/v1/security/roles/1"}, Response: Response{Status: 200, ContentType: "application/json", Body: `{"result":{"id":1}}`}},
+		{Request: Request{Method: "GET", URI: "/api/v1/security/roles/1"}, Response: Response{Status: 404, Body: `{"message":"Not found"}`}},
+		{Request: Request{Method: "DELETE", URI: "/api/v1/security/roles/1"}, Response: Response{Status: 200}},
+	}})
+	httpClient := &http.Client{Transport: p}
+
+	resp, err := httpClient.Get("http://any-host/api/v1/security/roles/1")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.JSONEq(t, `{"result":{"id":1}}`, string(body))
+
+	resp, err = httpClient.Get("http://other-host/api/v1/security/roles/1")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 404, resp.StatusCode)
+
+	_, err = httpClient.Get("http://any-host/api/v1/security/roles/1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no recorded response left for GET /api/v1/security/roles/1")
+
+	assert.Equal(t, []string{"DELETE /api/v1/security/roles/1"}, p.Unplayed())
+}
+
+func TestCassette_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "superset-4.1.1", "roles.yaml")
+	c := &Cassette{SupersetVersion: "4.1.1", Interactions: []Interaction{
+		{Request: Request{Method: "POST", URI: "/api/v1/security/roles/", Body: "{\n  \"name\": \"Analyst\"\n}\n"}, Response: Response{Status: 201, Body: `{"id":5}`}},
+	}}
+
+	require.NoError(t, c.Save(path))
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, c, loaded)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package cassette
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Player is an http.RoundTripper that answers requests from a cassette without touching the
+// network. Requests are matched on method and URI; repeated requests to the same URI receive
+// the recorded responses in order.
+type Player struct {
+	mu     sync.Mutex
+	queues map[string][]Response
+}
+
+// NewPlayer returns a Player serving the interactions in c.
+func NewPlayer(c *Cassette) *Player {
+	p := &Player{queues: map[string][]Response{}}
+	for _, i := range c.Interactions {
+		key := interactionKey(i.Request.Method, i.Request.URI)
+		p.queues[key] = append(p.queues[key], i.Response)
+	}
+	return p
+}
+
+// RoundTrip implements http.RoundTripper.
+func (p *Player) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+
+	key := interactionKey(req.Method, req.URL.RequestURI())
+
+	p.mu.Lock()
+	queue := p.queues[key]
+	if len(queue) == 0 {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("cassette: no recorded response left for %s", key)
+	}
+	recorded := queue[0]
+	p.queues[key] = queue[1:]
+	p.mu.Unlock()
+
+	header := http.Header{}
+	if recorded.ContentType != "" {
+		header.Set("Content-Type", recorded.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Unplayed lists the recorded requests that were never replayed, sorted. A non-empty result
+// means the client stopped making a call it made when the cassette was recorded.
+func (p *Player) Unplayed() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var keys []string
+	for key, queue := range p.queues {
+		for range queue {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func interactionKey(method, uri string) string {
+	return method + " " + uri
+}
@@ -0,0 +1,102 @@
+package fakesuperset
+
+import "net/http"
+
+type cssTemplate struct {
+	id           int64
+	templateName string
+	css          string
+}
+
+func (c *cssTemplate) render() map[string]any {
+	return map[string]any{"id": c.id, "template_name": c.templateName, "css": c.css}
+}
+
+func (s *Server) registerCSSTemplates(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/css_template/{$}", s.listCSSTemplates)
+	mux.HandleFunc("POST /api/v1/css_template/{$}", s.createCSSTemplate)
+	mux.HandleFunc("GET /api/v1/css_template/{id}", s.getCSSTemplate)
+	mux.HandleFunc("PUT /api/v1/css_template/{id}", s.updateCSSTemplate)
+	mux.HandleFunc("DELETE /api/v1/css_template/{id}", s.deleteCSSTemplate)
+}
+
+type cssTemplateBody struct {
+	TemplateName *string `json:"template_name"`
+	CSS          *string `json:"css"`
+}
+
+func (s *Server) listCSSTemplates(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]map[string]any, 0, s.cssTemplates.len())
+	for _, c := range s.cssTemplates.all() {
+		items = append(items, c.render())
+	}
+	s.writeList(w, r, items)
+}
+
+func (s *Server) createCSSTemplate(w http.ResponseWriter, r *http.Request) {
+	var body cssTemplateBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.TemplateName == nil || *body.TemplateName == "" {
+		writeMessage(w, http.StatusBadRequest, map[string]any{"template_name": []string{"Missing data for required field."}})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := &cssTemplate{id: s.cssTemplates.nextID(), templateName: *body.TemplateName}
+	if body.CSS != nil {
+		c.css = *body.CSS
+	}
+	s.cssTemplates.put(c.id, c)
+	writeJSON(w, http.StatusCreated, map[string]any{"id": c.id, "result": c.render()})
+}
+
+func (s *Server) getCSSTemplate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	c, ok := s.cssTemplates.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": c.render()})
+}
+
+func (s *Server) updateCSSTemplate(w http.ResponseWriter, r *http.Request) {
+	var body cssTemplateBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	c, ok := s.cssTemplates.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if body.TemplateName != nil {
+		c.templateName = *body.TemplateName
+	}
+	if body.CSS != nil {
+		c.css = *body.CSS
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": c.render()})
+}
+
+func (s *Server) deleteCSSTemplate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	if !s.cssTemplates.remove(id) {
+		writeNotFound(w)
+		return
+	}
+	writeOK(w)
+}
@@ -0,0 +1,547 @@
+package fakesuperset
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type chart struct {
+	id             int64
+	uuid           string
+	sliceName      string
+	vizType        string
+	description    string
+	params         string
+	queryContext   string
+	cacheTimeout   *int64
+	datasourceID   int64
+	datasourceType string
+	owners         []int64
+	dashboards     []int64
+}
+
+// renderChart renders a chart the way GET /api/v1/chart/{id} does: like Superset's show
+// columns, it carries neither the UUID nor the datasource.
+func (s *Server) renderChart(c *chart) map[string]any {
+	dashboards := make([]map[string]any, 0, len(c.dashboards))
+	for _, id := range c.dashboards {
+		if d, ok := s.dashboards.get(id); ok {
+			dashboards = append(dashboards, map[string]any{"id": d.id, "dashboard_title": d.title})
+		}
+	}
+	owners := make([]map[string]any, 0, len(c.owners))
+	for _, id := range c.owners {
+		if u, ok := s.users.get(id); ok {
+			owners = append(owners, map[string]any{"id": u.id, "first_name": u.firstName, "last_name": u.lastName})
+		}
+	}
+	return map[string]any{
+		"id":            c.id,
+		"slice_name":    c.sliceName,
+		"viz_type":      c.vizType,
+		"description":   nullable(c.description),
+		"params":        nullable(c.params),
+		"query_context": nullable(c.queryContext),
+		"cache_timeout": c.cacheTimeout,
+		"owners":        owners,
+		"dashboards":    dashboards,
+	}
+}
+
+// renderChartListItem renders a chart as an item of GET /api/v1/chart/, which adds the UUID and
+// the datasource to the show columns.
+func (s *Server) renderChartListItem(c *chart) map[string]any {
+	item := s.renderChart(c)
+	item["uuid"] = c.uuid
+	item["datasource_id"] = c.datasourceID
+	item["datasource_type"] = c.datasourceType
+	return item
+}
+
+// nullable renders an empty string as null, as Superset does for unset text columns.
+func nullable(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+type dashboard struct {
+	id                   int64
+	uuid                 string
+	title                string
+	slug                 string
+	positionJSON         string
+	jsonMetadata         string
+	css                  string
+	certifiedBy          string
+	certificationDetails string
+	published            bool
+	owners               []int64
+	roles                []int64
+}
+
+func (s *Server) renderDashboard(d *dashboard) map[string]any {
+	roles := make([]map[string]any, 0, len(d.roles))
+	for _, id := range d.roles {
+		if r, ok := s.roles.get(id); ok {
+			roles = append(roles, r.render())
+		}
+	}
+	owners := make([]map[string]any, 0, len(d.owners))
+	for _, id := range d.owners {
+		if u, ok := s.users.get(id); ok {
+			owners = append(owners, map[string]any{"id": u.id, "first_name": u.firstName, "last_name": u.lastName})
+		}
+	}
+	return map[string]any{
+		"id":                    d.id,
+		"uuid":                  d.uuid,
+		"dashboard_title":       d.title,
+		"slug":                  nullable(d.slug),
+		"position_json":         d.positionJSON,
+		"json_metadata":         d.jsonMetadata,
+		"css":                   d.css,
+		"certified_by":          nullable(d.certifiedBy),
+		"certification_details": nullable(d.certificationDetails),
+		"published":             d.published,
+		"roles":                 roles,
+		"owners":                owners,
+	}
+}
+
+type embeddedDashboard struct {
+	uuid           string
+	allowedDomains []string
+}
+
+func (e *embeddedDashboard) render(dashboardID int64) map[string]any {
+	return map[string]any{
+		"uuid":            e.uuid,
+		"allowed_domains": e.allowedDomains,
+		"dashboard_id":    fmt.Sprint(dashboardID),
+	}
+}
+
+func (s *Server) registerCharts(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/chart/{$}", s.listCharts)
+	mux.HandleFunc("POST /api/v1/chart/{$}", s.createChart)
+	mux.HandleFunc("GET /api/v1/chart/{id}", s.getChart)
+	mux.HandleFunc("PUT /api/v1/chart/{id}", s.updateChart)
+	mux.HandleFunc("DELETE /api/v1/chart/{id}", s.deleteChart)
+}
+
+func (s *Server) registerDashboards(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/dashboard/{$}", s.listDashboards)
+	mux.HandleFunc("POST /api/v1/dashboard/{$}", s.createDashboard)
+	mux.HandleFunc("GET /api/v1/dashboard/{id}", s.getDashboard)
+	mux.HandleFunc("PUT /api/v1/dashboard/{id}", s.updateDashboard)
+	mux.HandleFunc("DELETE /api/v1/dashboard/{id}", s.deleteDashboard)
+	mux.HandleFunc("GET /api/v1/dashboard/{id}/charts", s.getDashboardCharts)
+	mux.HandleFunc("GET /api/v1/dashboard/{id}/embedded", s.getEmbedded)
+	mux.HandleFunc("POST /api/v1/dashboard/{id}/embedded", s.setEmbedded)
+	mux.HandleFunc("PUT /api/v1/dashboard/{id}/embedded", s.setEmbedded)
+	mux.HandleFunc("DELETE /api/v1/dashboard/{id}/embedded", s.deleteEmbedded)
+}
+
+func (s *Server) listCharts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]map[string]any, 0, s.charts.len())
+	for _, c := range s.charts.all() {
+		items = append(items, s.renderChartListItem(c))
+	}
+	s.writeList(w, r, items)
+}
+
+// chartBody is the payload of chart create and update calls; nil fields were not sent.
+type chartBody struct {
+	SliceName      *string         `json:"slice_name"`
+	VizType        *string         `json:"viz_type"`
+	Description    *string         `json:"description"`
+	Params         *string         `json:"params"`
+	QueryContext   *string         `json:"query_context"`
+	CacheTimeout   optional[int64] `json:"cache_timeout"`
+	DatasourceID   *int64          `json:"datasource_id"`
+	DatasourceType *string         `json:"datasource_type"`
+	Owners         *[]int64        `json:"owners"`
+	Dashboards     *[]int64        `json:"dashboards"`
+}
+
+func (b chartBody) apply(c *chart) {
+	if b.SliceName != nil {
+		c.sliceName = *b.SliceName
+	}
+	if b.VizType != nil {
+		c.vizType = *b.VizType
+	}
+	if b.Description != nil {
+		c.description = *b.Description
+	}
+	if b.Params != nil {
+		c.params = *b.Params
+	}
+	if b.QueryContext != nil {
+		c.queryContext = *b.QueryContext
+	}
+	if b.CacheTimeout.set {
+		c.cacheTimeout = b.CacheTimeout.value
+	}
+	if b.DatasourceID != nil {
+		c.datasourceID = *b.DatasourceID
+	}
+	if b.DatasourceType != nil {
+		c.datasourceType = *b.DatasourceType
+	}
+	if b.Owners != nil {
+		c.owners = *b.Owners
+	}
+	if b.Dashboards != nil {
+		c.dashboards = *b.Dashboards
+	}
+}
+
+// validateChart returns a field validation message for c, or nil when it is valid.
+func (s *Server) validateChart(c *chart) map[string]any {
+	if c.sliceName == "" {
+		return map[string]any{"slice_name": []string{"Field may not be null."}}
+	}
+	if c.datasourceType != "table" {
+		return map[string]any{"datasource_type": []string{"Must be one of: table, query."}}
+	}
+	if _, ok := s.datasets.get(c.datasourceID); !ok {
+		return map[string]any{"datasource_id": []string{"Datasource does not exist"}}
+	}
+	for _, id := range c.owners {
+		if _, ok := s.users.get(id); !ok {
+			return map[string]any{"owners": []string{"Owners are invalid"}}
+		}
+	}
+	for _, id := range c.dashboards {
+		if _, ok := s.dashboards.get(id); !ok {
+			return map[string]any{"dashboards": []string{"Dashboards do not exist"}}
+		}
+	}
+	return nil
+}
+
+func (s *Server) createChart(w http.ResponseWriter, r *http.Request) {
+	var body chartBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := &chart{uuid: newUUID(), owners: []int64{}, dashboards: []int64{}}
+	body.apply(c)
+	if msg := s.validateChart(c); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	c.id = s.charts.nextID()
+	s.charts.put(c.id, c)
+	writeJSON(w, http.StatusCreated, map[string]any{"id": c.id, "result": map[string]any{
+		"slice_name":      c.sliceName,
+		"viz_type":        c.vizType,
+		"datasource_id":   c.datasourceID,
+		"datasource_type": c.datasourceType,
+	}})
+}
+
+func (s *Server) getChart(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	c, ok := s.charts.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": s.renderChart(c)})
+}
+
+func (s *Server) updateChart(w http.ResponseWriter, r *http.Request) {
+	var body chartBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	existing, ok := s.charts.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	updated := *existing
+	body.apply(&updated)
+	if msg := s.validateChart(&updated); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	*existing = updated
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": s.renderChart(existing)})
+}
+
+func (s *Server) deleteChart(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	if !s.charts.remove(id) {
+		writeNotFound(w)
+		return
+	}
+	writeOK(w)
+}
+
+func (s *Server) listDashboards(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]map[string]any, 0, s.dashboards.len())
+	for _, d := range s.dashboards.all() {
+		items = append(items, s.renderDashboard(d))
+	}
+	s.writeList(w, r, items)
+}
+
+// dashboardFor resolves the {id} wildcard, which like Superset's id_or_slug may also be a slug.
+func (s *Server) dashboardFor(r *http.Request) (*dashboard, bool) {
+	if id, ok := pathID(r); ok {
+		return s.dashboards.get(id)
+	}
+	for _, d := range s.dashboards.all() {
+		if d.slug != "" && d.slug == r.PathValue("id") {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+func (s *Server) getDashboard(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.dashboardFor(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": d.id, "result": s.renderDashboard(d)})
+}
+
+// dashboardBody is the payload of dashboard create and update calls; nil fields were not sent.
+type dashboardBody struct {
+	DashboardTitle       *string          `json:"dashboard_title"`
+	Slug                 optional[string] `json:"slug"`
+	PositionJSON         *string          `json:"position_json"`
+	JSONMetadata         *string          `json:"json_metadata"`
+	CSS                  *string          `json:"css"`
+	CertifiedBy          *string          `json:"certified_by"`
+	CertificationDetails *string          `json:"certification_details"`
+	Published            *bool            `json:"published"`
+	Owners               *[]int64         `json:"owners"`
+	Roles                *[]int64         `json:"roles"`
+}
+
+func (b dashboardBody) apply(d *dashboard) {
+	if b.DashboardTitle != nil {
+		d.title = *b.DashboardTitle
+	}
+	b.Slug.assign(&d.slug)
+	if b.PositionJSON != nil {
+		d.positionJSON = *b.PositionJSON
+	}
+	if b.JSONMetadata != nil {
+		d.jsonMetadata = *b.JSONMetadata
+	}
+	if b.CSS != nil {
+		d.css = *b.CSS
+	}
+	if b.CertifiedBy != nil {
+		d.certifiedBy = *b.CertifiedBy
+	}
+	if b.CertificationDetails != nil {
+		d.certificationDetails = *b.CertificationDetails
+	}
+	if b.Published != nil {
+		d.published = *b.Published
+	}
+	if b.Owners != nil {
+		d.owners = *b.Owners
+	}
+	if b.Roles != nil {
+		d.roles = *b.Roles
+	}
+}
+
+// validateDashboard returns a field validation message for the dashboard body b, or nil when it
+// is valid. d is the dashboard after b was applied.
+func (s *Server) validateDashboard(b dashboardBody, d *dashboard) map[string]any {
+	if b.Slug.set && b.Slug.value != nil && *b.Slug.value == "" {
+		return map[string]any{"slug": []string{"Length must be between 1 and 255."}}
+	}
+	for _, body := range []*string{b.PositionJSON, b.JSONMetadata} {
+		if body != nil && *body != "" && !json.Valid([]byte(*body)) {
+			return map[string]any{"json_metadata": []string{"JSON not valid"}}
+		}
+	}
+	for _, id := range d.owners {
+		if _, ok := s.users.get(id); !ok {
+			return map[string]any{"owners": []string{"Owners are invalid"}}
+		}
+	}
+	for _, id := range d.roles {
+		if _, ok := s.roles.get(id); !ok {
+			return map[string]any{"roles": []string{"Some roles do not exist"}}
+		}
+	}
+	if d.slug != "" {
+		for _, other := range s.dashboards.all() {
+			if other.id != d.id && other.slug == d.slug {
+				return map[string]any{"slug": []string{"Must be unique"}}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) createDashboard(w http.ResponseWriter, r *http.Request) {
+	var body dashboardBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d := &dashboard{uuid: newUUID(), owners: []int64{}, roles: []int64{}}
+	body.apply(d)
+	if msg := s.validateDashboard(body, d); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	d.id = s.dashboards.nextID()
+	s.dashboards.put(d.id, d)
+	writeJSON(w, http.StatusCreated, map[string]any{"id": d.id, "result": map[string]any{
+		"dashboard_title": d.title,
+		"slug":            nullable(d.slug),
+		"published":       d.published,
+	}})
+}
+
+func (s *Server) updateDashboard(w http.ResponseWriter, r *http.Request) {
+	var body dashboardBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	existing, ok := s.dashboards.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	updated := *existing
+	body.apply(&updated)
+	if msg := s.validateDashboard(body, &updated); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	*existing = updated
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": s.renderDashboard(existing)})
+}
+
+func (s *Server) deleteDashboard(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	if !s.dashboards.remove(id) {
+		writeNotFound(w)
+		return
+	}
+	for _, c := range s.charts.all() {
+		c.dashboards = withoutID(c.dashboards, id)
+	}
+	delete(s.embedded, id)
+	writeOK(w)
+}
+
+func (s *Server) getDashboardCharts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.dashboardFor(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	result := []map[string]any{}
+	for _, c := range s.charts.all() {
+		if containsID(c.dashboards, d.id) {
+			result = append(result, map[string]any{
+				"id":         c.id,
+				"slice_name": c.sliceName,
+				"viz_type":   c.vizType,
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"result": result})
+}
+
+func (s *Server) getEmbedded(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.dashboardFor(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	e, ok := s.embedded[d.id]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"result": e.render(d.id)})
+}
+
+// setEmbedded enables embedding or changes the allowed domains; the embed UUID survives updates.
+func (s *Server) setEmbedded(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		AllowedDomains []string `json:"allowed_domains"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.dashboardFor(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if body.AllowedDomains == nil {
+		body.AllowedDomains = []string{}
+	}
+	e, ok := s.embedded[d.id]
+	if !ok {
+		e = &embeddedDashboard{uuid: newUUID()}
+		s.embedded[d.id] = e
+	}
+	e.allowedDomains = body.AllowedDomains
+	writeJSON(w, http.StatusOK, map[string]any{"result": e.render(d.id)})
+}
+
+func (s *Server) deleteEmbedded(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.dashboardFor(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	delete(s.embedded, d.id)
+	writeOK(w)
+}
@@ -0,0 +1,415 @@
+package fakesuperset
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maskedPassword is what Superset shows instead of the password of a connection URI.
+const maskedPassword = "XXXXXXXXXX"
+
+// secretDatabaseFields are accepted on write but never returned.
+var secretDatabaseFields = []string{"masked_encrypted_extra", "encrypted_extra", "ssh_tunnel", "password"}
+
+type database struct {
+	id     int64
+	uuid   string
+	fields map[string]any
+}
+
+func (d *database) name() string {
+	return stringField(d.fields, "database_name")
+}
+
+// render returns the database as the single-item endpoint does; withExtra adds the fields
+// only the connection and list endpoints return.
+func (d *database) render(withExtra bool) map[string]any {
+	out := make(map[string]any, len(d.fields)+3)
+	for k, v := range d.fields {
+		out[k] = v
+	}
+	for _, k := range secretDatabaseFields {
+		delete(out, k)
+	}
+	if !withExtra {
+		delete(out, "extra")
+	}
+
+	uri := stringField(d.fields, "sqlalchemy_uri")
+	out["id"] = d.id
+	out["uuid"] = d.uuid
+	out["sqlalchemy_uri"] = maskURI(uri)
+	out["backend"] = backend(uri)
+	return out
+}
+
+// parameters splits the connection URI into the fields Superset reports for engines that
+// support parameter-based configuration.
+func (d *database) parameters() map[string]any {
+	u, err := url.Parse(stringField(d.fields, "sqlalchemy_uri"))
+	if err != nil || u.Host == "" {
+		return map[string]any{}
+	}
+	params := map[string]any{
+		"host":     u.Hostname(),
+		"username": u.User.Username(),
+		"database": strings.TrimPrefix(u.Path, "/"),
+	}
+	if port, err := strconv.Atoi(u.Port()); err == nil {
+		params["port"] = port
+	}
+	return params
+}
+
+// maskURI replaces the password of a connection URI the way Superset does.
+func maskURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.User == nil {
+		return uri
+	}
+	if _, ok := u.User.Password(); !ok {
+		return uri
+	}
+	u.User = url.UserPassword(u.User.Username(), maskedPassword)
+	return u.String()
+}
+
+// backend returns the dialect of a connection URI, e.g. postgresql for postgresql+psycopg2://.
+func backend(uri string) string {
+	scheme, _, _ := strings.Cut(uri, "://")
+	dialect, _, _ := strings.Cut(scheme, "+")
+	return dialect
+}
+
+type dataset struct {
+	id         int64
+	uuid       string
+	tableName  string
+	catalog    string
+	schema     string
+	sql        string
+	databaseID int64
+}
+
+func (s *Server) renderDataset(d *dataset) map[string]any {
+	db := map[string]any{"id": d.databaseID}
+	if parent, ok := s.databases.get(d.databaseID); ok {
+		db["database_name"] = parent.name()
+	}
+	kind := "physical"
+	if d.sql != "" {
+		kind = "virtual"
+	}
+	return map[string]any{
+		"id":              d.id,
+		"uuid":            d.uuid,
+		"table_name":      d.tableName,
+		"catalog":         nullable(d.catalog),
+		"schema":          d.schema,
+		"sql":             d.sql,
+		"kind":            kind,
+		"datasource_type": "table",
+		"database":        db,
+	}
+}
+
+func (s *Server) registerDatabases(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/database/{$}", s.listDatabases)
+	mux.HandleFunc("POST /api/v1/database/{$}", s.createDatabase)
+	mux.HandleFunc("GET /api/v1/database/{id}", s.getDatabase)
+	mux.HandleFunc("GET /api/v1/database/{id}/connection", s.getDatabaseConnection)
+	mux.HandleFunc("GET /api/v1/database/{id}/schemas/{$}", s.getDatabaseSchemas)
+	mux.HandleFunc("PUT /api/v1/database/{id}", s.updateDatabase)
+	mux.HandleFunc("DELETE /api/v1/database/{id}", s.deleteDatabase)
+}
+
+func (s *Server) registerDatasets(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/dataset/{$}", s.listDatasets)
+	mux.HandleFunc("POST /api/v1/dataset/{$}", s.createDataset)
+	mux.HandleFunc("GET /api/v1/dataset/{id}", s.getDataset)
+	mux.HandleFunc("PUT /api/v1/dataset/{id}", s.updateDataset)
+	mux.HandleFunc("DELETE /api/v1/dataset/{id}", s.deleteDataset)
+}
+
+func (s *Server) listDatabases(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]map[string]any, 0, s.databases.len())
+	for _, d := range s.databases.all() {
+		items = append(items, d.render(true))
+	}
+	s.writeList(w, r, items)
+}
+
+// validateDatabase returns a field validation message for d, or nil when it is valid.
+func (s *Server) validateDatabase(d *database) map[string]any {
+	if d.name() == "" {
+		return map[string]any{"database_name": []string{"Field may not be null."}}
+	}
+	if stringField(d.fields, "sqlalchemy_uri") == "" {
+		return map[string]any{"sqlalchemy_uri": []string{"Field may not be null."}}
+	}
+	for _, other := range s.databases.all() {
+		if other.id != d.id && other.name() == d.name() {
+			return map[string]any{"database_name": "A database with the same name already exists."}
+		}
+	}
+	return nil
+}
+
+func (s *Server) createDatabase(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d := &database{uuid: newUUID(), fields: body}
+	if msg := s.validateDatabase(d); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	d.id = s.databases.nextID()
+	s.databases.put(d.id, d)
+	writeJSON(w, http.StatusCreated, map[string]any{"id": d.id, "result": d.render(true)})
+}
+
+func (s *Server) getDatabase(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	d, ok := s.databases.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": d.render(false)})
+}
+
+func (s *Server) getDatabaseConnection(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	d, ok := s.databases.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	result := d.render(true)
+	result["parameters"] = d.parameters()
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": result})
+}
+
+func (s *Server) getDatabaseSchemas(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	if _, ok := s.databases.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	seen := map[string]bool{"information_schema": true, "public": true}
+	for _, ds := range s.datasets.all() {
+		if ds.databaseID == id && ds.schema != "" {
+			seen[ds.schema] = true
+		}
+	}
+	schemas := make([]string, 0, len(seen))
+	for name := range seen {
+		schemas = append(schemas, name)
+	}
+	sort.Strings(schemas)
+	writeJSON(w, http.StatusOK, map[string]any{"result": schemas})
+}
+
+func (s *Server) updateDatabase(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	existing, ok := s.databases.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	updated := &database{id: id, uuid: existing.uuid, fields: map[string]any{}}
+	for k, v := range existing.fields {
+		updated.fields[k] = v
+	}
+	for k, v := range body {
+		// Superset keeps the stored password when the URI comes back masked.
+		if k == "sqlalchemy_uri" {
+			if uri, ok := v.(string); ok && strings.Contains(uri, maskedPassword) {
+				continue
+			}
+		}
+		updated.fields[k] = v
+	}
+	if msg := s.validateDatabase(updated); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	s.databases.put(id, updated)
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": updated.render(true)})
+}
+
+func (s *Server) deleteDatabase(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	if _, ok := s.databases.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	for _, ds := range s.datasets.all() {
+		if ds.databaseID == id {
+			writeMessage(w, http.StatusUnprocessableEntity, "Cannot delete a database that has datasets attached")
+			return
+		}
+	}
+	s.databases.remove(id)
+	writeOK(w)
+}
+
+func (s *Server) listDatasets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]map[string]any, 0, s.datasets.len())
+	for _, d := range s.datasets.all() {
+		items = append(items, s.renderDataset(d))
+	}
+	s.writeList(w, r, items)
+}
+
+// datasetBody is the payload of dataset create and update calls; nil fields were not sent.
+type datasetBody struct {
+	TableName *string `json:"table_name"`
+	Catalog   *string `json:"catalog"`
+	Schema    *string `json:"schema"`
+	SQL       *string `json:"sql"`
+	Database  *int64  `json:"database"`
+}
+
+func (b datasetBody) apply(d *dataset) {
+	if b.TableName != nil {
+		d.tableName = *b.TableName
+	}
+	if b.Catalog != nil {
+		d.catalog = *b.Catalog
+	}
+	if b.Schema != nil {
+		d.schema = *b.Schema
+	}
+	if b.SQL != nil {
+		d.sql = *b.SQL
+	}
+	if b.Database != nil {
+		d.databaseID = *b.Database
+	}
+}
+
+// validateDataset returns a field validation message for d, or nil when it is valid.
+func (s *Server) validateDataset(d *dataset) map[string]any {
+	if d.tableName == "" {
+		return map[string]any{"table_name": []string{"Field may not be null."}}
+	}
+	if _, ok := s.databases.get(d.databaseID); !ok {
+		return map[string]any{"database": []string{"Database does not exist"}}
+	}
+	for _, other := range s.datasets.all() {
+		if other.id != d.id && other.databaseID == d.databaseID && other.catalog == d.catalog && other.schema == d.schema && other.tableName == d.tableName {
+			return map[string]any{"table_name": []string{fmt.Sprintf("Dataset %s already exists", d.tableName)}}
+		}
+	}
+	return nil
+}
+
+func (s *Server) createDataset(w http.ResponseWriter, r *http.Request) {
+	var body datasetBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rejectCatalog(w, body.Catalog != nil) {
+		return
+	}
+	d := &dataset{uuid: newUUID()}
+	body.apply(d)
+	if msg := s.validateDataset(d); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	d.id = s.datasets.nextID()
+	s.datasets.put(d.id, d)
+	writeJSON(w, http.StatusCreated, map[string]any{"id": d.id, "result": map[string]any{
+		"table_name": d.tableName,
+		"catalog":    nullable(d.catalog),
+		"schema":     d.schema,
+		"sql":        d.sql,
+		"database":   d.databaseID,
+	}})
+}
+
+func (s *Server) getDataset(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	d, ok := s.datasets.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": s.renderDataset(d)})
+}
+
+func (s *Server) updateDataset(w http.ResponseWriter, r *http.Request) {
+	var body datasetBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rejectCatalog(w, body.Catalog != nil) {
+		return
+	}
+	id, _ := pathID(r)
+	existing, ok := s.datasets.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	updated := *existing
+	body.apply(&updated)
+	if msg := s.validateDataset(&updated); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	*existing = updated
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": s.renderDataset(existing)})
+}
+
+func (s *Server) deleteDataset(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	if !s.datasets.remove(id) {
+		writeNotFound(w)
+		return
+	}
+	for _, rule := range s.rlsRules.all() {
+		rule.tables = withoutID(rule.tables, id)
+	}
+	writeOK(w)
+}
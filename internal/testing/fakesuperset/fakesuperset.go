@@ -0,0 +1,346 @@
+// Package fakesuperset is a stateful, in-memory stand-in for the Superset REST API. It serves
+// the endpoints the provider uses from an httptest.Server and keeps real state behind them, so
+// objects created by one request are visible to the next, deletes remove them and imports
+// upsert the objects of a bundle by UUID. It lets acceptance tests run whole resource.Test
+// lifecycles offline and catch disagreements between Create, Read, Update and Delete that
+// per-URL httpmock responders cannot.
+package fakesuperset
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// DefaultVersion is the Superset version a new Server reports.
+const DefaultVersion = "4.1.1"
+
+// Server is a fake Superset. The zero value is not usable; create one with New or NewServer.
+type Server struct {
+	srv *httptest.Server
+
+	mu sync.Mutex
+
+	version      string
+	featureFlags map[string]bool
+	// maxPageSize caps page_size the way FAB_API_MAX_PAGE_SIZE does.
+	maxPageSize int
+
+	accessTokens  map[string]bool
+	refreshTokens map[string]bool
+	csrfTokens    map[string]bool
+	issued        int
+
+	roles           table[role]
+	permissions     table[permissionView]
+	rolePermissions map[int64][]int64
+	users           table[user]
+	databases       table[database]
+	datasets        table[dataset]
+	charts          table[chart]
+	dashboards      table[dashboard]
+	embedded        map[int64]*embeddedDashboard
+	cssTemplates    table[cssTemplate]
+	rlsRules        table[rlsRule]
+	savedQueries    table[savedQuery]
+	reportSchedules table[reportSchedule]
+}
+
+// New starts a fake Superset for the duration of t.
+func New(t testing.TB) *Server {
+	t.Helper()
+	s := NewServer()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// NewServer starts a fake Superset. Callers must Close it.
+func NewServer() *Server {
+	s := &Server{
+		version:         DefaultVersion,
+		featureFlags:    map[string]bool{"EMBEDDED_SUPERSET": true},
+		maxPageSize:     100,
+		accessTokens:    map[string]bool{},
+		refreshTokens:   map[string]bool{},
+		csrfTokens:      map[string]bool{},
+		rolePermissions: map[int64][]int64{},
+		embedded:        map[int64]*embeddedDashboard{},
+	}
+	s.srv = httptest.NewServer(s.handler())
+	return s
+}
+
+// URL is the base URL of the server, suitable as the provider's host.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// SetVersion changes the version reported by /api/v1/version and /api/v1/menu/. Before 4.1
+// the server also rejects the catalog field of datasets and saved queries, as Superset does.
+func (s *Server) SetVersion(version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.version = version
+}
+
+// rejectCatalog writes the 400 FAB answers for an unknown field and returns true when sent
+// carries a catalog the reported version does not have. A version it cannot parse counts as
+// current. The caller must hold s.mu.
+func (s *Server) rejectCatalog(w http.ResponseWriter, sent bool) bool {
+	var major, minor int
+	if !sent {
+		return false
+	}
+	if _, err := fmt.Sscanf(s.version, "%d.%d", &major, &minor); err != nil || major > 4 || major == 4 && minor >= 1 {
+		return false
+	}
+	writeMessage(w, http.StatusBadRequest, map[string]any{"catalog": []string{"Unknown field."}})
+	return true
+}
+
+// SetFeatureFlag changes a feature flag reported by /api/v1/version.
+func (s *Server) SetFeatureFlag(name string, enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.featureFlags[name] = enabled
+}
+
+// SetMaxPageSize changes the largest page list endpoints return, like FAB_API_MAX_PAGE_SIZE.
+func (s *Server) SetMaxPageSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxPageSize = n
+}
+
+// ExpireAccessTokens invalidates every access token handed out so far, so the next API call
+// answers 401 and the client has to refresh or log in again.
+func (s *Server) ExpireAccessTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accessTokens = map[string]bool{}
+}
+
+// handler routes every endpoint the provider uses.
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	s.registerSecurity(mux)
+	s.registerDatabases(mux)
+	s.registerDatasets(mux)
+	s.registerCharts(mux)
+	s.registerDashboards(mux)
+	s.registerCSSTemplates(mux)
+	s.registerRowLevelSecurity(mux)
+	s.registerSavedQueries(mux)
+	s.registerReportSchedules(mux)
+	s.registerImports(mux)
+	mux.HandleFunc("GET /api/v1/version", s.handleVersion)
+	mux.HandleFunc("GET /api/v1/menu/", s.handleMenu)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorized(w, r) {
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleVersion(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	flags := make(map[string]bool, len(s.featureFlags))
+	for name, enabled := range s.featureFlags {
+		flags[name] = enabled
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"result": map[string]any{"version": s.version, "feature_flags": flags},
+	})
+}
+
+func (s *Server) handleMenu(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"result": map[string]any{"navbar_right": map[string]any{"version_string": s.version}},
+	})
+}
+
+// table stores one kind of object by ID. IDs start at 1 and are never reused.
+type table[T any] struct {
+	lastID int64
+	rows   map[int64]*T
+}
+
+// nextID reserves the ID of the next row.
+func (t *table[T]) nextID() int64 {
+	t.lastID++
+	return t.lastID
+}
+
+func (t *table[T]) put(id int64, row *T) {
+	if t.rows == nil {
+		t.rows = map[int64]*T{}
+	}
+	t.rows[id] = row
+}
+
+func (t *table[T]) get(id int64) (*T, bool) {
+	row, ok := t.rows[id]
+	return row, ok
+}
+
+func (t *table[T]) remove(id int64) bool {
+	_, ok := t.rows[id]
+	delete(t.rows, id)
+	return ok
+}
+
+// all returns the rows in ID order.
+func (t *table[T]) all() []*T {
+	ids := make([]int64, 0, len(t.rows))
+	for id := range t.rows {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	rows := make([]*T, 0, len(ids))
+	for _, id := range ids {
+		rows = append(rows, t.rows[id])
+	}
+	return rows
+}
+
+func (t *table[T]) len() int {
+	return len(t.rows)
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// pathID parses the {id} wildcard of r.
+func pathID(r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	return id, err == nil
+}
+
+// decodeBody decodes a JSON request body into out, answering 400 when it is malformed.
+func decodeBody(w http.ResponseWriter, r *http.Request, out any) bool {
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		writeMessage(w, http.StatusBadRequest, fmt.Sprintf("Request is not JSON: %s", err))
+		return false
+	}
+	return true
+}
+
+// optional is a request field that may be absent, null or set. Unlike a pointer it tells an
+// explicit null, which clears a column, from a field that was not sent.
+type optional[T any] struct {
+	set   bool
+	value *T
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (o *optional[T]) UnmarshalJSON(data []byte) error {
+	o.set = true
+	return json.Unmarshal(data, &o.value)
+}
+
+// null reports whether the field was sent as an explicit null.
+func (o optional[T]) null() bool {
+	return o.set && o.value == nil
+}
+
+// assign stores the field in dst when it was sent; null stores the zero value.
+func (o optional[T]) assign(dst *T) {
+	if !o.set {
+		return
+	}
+	var zero T
+	*dst = zero
+	if o.value != nil {
+		*dst = *o.value
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeMessage writes Superset's {"message": ...} error shape; message may be a string or,
+// for field validation errors, a map of field name to messages.
+func writeMessage(w http.ResponseWriter, status int, message any) {
+	writeJSON(w, status, map[string]any{"message": message})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeMessage(w, http.StatusNotFound, "Not found")
+}
+
+func writeOK(w http.ResponseWriter) {
+	writeMessage(w, http.StatusOK, "OK")
+}
+
+// int64s converts a decoded JSON array of numbers to IDs, skipping anything else.
+func int64s(values []any) []int64 {
+	ids := make([]int64, 0, len(values))
+	for _, v := range values {
+		if f, ok := v.(float64); ok {
+			ids = append(ids, int64(f))
+		}
+	}
+	return ids
+}
+
+// stringField returns fields[name] when it is a string.
+func stringField(fields map[string]any, name string) string {
+	s, _ := fields[name].(string)
+	return s
+}
+
+// containsID reports whether ids contains id.
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutID returns ids without id.
+func withoutID(ids []int64, id int64) []int64 {
+	kept := ids[:0:0]
+	for _, v := range ids {
+		if v != id {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// bearerToken returns the token of an "Authorization: Bearer ..." header.
+func bearerToken(r *http.Request) string {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+	return token
+}
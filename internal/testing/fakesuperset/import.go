@@ -0,0 +1,452 @@
+package fakesuperset
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// bundleKind describes one import endpoint: the metadata.yaml type it accepts and the
+// directory of the bundle holding the objects it imports. Objects in the other directories
+// are imported too, but only when they do not exist yet, as Superset does for dependencies.
+type bundleKind struct {
+	noun         string
+	metadataType string
+	dir          string
+}
+
+var (
+	dashboardBundle = bundleKind{noun: "Dashboard", metadataType: "Dashboard", dir: "dashboards"}
+	datasetBundle   = bundleKind{noun: "Dataset", metadataType: "SqlaTable", dir: "datasets"}
+	chartBundle     = bundleKind{noun: "Chart", metadataType: "Slice", dir: "charts"}
+)
+
+// bundleFile is one YAML document of a bundle, keyed by its path below the bundle root.
+type bundleFile struct {
+	name   string
+	config map[string]any
+}
+
+func (f bundleFile) uuid() string {
+	return stringField(f.config, "uuid")
+}
+
+// bundle is a parsed import ZIP.
+type bundle struct {
+	metadata   map[string]any
+	databases  []bundleFile
+	datasets   []bundleFile
+	charts     []bundleFile
+	dashboards []bundleFile
+}
+
+// importError is answered as Superset's 422 import failure, with per-file details in extra.
+type importError struct {
+	message string
+	extra   map[string]any
+}
+
+func (e *importError) Error() string {
+	return e.message
+}
+
+func (s *Server) registerImports(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/v1/dashboard/import/{$}", s.importHandler(dashboardBundle))
+	mux.HandleFunc("POST /api/v1/dataset/import/{$}", s.importHandler(datasetBundle))
+	mux.HandleFunc("POST /api/v1/chart/import/{$}", s.importHandler(chartBundle))
+}
+
+func (s *Server) importHandler(kind bundleKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			writeMessage(w, http.StatusBadRequest, fmt.Sprintf("Request is not a multipart form: %s", err))
+			return
+		}
+		file, _, err := r.FormFile("formData")
+		if err != nil {
+			writeMessage(w, http.StatusBadRequest, "Arguments are not correct")
+			return
+		}
+		defer file.Close()
+		data, err := io.ReadAll(file)
+		if err != nil {
+			writeMessage(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		passwords := map[string]string{}
+		if raw := r.FormValue("passwords"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &passwords); err != nil {
+				writeMessage(w, http.StatusBadRequest, fmt.Sprintf("passwords is not a JSON object: %s", err))
+				return
+			}
+		}
+
+		b, err := parseBundle(data)
+		if err != nil {
+			writeImportError(w, kind, &importError{message: err.Error()})
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.importBundle(kind, b, r.FormValue("overwrite") == "true", passwords); err != nil {
+			writeImportError(w, kind, err)
+			return
+		}
+		writeMessage(w, http.StatusOK, "OK")
+	}
+}
+
+func writeImportError(w http.ResponseWriter, kind bundleKind, err *importError) {
+	extra := map[string]any{"issue_codes": []any{map[string]any{"code": 1010, "message": "Issue 1010 - Superset encountered an error while running a command."}}}
+	for k, v := range err.extra {
+		extra[k] = v
+	}
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"errors": []any{map[string]any{
+		"message":    fmt.Sprintf("Error importing %s: %s", strings.ToLower(kind.noun), err.message),
+		"error_type": "GENERIC_COMMAND_ERROR",
+		"level":      "warning",
+		"extra":      extra,
+	}}})
+}
+
+// parseBundle reads the YAML files of an export ZIP. Paths are taken relative to the single
+// top-level directory every Superset export has.
+func parseBundle(data []byte) (*bundle, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not a valid ZIP file: %w", err)
+	}
+
+	b := &bundle{}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		name := f.Name
+		if _, rest, ok := strings.Cut(name, "/"); ok {
+			name = rest
+		}
+		if path.Ext(name) != ".yaml" && path.Ext(name) != ".yml" {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		var config map[string]any
+		if err := yaml.Unmarshal(content, &config); err != nil {
+			return nil, fmt.Errorf("%s is not valid YAML: %w", name, err)
+		}
+
+		file := bundleFile{name: name, config: config}
+		switch dir, _, _ := strings.Cut(name, "/"); dir {
+		case "metadata.yaml":
+			b.metadata = config
+		case "databases":
+			b.databases = append(b.databases, file)
+		case "datasets":
+			b.datasets = append(b.datasets, file)
+		case "charts":
+			b.charts = append(b.charts, file)
+		case "dashboards":
+			b.dashboards = append(b.dashboards, file)
+		}
+	}
+
+	for _, files := range [][]bundleFile{b.databases, b.datasets, b.charts, b.dashboards} {
+		sort.Slice(files, func(i, j int) bool { return files[i].name < files[j].name })
+		for _, f := range files {
+			if f.uuid() == "" {
+				return nil, fmt.Errorf("%s has no uuid", f.name)
+			}
+		}
+	}
+	return b, nil
+}
+
+// importBundle validates the whole bundle before changing anything, then upserts its objects
+// by UUID: databases, datasets, charts and finally dashboards, whose layout links the charts.
+func (s *Server) importBundle(kind bundleKind, b *bundle, overwrite bool, passwords map[string]string) *importError {
+	if b.metadata == nil {
+		return &importError{message: "Missing metadata.yaml", extra: map[string]any{"metadata.yaml": map[string]any{"type": []string{"Missing data for required field."}}}}
+	}
+	if got := stringField(b.metadata, "type"); got != kind.metadataType {
+		return &importError{message: "Invalid metadata.yaml", extra: map[string]any{"metadata.yaml": map[string]any{"type": []string{fmt.Sprintf("Must be equal to %s.", kind.metadataType)}}}}
+	}
+
+	primary := map[string][]bundleFile{"databases": b.databases, "datasets": b.datasets, "charts": b.charts, "dashboards": b.dashboards}[kind.dir]
+	if len(primary) == 0 {
+		return &importError{message: fmt.Sprintf("No %s found in the bundle", kind.dir)}
+	}
+	if !overwrite {
+		for _, f := range primary {
+			if s.existsByUUID(kind.dir, f.uuid()) {
+				return &importError{message: fmt.Sprintf("%s already exists and `overwrite=true` was not passed", kind.noun),
+					extra: map[string]any{f.name: fmt.Sprintf("%s already exists and `overwrite=true` was not passed", kind.noun)}}
+			}
+		}
+	}
+
+	bundleUUIDs := map[string]bool{}
+	for _, files := range [][]bundleFile{b.databases, b.datasets, b.charts, b.dashboards} {
+		for _, f := range files {
+			bundleUUIDs[f.uuid()] = true
+		}
+	}
+	for _, f := range b.databases {
+		if strings.Contains(stringField(f.config, "sqlalchemy_uri"), maskedPassword) && passwords[f.name] == "" {
+			return &importError{message: "Must provide a password for the database",
+				extra: map[string]any{f.name: map[string]any{"_schema": []string{"Must provide a password for the database"}}}}
+		}
+	}
+	for _, f := range b.datasets {
+		if ref := stringField(f.config, "database_uuid"); !bundleUUIDs[ref] && !s.existsByUUID("databases", ref) {
+			return &importError{message: fmt.Sprintf("%s references unknown database %s", f.name, ref)}
+		}
+	}
+	for _, f := range b.charts {
+		if ref := stringField(f.config, "dataset_uuid"); !bundleUUIDs[ref] && !s.existsByUUID("datasets", ref) {
+			return &importError{message: fmt.Sprintf("%s references unknown dataset %s", f.name, ref)}
+		}
+	}
+
+	replace := func(dir string) bool { return overwrite && dir == kind.dir }
+	for _, f := range b.databases {
+		s.importDatabase(f, passwords[f.name], replace("databases"))
+	}
+	for _, f := range b.datasets {
+		s.importDataset(f, replace("datasets"))
+	}
+	for _, f := range b.charts {
+		s.importChart(f, replace("charts"))
+	}
+	for _, f := range b.dashboards {
+		s.importDashboard(f, replace("dashboards"))
+	}
+	return nil
+}
+
+func (s *Server) existsByUUID(dir, uuid string) bool {
+	switch dir {
+	case "databases":
+		return s.databaseByUUID(uuid) != nil
+	case "datasets":
+		return s.datasetByUUID(uuid) != nil
+	case "charts":
+		return s.chartByUUID(uuid) != nil
+	case "dashboards":
+		return s.dashboardByUUID(uuid) != nil
+	}
+	return false
+}
+
+func (s *Server) databaseByUUID(uuid string) *database {
+	for _, d := range s.databases.all() {
+		if d.uuid == uuid {
+			return d
+		}
+	}
+	return nil
+}
+
+func (s *Server) datasetByUUID(uuid string) *dataset {
+	for _, d := range s.datasets.all() {
+		if d.uuid == uuid {
+			return d
+		}
+	}
+	return nil
+}
+
+func (s *Server) chartByUUID(uuid string) *chart {
+	for _, c := range s.charts.all() {
+		if c.uuid == uuid {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *Server) dashboardByUUID(uuid string) *dashboard {
+	for _, d := range s.dashboards.all() {
+		if d.uuid == uuid {
+			return d
+		}
+	}
+	return nil
+}
+
+// jsonString returns v as Superset stores JSON columns: strings as they are, anything else
+// marshalled.
+func jsonString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+func (s *Server) importDatabase(f bundleFile, password string, replace bool) {
+	existing := s.databaseByUUID(f.uuid())
+	if existing != nil && !replace {
+		return
+	}
+
+	fields := map[string]any{}
+	for k, v := range f.config {
+		switch k {
+		case "uuid", "version":
+		case "extra":
+			fields[k] = jsonString(v)
+		default:
+			fields[k] = v
+		}
+	}
+	if password != "" {
+		fields["sqlalchemy_uri"] = strings.Replace(stringField(fields, "sqlalchemy_uri"), maskedPassword, password, 1)
+	}
+
+	if existing != nil {
+		existing.fields = fields
+		return
+	}
+	d := &database{id: s.databases.nextID(), uuid: f.uuid(), fields: fields}
+	s.databases.put(d.id, d)
+}
+
+func (s *Server) importDataset(f bundleFile, replace bool) {
+	existing := s.datasetByUUID(f.uuid())
+	if existing != nil && !replace {
+		return
+	}
+
+	d := &dataset{
+		uuid:      f.uuid(),
+		tableName: stringField(f.config, "table_name"),
+		schema:    stringField(f.config, "schema"),
+		sql:       stringField(f.config, "sql"),
+	}
+	if db := s.databaseByUUID(stringField(f.config, "database_uuid")); db != nil {
+		d.databaseID = db.id
+	}
+
+	if existing != nil {
+		d.id = existing.id
+		*existing = *d
+		return
+	}
+	d.id = s.datasets.nextID()
+	s.datasets.put(d.id, d)
+}
+
+func (s *Server) importChart(f bundleFile, replace bool) {
+	existing := s.chartByUUID(f.uuid())
+	if existing != nil && !replace {
+		return
+	}
+
+	c := &chart{
+		uuid:           f.uuid(),
+		sliceName:      stringField(f.config, "slice_name"),
+		vizType:        stringField(f.config, "viz_type"),
+		params:         jsonString(f.config["params"]),
+		datasourceType: "table",
+		owners:         []int64{},
+		dashboards:     []int64{},
+	}
+	if ds := s.datasetByUUID(stringField(f.config, "dataset_uuid")); ds != nil {
+		c.datasourceID = ds.id
+	}
+
+	if existing != nil {
+		c.id = existing.id
+		c.dashboards = existing.dashboards
+		*existing = *c
+		return
+	}
+	c.id = s.charts.nextID()
+	s.charts.put(c.id, c)
+}
+
+func (s *Server) importDashboard(f bundleFile, replace bool) {
+	existing := s.dashboardByUUID(f.uuid())
+	if existing != nil && !replace {
+		return
+	}
+
+	d := &dashboard{
+		uuid:         f.uuid(),
+		title:        stringField(f.config, "dashboard_title"),
+		slug:         stringField(f.config, "slug"),
+		positionJSON: jsonString(f.config["position"]),
+		jsonMetadata: jsonString(f.config["metadata"]),
+		css:          stringField(f.config, "css"),
+		owners:       []int64{},
+		roles:        []int64{},
+	}
+	if published, ok := f.config["published"].(bool); ok {
+		d.published = published
+	}
+
+	if existing != nil {
+		d.id = existing.id
+		d.owners = existing.owners
+		d.roles = existing.roles
+		*existing = *d
+	} else {
+		d.id = s.dashboards.nextID()
+		s.dashboards.put(d.id, d)
+	}
+
+	// The layout decides which charts belong to the dashboard.
+	linked := chartUUIDsInPosition(f.config["position"])
+	for _, c := range s.charts.all() {
+		c.dashboards = withoutID(c.dashboards, d.id)
+		if linked[c.uuid] {
+			c.dashboards = append(c.dashboards, d.id)
+		}
+	}
+}
+
+// chartUUIDsInPosition collects meta.uuid of the CHART components of a dashboard layout.
+func chartUUIDsInPosition(position any) map[string]bool {
+	uuids := map[string]bool{}
+	components, ok := position.(map[string]any)
+	if !ok {
+		return uuids
+	}
+	for _, component := range components {
+		c, ok := component.(map[string]any)
+		if !ok || c["type"] != "CHART" {
+			continue
+		}
+		if meta, ok := c["meta"].(map[string]any); ok {
+			if uuid := stringField(meta, "uuid"); uuid != "" {
+				uuids[uuid] = true
+			}
+		}
+	}
+	return uuids
+}
@@ -0,0 +1,225 @@
+package fakesuperset
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// listQuery is the decoded q= parameter of a list endpoint.
+type listQuery struct {
+	filters        []listFilter
+	columns        []string
+	orderColumn    string
+	orderDirection string
+	page           int
+	pageSize       int
+}
+
+type listFilter struct {
+	col   string
+	opr   string
+	value any
+}
+
+// parseListQuery decodes the q= parameter of r. A missing parameter is an empty query.
+func parseListQuery(r *http.Request) (listQuery, error) {
+	var q listQuery
+	raw := r.URL.Query().Get("q")
+	if raw == "" {
+		return q, nil
+	}
+
+	decoded, err := risonDecode(raw)
+	if err != nil {
+		return q, err
+	}
+	fields, ok := decoded.(map[string]any)
+	if !ok {
+		return q, fmt.Errorf("q must be a Rison object")
+	}
+
+	if filters, ok := fields["filters"].([]any); ok {
+		for _, f := range filters {
+			filter, ok := f.(map[string]any)
+			if !ok {
+				return q, fmt.Errorf("filter %v is not an object", f)
+			}
+			q.filters = append(q.filters, listFilter{
+				col:   stringField(filter, "col"),
+				opr:   stringField(filter, "opr"),
+				value: filter["value"],
+			})
+		}
+	}
+	if columns, ok := fields["columns"].([]any); ok {
+		for _, c := range columns {
+			if name, ok := c.(string); ok {
+				q.columns = append(q.columns, name)
+			}
+		}
+	}
+	q.orderColumn = stringField(fields, "order_column")
+	q.orderDirection = stringField(fields, "order_direction")
+	if page, ok := fields["page"].(float64); ok {
+		q.page = int(page)
+	}
+	if pageSize, ok := fields["page_size"].(float64); ok {
+		q.pageSize = int(pageSize)
+	}
+	return q, nil
+}
+
+// match reports whether an item passes every filter.
+func (q listQuery) match(item map[string]any) (bool, error) {
+	for _, f := range q.filters {
+		got := scalar(item[f.col])
+		switch f.opr {
+		case "eq":
+			if got != scalar(f.value) {
+				return false, nil
+			}
+		case "neq":
+			if got == scalar(f.value) {
+				return false, nil
+			}
+		case "ct":
+			if !strings.Contains(strings.ToLower(got), strings.ToLower(scalar(f.value))) {
+				return false, nil
+			}
+		case "sw":
+			if !strings.HasPrefix(strings.ToLower(got), strings.ToLower(scalar(f.value))) {
+				return false, nil
+			}
+		case "rel_o_m":
+			// Related object filters match on the ID of the related object.
+			related, _ := item[f.col].(map[string]any)
+			if scalar(related["id"]) != scalar(f.value) {
+				return false, nil
+			}
+		case "in":
+			values, ok := f.value.([]any)
+			if !ok {
+				return false, fmt.Errorf("filter %s in expects a list", f.col)
+			}
+			found := false
+			for _, v := range values {
+				if got == scalar(v) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false, nil
+			}
+		default:
+			return false, fmt.Errorf("filter operator %q is not supported on %s", f.opr, f.col)
+		}
+	}
+	return true, nil
+}
+
+// scalar renders a JSON scalar for comparison, so that 3, 3.0 and "3" all compare equal.
+func scalar(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		if v == float64(int64(v)) {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// less orders numbers numerically and everything else by its scalar rendering.
+func less(a, b any) bool {
+	fa, aok := number(a)
+	fb, bok := number(b)
+	if aok && bok {
+		return fa < fb
+	}
+	return scalar(a) < scalar(b)
+}
+
+func number(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// writeList answers a list request: items are filtered, ordered and paginated by the q=
+// parameter and projected onto its columns, and count reports the number of matches.
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, items []map[string]any) {
+	q, err := parseListQuery(r)
+	if err != nil {
+		writeMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	matched := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		ok, err := q.match(item)
+		if err != nil {
+			writeMessage(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if ok {
+			matched = append(matched, item)
+		}
+	}
+
+	if q.orderColumn != "" {
+		sort.SliceStable(matched, func(i, j int) bool {
+			a, b := matched[i][q.orderColumn], matched[j][q.orderColumn]
+			if q.orderDirection == "desc" {
+				return less(b, a)
+			}
+			return less(a, b)
+		})
+	}
+
+	pageSize := q.pageSize
+	if pageSize <= 0 || pageSize > s.maxPageSize {
+		pageSize = s.maxPageSize
+	}
+	start := q.page * pageSize
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := min(start+pageSize, len(matched))
+
+	result := make([]map[string]any, 0, end-start)
+	for _, item := range matched[start:end] {
+		if len(q.columns) > 0 {
+			projected := make(map[string]any, len(q.columns))
+			for _, col := range q.columns {
+				if v, ok := item[col]; ok {
+					projected[col] = v
+				}
+			}
+			item = projected
+		}
+		result = append(result, item)
+	}
+
+	ids := make([]any, 0, len(result))
+	for _, item := range matched[start:end] {
+		ids = append(ids, item["id"])
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"result": result, "count": len(matched), "ids": ids})
+}
@@ -0,0 +1,325 @@
+package fakesuperset
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+type reportRecipient struct {
+	typ    string
+	target string
+}
+
+type reportSchedule struct {
+	id                  int64
+	typ                 string
+	name                string
+	description         string
+	active              bool
+	crontab             string
+	timezone            string
+	dashboardID         int64
+	chartID             int64
+	databaseID          int64
+	sql                 string
+	validatorType       string
+	validatorConfigJSON string
+	recipients          []reportRecipient
+	workingTimeout      int64
+	logRetention        int64
+	gracePeriod         int64
+	reportFormat        string
+}
+
+// newReportSchedule returns a report schedule with Superset's column defaults.
+func newReportSchedule() *reportSchedule {
+	return &reportSchedule{
+		active:              true,
+		timezone:            "UTC",
+		validatorConfigJSON: "{}",
+		workingTimeout:      3600,
+		logRetention:        90,
+		gracePeriod:         14400,
+		reportFormat:        "PNG",
+	}
+}
+
+func (s *Server) renderReportSchedule(rs *reportSchedule) map[string]any {
+	var dashboardRef, chartRef, databaseRef any
+	if d, ok := s.dashboards.get(rs.dashboardID); ok {
+		dashboardRef = map[string]any{"id": d.id, "dashboard_title": d.title}
+	}
+	if c, ok := s.charts.get(rs.chartID); ok {
+		chartRef = map[string]any{"id": c.id, "slice_name": c.sliceName}
+	}
+	if db, ok := s.databases.get(rs.databaseID); ok {
+		databaseRef = map[string]any{"id": db.id, "database_name": db.name()}
+	}
+	recipients := make([]map[string]any, 0, len(rs.recipients))
+	for i, r := range rs.recipients {
+		config, _ := json.Marshal(map[string]string{"target": r.target})
+		recipients = append(recipients, map[string]any{"id": i + 1, "type": r.typ, "recipient_config_json": string(config)})
+	}
+	return map[string]any{
+		"id":                    rs.id,
+		"type":                  rs.typ,
+		"name":                  rs.name,
+		"description":           nullable(rs.description),
+		"active":                rs.active,
+		"crontab":               rs.crontab,
+		"timezone":              rs.timezone,
+		"creation_method":       "alerts_reports",
+		"dashboard":             dashboardRef,
+		"chart":                 chartRef,
+		"database":              databaseRef,
+		"sql":                   nullable(rs.sql),
+		"validator_type":        nullable(rs.validatorType),
+		"validator_config_json": rs.validatorConfigJSON,
+		"recipients":            recipients,
+		"working_timeout":       rs.workingTimeout,
+		"log_retention":         rs.logRetention,
+		"grace_period":          rs.gracePeriod,
+		"report_format":         rs.reportFormat,
+		"last_state":            "Not triggered",
+	}
+}
+
+func (s *Server) registerReportSchedules(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/v1/report/{$}", s.createReportSchedule)
+	mux.HandleFunc("GET /api/v1/report/{id}", s.getReportSchedule)
+	mux.HandleFunc("PUT /api/v1/report/{id}", s.updateReportSchedule)
+	mux.HandleFunc("DELETE /api/v1/report/{id}", s.deleteReportSchedule)
+}
+
+type reportRecipientBody struct {
+	Type                string `json:"type"`
+	RecipientConfigJSON struct {
+		Target string `json:"target"`
+	} `json:"recipient_config_json"`
+}
+
+// reportScheduleBody is the payload of report schedule create and update calls; nil fields were
+// not sent.
+type reportScheduleBody struct {
+	Type                *string                `json:"type"`
+	Name                *string                `json:"name"`
+	Description         *string                `json:"description"`
+	Active              *bool                  `json:"active"`
+	Crontab             *string                `json:"crontab"`
+	Timezone            *string                `json:"timezone"`
+	Dashboard           optional[int64]        `json:"dashboard"`
+	Chart               optional[int64]        `json:"chart"`
+	Database            optional[int64]        `json:"database"`
+	SQL                 optional[string]       `json:"sql"`
+	ValidatorType       optional[string]       `json:"validator_type"`
+	ValidatorConfigJSON json.RawMessage        `json:"validator_config_json"`
+	Recipients          *[]reportRecipientBody `json:"recipients"`
+	WorkingTimeout      *int64                 `json:"working_timeout"`
+	LogRetention        *int64                 `json:"log_retention"`
+	GracePeriod         *int64                 `json:"grace_period"`
+	ReportFormat        *string                `json:"report_format"`
+}
+
+// nullOnCreate returns the first target or alert field sent as null. Superset's POST schema
+// does not allow null for them, unlike the PUT schema, which clears them with it.
+func (b reportScheduleBody) nullOnCreate() string {
+	fields := []struct {
+		name string
+		null bool
+	}{
+		{"dashboard", b.Dashboard.null()},
+		{"chart", b.Chart.null()},
+		{"database", b.Database.null()},
+		{"sql", b.SQL.null()},
+		{"validator_type", b.ValidatorType.null()},
+	}
+	for _, f := range fields {
+		if f.null {
+			return f.name
+		}
+	}
+	return ""
+}
+
+func (b reportScheduleBody) apply(rs *reportSchedule) {
+	if b.Type != nil {
+		rs.typ = *b.Type
+	}
+	if b.Name != nil {
+		rs.name = *b.Name
+	}
+	if b.Description != nil {
+		rs.description = *b.Description
+	}
+	if b.Active != nil {
+		rs.active = *b.Active
+	}
+	if b.Crontab != nil {
+		rs.crontab = *b.Crontab
+	}
+	if b.Timezone != nil {
+		rs.timezone = *b.Timezone
+	}
+	b.Dashboard.assign(&rs.dashboardID)
+	b.Chart.assign(&rs.chartID)
+	b.Database.assign(&rs.databaseID)
+	b.SQL.assign(&rs.sql)
+	b.ValidatorType.assign(&rs.validatorType)
+	if b.ValidatorConfigJSON != nil {
+		// Superset stores the validator configuration as a string and returns it as sent.
+		rs.validatorConfigJSON = string(b.ValidatorConfigJSON)
+	}
+	if b.Recipients != nil {
+		rs.recipients = make([]reportRecipient, 0, len(*b.Recipients))
+		for _, r := range *b.Recipients {
+			rs.recipients = append(rs.recipients, reportRecipient{typ: r.Type, target: r.RecipientConfigJSON.Target})
+		}
+	}
+	if b.WorkingTimeout != nil {
+		rs.workingTimeout = *b.WorkingTimeout
+	}
+	if b.LogRetention != nil {
+		rs.logRetention = *b.LogRetention
+	}
+	if b.GracePeriod != nil {
+		rs.gracePeriod = *b.GracePeriod
+	}
+	if b.ReportFormat != nil {
+		rs.reportFormat = *b.ReportFormat
+	}
+}
+
+// validateReportSchedule returns a field validation message for rs, or nil when it is valid.
+// It checks the rules Superset's report schedule commands enforce.
+func (s *Server) validateReportSchedule(rs *reportSchedule) map[string]any {
+	if rs.typ != "Alert" && rs.typ != "Report" {
+		return map[string]any{"type": []string{"Must be one of: Alert, Report."}}
+	}
+	if rs.name == "" {
+		return map[string]any{"name": []string{"Missing data for required field."}}
+	}
+	for _, other := range s.reportSchedules.all() {
+		if other.id != rs.id && other.typ == rs.typ && other.name == rs.name {
+			return map[string]any{"name": []string{"Name must be unique"}}
+		}
+	}
+	if len(strings.Fields(rs.crontab)) != 5 {
+		return map[string]any{"crontab": []string{"Invalid cron expression"}}
+	}
+	switch {
+	case rs.dashboardID != 0 && rs.chartID != 0:
+		return map[string]any{"chart": "Choose a chart or dashboard not both"}
+	case rs.dashboardID == 0 && rs.chartID == 0:
+		return map[string]any{"dashboard": "Choose a chart or dashboard"}
+	}
+	if _, ok := s.dashboards.get(rs.dashboardID); rs.dashboardID != 0 && !ok {
+		return map[string]any{"dashboard": "Dashboard does not exist"}
+	}
+	if _, ok := s.charts.get(rs.chartID); rs.chartID != 0 && !ok {
+		return map[string]any{"chart": "Chart does not exist"}
+	}
+	if rs.typ == "Alert" {
+		if _, ok := s.databases.get(rs.databaseID); !ok {
+			return map[string]any{"database": "Database is required for alerts"}
+		}
+		if rs.sql == "" {
+			return map[string]any{"sql": []string{"Field may not be null."}}
+		}
+		if rs.validatorType != "not null" && rs.validatorType != "operator" {
+			return map[string]any{"validator_type": []string{"Must be one of: not null, operator."}}
+		}
+	}
+	if !json.Valid([]byte(rs.validatorConfigJSON)) {
+		return map[string]any{"validator_config_json": []string{"Not a valid JSON document."}}
+	}
+	if !slices.Contains([]string{"PNG", "CSV", "TEXT", "PDF"}, rs.reportFormat) {
+		return map[string]any{"report_format": []string{"Must be one of: PNG, CSV, TEXT, PDF."}}
+	}
+	for _, r := range rs.recipients {
+		if !slices.Contains([]string{"Email", "Slack", "SlackV2"}, r.typ) {
+			return map[string]any{"recipients": []string{"Must be one of: Email, Slack, SlackV2."}}
+		}
+	}
+	return nil
+}
+
+func (s *Server) createReportSchedule(w http.ResponseWriter, r *http.Request) {
+	var body reportScheduleBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	if field := body.nullOnCreate(); field != "" {
+		writeMessage(w, http.StatusBadRequest, map[string]any{field: []string{"Field may not be null."}})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rs := newReportSchedule()
+	body.apply(rs)
+	if msg := s.validateReportSchedule(rs); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	rs.id = s.reportSchedules.nextID()
+	s.reportSchedules.put(rs.id, rs)
+	writeJSON(w, http.StatusCreated, map[string]any{"id": rs.id, "result": map[string]any{
+		"type":    rs.typ,
+		"name":    rs.name,
+		"crontab": rs.crontab,
+	}})
+}
+
+func (s *Server) getReportSchedule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	rs, ok := s.reportSchedules.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": s.renderReportSchedule(rs)})
+}
+
+func (s *Server) updateReportSchedule(w http.ResponseWriter, r *http.Request) {
+	var body reportScheduleBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	existing, ok := s.reportSchedules.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	updated := *existing
+	body.apply(&updated)
+	if msg := s.validateReportSchedule(&updated); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	*existing = updated
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": map[string]any{
+		"type":    existing.typ,
+		"name":    existing.name,
+		"crontab": existing.crontab,
+	}})
+}
+
+func (s *Server) deleteReportSchedule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	if !s.reportSchedules.remove(id) {
+		writeNotFound(w)
+		return
+	}
+	writeOK(w)
+}
@@ -0,0 +1,182 @@
+package fakesuperset
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// risonDecode parses a Rison value, the dialect Superset list endpoints take in q=. Objects
+// decode to map[string]any, arrays to []any, numbers to float64, !t and !f to bool and !n to nil.
+func risonDecode(s string) (any, error) {
+	p := &risonParser{s: s}
+	v, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.s) {
+		return nil, fmt.Errorf("rison: unexpected %q at offset %d", p.s[p.pos:], p.pos)
+	}
+	return v, nil
+}
+
+type risonParser struct {
+	s   string
+	pos int
+}
+
+const risonNotIDChar = " '!:(),*@$"
+
+func (p *risonParser) value() (any, error) {
+	if p.pos >= len(p.s) {
+		return nil, fmt.Errorf("rison: unexpected end of input")
+	}
+
+	switch c := p.s[p.pos]; {
+	case c == '(':
+		return p.object()
+	case c == '\'':
+		return p.quoted()
+	case c == '!':
+		return p.bang()
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.number()
+	default:
+		id := p.ident()
+		if id == "" {
+			return nil, fmt.Errorf("rison: unexpected %q at offset %d", c, p.pos)
+		}
+		return id, nil
+	}
+}
+
+func (p *risonParser) object() (any, error) {
+	p.pos++ // (
+	obj := map[string]any{}
+	for {
+		if p.pos >= len(p.s) {
+			return nil, fmt.Errorf("rison: unterminated object")
+		}
+		if p.s[p.pos] == ')' {
+			p.pos++
+			return obj, nil
+		}
+		if len(obj) > 0 {
+			if p.s[p.pos] != ',' {
+				return nil, fmt.Errorf("rison: expected ',' at offset %d", p.pos)
+			}
+			p.pos++
+		}
+
+		key, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("rison: object key %v is not a string", key)
+		}
+		if p.pos >= len(p.s) || p.s[p.pos] != ':' {
+			return nil, fmt.Errorf("rison: expected ':' at offset %d", p.pos)
+		}
+		p.pos++
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		obj[name] = v
+	}
+}
+
+func (p *risonParser) array() (any, error) {
+	p.pos++ // (
+	arr := []any{}
+	for {
+		if p.pos >= len(p.s) {
+			return nil, fmt.Errorf("rison: unterminated array")
+		}
+		if p.s[p.pos] == ')' {
+			p.pos++
+			return arr, nil
+		}
+		if len(arr) > 0 {
+			if p.s[p.pos] != ',' {
+				return nil, fmt.Errorf("rison: expected ',' at offset %d", p.pos)
+			}
+			p.pos++
+		}
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+	}
+}
+
+func (p *risonParser) bang() (any, error) {
+	p.pos++ // !
+	if p.pos >= len(p.s) {
+		return nil, fmt.Errorf("rison: unexpected end of input after '!'")
+	}
+	c := p.s[p.pos]
+	p.pos++
+	switch c {
+	case 't':
+		return true, nil
+	case 'f':
+		return false, nil
+	case 'n':
+		return nil, nil
+	case '(':
+		p.pos--
+		return p.array()
+	}
+	return nil, fmt.Errorf("rison: unknown literal !%c", c)
+}
+
+func (p *risonParser) quoted() (any, error) {
+	p.pos++ // '
+	var b strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch c {
+		case '\'':
+			p.pos++
+			return b.String(), nil
+		case '!':
+			if p.pos+1 >= len(p.s) {
+				return nil, fmt.Errorf("rison: unterminated escape")
+			}
+			b.WriteByte(p.s[p.pos+1])
+			p.pos += 2
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return nil, fmt.Errorf("rison: unterminated string")
+}
+
+func (p *risonParser) number() (any, error) {
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("-0123456789.e", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	// Rison drops the '+' of positive exponents.
+	f, err := strconv.ParseFloat(strings.Replace(p.s[start:p.pos], "e", "e+", 1), 64)
+	if err != nil {
+		f, err = strconv.ParseFloat(p.s[start:p.pos], 64)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("rison: invalid number %q", p.s[start:p.pos])
+	}
+	return f, nil
+}
+
+func (p *risonParser) ident() string {
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune(risonNotIDChar, rune(p.s[p.pos])) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
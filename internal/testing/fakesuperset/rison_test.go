@@ -0,0 +1,48 @@
+package fakesuperset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRisonDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want any
+	}{
+		{name: "empty object", in: "()", want: map[string]any{}},
+		{name: "pagination", in: "(page:0,page_size:100)", want: map[string]any{"page": float64(0), "page_size": float64(100)}},
+		{name: "literals", in: "!(!t,!f,!n)", want: []any{true, false, nil}},
+		{name: "empty array", in: "!()", want: []any{}},
+		{name: "quoted string with escapes", in: "'it!'s a !!test'", want: "it's a !test"},
+		{name: "negative and exponent", in: "!(-1.5,2e3)", want: []any{-1.5, float64(2000)}},
+		{
+			name: "list query",
+			in:   "(columns:!(id),filters:!((col:uuid,opr:eq,value:'0b7f0f7e-3c1a-4f0e-9d3e-2f5c1c9f1a11')),page:0,page_size:1)",
+			want: map[string]any{
+				"columns":   []any{"id"},
+				"filters":   []any{map[string]any{"col": "uuid", "opr": "eq", "value": "0b7f0f7e-3c1a-4f0e-9d3e-2f5c1c9f1a11"}},
+				"page":      float64(0),
+				"page_size": float64(1),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := risonDecode(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRisonDecode_Errors(t *testing.T) {
+	for _, in := range []string{"", "(a:1", "!(1,2", "'open", "!x", "(a)", "(a:1)trailing"} {
+		_, err := risonDecode(in)
+		assert.Error(t, err, "input %q", in)
+	}
+}
@@ -0,0 +1,184 @@
+package fakesuperset
+
+import "net/http"
+
+type rlsRule struct {
+	id          int64
+	name        string
+	filterType  string
+	tables      []int64
+	roles       []int64
+	clause      string
+	groupKey    string
+	description string
+}
+
+func (s *Server) renderRLSRule(rule *rlsRule) map[string]any {
+	tables := make([]map[string]any, 0, len(rule.tables))
+	for _, id := range rule.tables {
+		if d, ok := s.datasets.get(id); ok {
+			tables = append(tables, map[string]any{"id": d.id, "table_name": d.tableName, "schema": d.schema})
+		}
+	}
+	roles := make([]map[string]any, 0, len(rule.roles))
+	for _, id := range rule.roles {
+		if r, ok := s.roles.get(id); ok {
+			roles = append(roles, r.render())
+		}
+	}
+	return map[string]any{
+		"id":          rule.id,
+		"name":        rule.name,
+		"filter_type": rule.filterType,
+		"tables":      tables,
+		"roles":       roles,
+		"clause":      rule.clause,
+		"group_key":   rule.groupKey,
+		"description": rule.description,
+	}
+}
+
+func (s *Server) registerRowLevelSecurity(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/rowlevelsecurity/{$}", s.listRLSRules)
+	mux.HandleFunc("POST /api/v1/rowlevelsecurity/{$}", s.createRLSRule)
+	mux.HandleFunc("GET /api/v1/rowlevelsecurity/{id}", s.getRLSRule)
+	mux.HandleFunc("PUT /api/v1/rowlevelsecurity/{id}", s.updateRLSRule)
+	mux.HandleFunc("DELETE /api/v1/rowlevelsecurity/{id}", s.deleteRLSRule)
+}
+
+// rlsBody is the payload of RLS create and update calls; nil fields were not sent.
+type rlsBody struct {
+	Name        *string `json:"name"`
+	FilterType  *string `json:"filter_type"`
+	Tables      []int64 `json:"tables"`
+	Roles       []int64 `json:"roles"`
+	Clause      *string `json:"clause"`
+	GroupKey    *string `json:"group_key"`
+	Description *string `json:"description"`
+}
+
+func (b rlsBody) apply(rule *rlsRule) {
+	if b.Name != nil {
+		rule.name = *b.Name
+	}
+	if b.FilterType != nil {
+		rule.filterType = *b.FilterType
+	}
+	if b.Tables != nil {
+		rule.tables = b.Tables
+	}
+	if b.Roles != nil {
+		rule.roles = b.Roles
+	}
+	if b.Clause != nil {
+		rule.clause = *b.Clause
+	}
+	if b.GroupKey != nil {
+		rule.groupKey = *b.GroupKey
+	}
+	if b.Description != nil {
+		rule.description = *b.Description
+	}
+}
+
+// validateRLSRule returns a field validation message for rule, or nil when it is valid.
+func (s *Server) validateRLSRule(rule *rlsRule) map[string]any {
+	if rule.name == "" {
+		return map[string]any{"name": []string{"Field may not be null."}}
+	}
+	if rule.filterType != "Regular" && rule.filterType != "Base" {
+		return map[string]any{"filter_type": []string{"Must be one of: Regular, Base."}}
+	}
+	for _, other := range s.rlsRules.all() {
+		if other.id != rule.id && other.name == rule.name {
+			return map[string]any{"name": []string{"Name must be unique"}}
+		}
+	}
+	for _, id := range rule.tables {
+		if _, ok := s.datasets.get(id); !ok {
+			return map[string]any{"tables": []string{"Datasource does not exist"}}
+		}
+	}
+	for _, id := range rule.roles {
+		if _, ok := s.roles.get(id); !ok {
+			return map[string]any{"roles": []string{"Some roles do not exist"}}
+		}
+	}
+	return nil
+}
+
+func (s *Server) listRLSRules(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]map[string]any, 0, s.rlsRules.len())
+	for _, rule := range s.rlsRules.all() {
+		items = append(items, s.renderRLSRule(rule))
+	}
+	s.writeList(w, r, items)
+}
+
+func (s *Server) createRLSRule(w http.ResponseWriter, r *http.Request) {
+	var body rlsBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rule := &rlsRule{tables: []int64{}, roles: []int64{}}
+	body.apply(rule)
+	if msg := s.validateRLSRule(rule); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	rule.id = s.rlsRules.nextID()
+	s.rlsRules.put(rule.id, rule)
+	writeJSON(w, http.StatusCreated, map[string]any{"id": rule.id, "result": s.renderRLSRule(rule)})
+}
+
+func (s *Server) getRLSRule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	rule, ok := s.rlsRules.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": s.renderRLSRule(rule)})
+}
+
+func (s *Server) updateRLSRule(w http.ResponseWriter, r *http.Request) {
+	var body rlsBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	existing, ok := s.rlsRules.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	updated := *existing
+	body.apply(&updated)
+	if msg := s.validateRLSRule(&updated); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	*existing = updated
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": s.renderRLSRule(existing)})
+}
+
+func (s *Server) deleteRLSRule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	if !s.rlsRules.remove(id) {
+		writeNotFound(w)
+		return
+	}
+	writeOK(w)
+}
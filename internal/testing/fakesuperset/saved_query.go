@@ -0,0 +1,173 @@
+package fakesuperset
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type savedQuery struct {
+	id                 int64
+	label              string
+	description        string
+	databaseID         int64
+	catalog            string
+	schema             string
+	sql                string
+	templateParameters string
+}
+
+func (s *Server) renderSavedQuery(q *savedQuery) map[string]any {
+	db := map[string]any{"id": q.databaseID}
+	if parent, ok := s.databases.get(q.databaseID); ok {
+		db["database_name"] = parent.name()
+	}
+	return map[string]any{
+		"id":                  q.id,
+		"label":               q.label,
+		"description":         q.description,
+		"database":            db,
+		"catalog":             nullable(q.catalog),
+		"schema":              nullable(q.schema),
+		"sql":                 q.sql,
+		"template_parameters": nullable(q.templateParameters),
+	}
+}
+
+func (s *Server) registerSavedQueries(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/saved_query/{$}", s.listSavedQueries)
+	mux.HandleFunc("POST /api/v1/saved_query/{$}", s.createSavedQuery)
+	mux.HandleFunc("GET /api/v1/saved_query/{id}", s.getSavedQuery)
+	mux.HandleFunc("PUT /api/v1/saved_query/{id}", s.updateSavedQuery)
+	mux.HandleFunc("DELETE /api/v1/saved_query/{id}", s.deleteSavedQuery)
+}
+
+// savedQueryBody is the payload of saved query create and update calls; nil fields were not
+// sent.
+type savedQueryBody struct {
+	Label              *string          `json:"label"`
+	Description        *string          `json:"description"`
+	DatabaseID         *int64           `json:"db_id"`
+	Catalog            optional[string] `json:"catalog"`
+	Schema             optional[string] `json:"schema"`
+	SQL                *string          `json:"sql"`
+	TemplateParameters optional[string] `json:"template_parameters"`
+}
+
+func (b savedQueryBody) apply(q *savedQuery) {
+	if b.Label != nil {
+		q.label = *b.Label
+	}
+	if b.Description != nil {
+		q.description = *b.Description
+	}
+	if b.DatabaseID != nil {
+		q.databaseID = *b.DatabaseID
+	}
+	if b.SQL != nil {
+		q.sql = *b.SQL
+	}
+	b.Catalog.assign(&q.catalog)
+	b.Schema.assign(&q.schema)
+	b.TemplateParameters.assign(&q.templateParameters)
+}
+
+// validateSavedQuery returns a field validation message for q, or nil when it is valid.
+func (s *Server) validateSavedQuery(q *savedQuery) map[string]any {
+	if _, ok := s.databases.get(q.databaseID); !ok {
+		return map[string]any{"db_id": []string{"Database does not exist"}}
+	}
+	if q.templateParameters != "" && !json.Valid([]byte(q.templateParameters)) {
+		return map[string]any{"template_parameters": []string{"Not a valid JSON document."}}
+	}
+	return nil
+}
+
+func (s *Server) listSavedQueries(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]map[string]any, 0, s.savedQueries.len())
+	for _, q := range s.savedQueries.all() {
+		items = append(items, s.renderSavedQuery(q))
+	}
+	s.writeList(w, r, items)
+}
+
+func (s *Server) createSavedQuery(w http.ResponseWriter, r *http.Request) {
+	var body savedQueryBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rejectCatalog(w, body.Catalog.set) {
+		return
+	}
+	q := &savedQuery{}
+	body.apply(q)
+	if msg := s.validateSavedQuery(q); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	q.id = s.savedQueries.nextID()
+	s.savedQueries.put(q.id, q)
+	writeJSON(w, http.StatusCreated, map[string]any{"id": q.id, "result": map[string]any{
+		"label": q.label,
+		"db_id": q.databaseID,
+		"sql":   q.sql,
+	}})
+}
+
+func (s *Server) getSavedQuery(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	q, ok := s.savedQueries.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": s.renderSavedQuery(q)})
+}
+
+func (s *Server) updateSavedQuery(w http.ResponseWriter, r *http.Request) {
+	var body savedQueryBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rejectCatalog(w, body.Catalog.set) {
+		return
+	}
+	id, _ := pathID(r)
+	existing, ok := s.savedQueries.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	updated := *existing
+	body.apply(&updated)
+	if msg := s.validateSavedQuery(&updated); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	*existing = updated
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": map[string]any{
+		"label": existing.label,
+		"db_id": existing.databaseID,
+		"sql":   existing.sql,
+	}})
+}
+
+func (s *Server) deleteSavedQuery(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	if !s.savedQueries.remove(id) {
+		writeNotFound(w)
+		return
+	}
+	writeOK(w)
+}
@@ -0,0 +1,474 @@
+package fakesuperset
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type role struct {
+	id   int64
+	name string
+}
+
+func (r *role) render() map[string]any {
+	return map[string]any{"id": r.id, "name": r.name}
+}
+
+type permissionView struct {
+	id         int64
+	permission string
+	viewMenu   string
+}
+
+func (p *permissionView) render() map[string]any {
+	return map[string]any{
+		"id":         p.id,
+		"permission": map[string]any{"name": p.permission},
+		"view_menu":  map[string]any{"name": p.viewMenu},
+	}
+}
+
+type user struct {
+	id        int64
+	username  string
+	firstName string
+	lastName  string
+	email     string
+	password  string
+	active    bool
+	roles     []int64
+}
+
+func (s *Server) renderUser(u *user) map[string]any {
+	roles := make([]map[string]any, 0, len(u.roles))
+	for _, id := range u.roles {
+		if r, ok := s.roles.get(id); ok {
+			roles = append(roles, r.render())
+		}
+	}
+	return map[string]any{
+		"id":         u.id,
+		"username":   u.username,
+		"first_name": u.firstName,
+		"last_name":  u.lastName,
+		"email":      u.email,
+		"active":     u.active,
+		"roles":      roles,
+	}
+}
+
+// AddRole seeds a role and returns its ID.
+func (s *Server) AddRole(name string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.roles.nextID()
+	s.roles.put(id, &role{id: id, name: name})
+	return id
+}
+
+// AddPermission seeds a permission on a view menu, as Superset registers them at startup,
+// and returns the ID of the pair.
+func (s *Server) AddPermission(permission, viewMenu string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.permissions.nextID()
+	s.permissions.put(id, &permissionView{id: id, permission: permission, viewMenu: viewMenu})
+	return id
+}
+
+// authorized checks the bearer token of every API call except login, and the CSRF token of
+// requests that send one. It answers 401 or 400 itself and reports false when it did.
+func (s *Server) authorized(w http.ResponseWriter, r *http.Request) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/api/v1/security/login":
+		return true
+	case "/api/v1/security/refresh":
+		if !s.refreshTokens[bearerToken(r)] {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"msg": "Invalid refresh token"})
+			return false
+		}
+		return true
+	}
+
+	if !s.accessTokens[bearerToken(r)] {
+		writeJSON(w, http.StatusUnauthorized, map[string]any{"msg": "Token has expired"})
+		return false
+	}
+	if token := r.Header.Get("X-CSRFToken"); token != "" && !s.csrfTokens[token] {
+		writeMessage(w, http.StatusBadRequest, "400 Bad Request: The CSRF token is invalid.")
+		return false
+	}
+	return true
+}
+
+func (s *Server) registerSecurity(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/v1/security/login", s.handleLogin)
+	mux.HandleFunc("POST /api/v1/security/refresh", s.handleRefresh)
+	mux.HandleFunc("GET /api/v1/security/csrf_token/{$}", s.handleCSRFToken)
+
+	mux.HandleFunc("GET /api/v1/security/roles", s.listRoles)
+	mux.HandleFunc("GET /api/v1/security/roles/{$}", s.listRoles)
+	mux.HandleFunc("POST /api/v1/security/roles/{$}", s.createRole)
+	mux.HandleFunc("GET /api/v1/security/roles/{id}", s.getRole)
+	mux.HandleFunc("PUT /api/v1/security/roles/{id}", s.updateRole)
+	mux.HandleFunc("DELETE /api/v1/security/roles/{id}", s.deleteRole)
+	mux.HandleFunc("GET /api/v1/security/roles/{id}/permissions/{$}", s.getRolePermissions)
+	mux.HandleFunc("POST /api/v1/security/roles/{id}/permissions", s.setRolePermissions)
+	mux.HandleFunc("GET /api/v1/security/permissions-resources", s.listPermissions)
+	mux.HandleFunc("GET /api/v1/security/permissions-resources/{$}", s.listPermissions)
+
+	mux.HandleFunc("GET /api/v1/security/users/{$}", s.listUsers)
+	mux.HandleFunc("POST /api/v1/security/users/{$}", s.createUser)
+	mux.HandleFunc("GET /api/v1/security/users/{id}", s.getUser)
+	mux.HandleFunc("PUT /api/v1/security/users/{id}", s.updateUser)
+	mux.HandleFunc("DELETE /api/v1/security/users/{id}", s.deleteUser)
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Refresh  bool   `json:"refresh"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.Username == "" || body.Password == "" {
+		writeMessage(w, http.StatusUnauthorized, "Not authorized")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.issued++
+	accessToken := fmt.Sprintf("access-token-%d", s.issued)
+	s.accessTokens[accessToken] = true
+	resp := map[string]any{"access_token": accessToken}
+	if body.Refresh {
+		refreshToken := fmt.Sprintf("refresh-token-%d", s.issued)
+		s.refreshTokens[refreshToken] = true
+		resp["refresh_token"] = refreshToken
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleRefresh(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.issued++
+	token := fmt.Sprintf("access-token-%d", s.issued)
+	s.accessTokens[token] = true
+	writeJSON(w, http.StatusOK, map[string]any{"access_token": token})
+}
+
+func (s *Server) handleCSRFToken(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.issued++
+	token := fmt.Sprintf("csrf-token-%d", s.issued)
+	s.csrfTokens[token] = true
+	http.SetCookie(w, &http.Cookie{Name: "session", Value: fmt.Sprintf("session-%d", s.issued), Path: "/", HttpOnly: true})
+	writeJSON(w, http.StatusOK, map[string]any{"result": token})
+}
+
+func (s *Server) listRoles(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]map[string]any, 0, s.roles.len())
+	for _, role := range s.roles.all() {
+		items = append(items, role.render())
+	}
+	s.writeList(w, r, items)
+}
+
+func (s *Server) roleByName(name string) *role {
+	for _, r := range s.roles.all() {
+		if r.name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+func (s *Server) createRole(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if strings.TrimSpace(body.Name) == "" {
+		writeMessage(w, http.StatusBadRequest, map[string]any{"name": []string{"Field may not be null."}})
+		return
+	}
+	if s.roleByName(body.Name) != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, fmt.Sprintf("Role %s already exists", body.Name))
+		return
+	}
+	id := s.roles.nextID()
+	s.roles.put(id, &role{id: id, name: body.Name})
+	writeJSON(w, http.StatusCreated, map[string]any{"id": id, "result": map[string]any{"name": body.Name}})
+}
+
+func (s *Server) getRole(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	role, ok := s.roles.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": role.render()})
+}
+
+func (s *Server) updateRole(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	role, ok := s.roles.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if other := s.roleByName(body.Name); other != nil && other.id != id {
+		writeMessage(w, http.StatusUnprocessableEntity, fmt.Sprintf("Role %s already exists", body.Name))
+		return
+	}
+	role.name = body.Name
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": map[string]any{"name": role.name}})
+}
+
+func (s *Server) deleteRole(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	if !s.roles.remove(id) {
+		writeNotFound(w)
+		return
+	}
+	delete(s.rolePermissions, id)
+	for _, u := range s.users.all() {
+		u.roles = withoutID(u.roles, id)
+	}
+	for _, d := range s.dashboards.all() {
+		d.roles = withoutID(d.roles, id)
+	}
+	for _, rule := range s.rlsRules.all() {
+		rule.roles = withoutID(rule.roles, id)
+	}
+	writeOK(w)
+}
+
+func (s *Server) getRolePermissions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	if _, ok := s.roles.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	result := make([]map[string]any, 0, len(s.rolePermissions[id]))
+	for _, pvID := range s.rolePermissions[id] {
+		if pv, ok := s.permissions.get(pvID); ok {
+			result = append(result, map[string]any{
+				"id":              pv.id,
+				"permission_name": pv.permission,
+				"view_menu_name":  pv.viewMenu,
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"result": result})
+}
+
+func (s *Server) setRolePermissions(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		PermissionViewMenuIDs []int64 `json:"permission_view_menu_ids"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	if _, ok := s.roles.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	ids := []int64{}
+	for _, pvID := range body.PermissionViewMenuIDs {
+		if _, ok := s.permissions.get(pvID); !ok {
+			writeMessage(w, http.StatusUnprocessableEntity, fmt.Sprintf("Permission view menu %d does not exist", pvID))
+			return
+		}
+		if !containsID(ids, pvID) {
+			ids = append(ids, pvID)
+		}
+	}
+	s.rolePermissions[id] = ids
+	writeJSON(w, http.StatusOK, map[string]any{"result": map[string]any{"permission_view_menu_ids": ids}})
+}
+
+func (s *Server) listPermissions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]map[string]any, 0, s.permissions.len())
+	for _, pv := range s.permissions.all() {
+		items = append(items, pv.render())
+	}
+	s.writeList(w, r, items)
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]map[string]any, 0, s.users.len())
+	for _, u := range s.users.all() {
+		items = append(items, s.renderUser(u))
+	}
+	s.writeList(w, r, items)
+}
+
+// userBody is the payload of user create and update calls; nil fields were not sent.
+type userBody struct {
+	Username  *string `json:"username"`
+	FirstName *string `json:"first_name"`
+	LastName  *string `json:"last_name"`
+	Email     *string `json:"email"`
+	Password  *string `json:"password"`
+	Active    *bool   `json:"active"`
+	Roles     []int64 `json:"roles"`
+}
+
+// validateUser checks the fields of u against the other users and the known roles and returns
+// a field validation message, or nil when u is valid.
+func (s *Server) validateUser(u *user) map[string]any {
+	switch {
+	case u.username == "":
+		return map[string]any{"username": []string{"Field may not be null."}}
+	case u.email == "":
+		return map[string]any{"email": []string{"Field may not be null."}}
+	}
+	for _, other := range s.users.all() {
+		if other.id == u.id {
+			continue
+		}
+		if other.username == u.username {
+			return map[string]any{"username": []string{"Already exists."}}
+		}
+		if other.email == u.email {
+			return map[string]any{"email": []string{"Already exists."}}
+		}
+	}
+	for _, id := range u.roles {
+		if _, ok := s.roles.get(id); !ok {
+			return map[string]any{"roles": []string{fmt.Sprintf("Role %d does not exist.", id)}}
+		}
+	}
+	return nil
+}
+
+func (b userBody) apply(u *user) {
+	if b.Username != nil {
+		u.username = *b.Username
+	}
+	if b.FirstName != nil {
+		u.firstName = *b.FirstName
+	}
+	if b.LastName != nil {
+		u.lastName = *b.LastName
+	}
+	if b.Email != nil {
+		u.email = *b.Email
+	}
+	if b.Password != nil {
+		u.password = *b.Password
+	}
+	if b.Active != nil {
+		u.active = *b.Active
+	}
+	if b.Roles != nil {
+		u.roles = b.Roles
+	}
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	var body userBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := &user{roles: []int64{}}
+	body.apply(u)
+	if msg := s.validateUser(u); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	u.id = s.users.nextID()
+	s.users.put(u.id, u)
+	writeJSON(w, http.StatusCreated, map[string]any{"id": u.id, "result": s.renderUser(u)})
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	u, ok := s.users.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": s.renderUser(u)})
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	var body userBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	existing, ok := s.users.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	updated := *existing
+	body.apply(&updated)
+	if msg := s.validateUser(&updated); msg != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, msg)
+		return
+	}
+	*existing = updated
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "result": s.renderUser(existing)})
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, _ := pathID(r)
+	if !s.users.remove(id) {
+		writeNotFound(w)
+		return
+	}
+	writeOK(w)
+}